@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/malamsyah/geo-service/internal/db"
 	"github.com/malamsyah/geo-service/internal/handler"
+	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/internal/service"
 	"github.com/malamsyah/geo-service/pkg/config"
 	"gorm.io/gorm"
 )
@@ -26,6 +29,14 @@ func main() {
 		panic(err)
 	}
 
+	if conf.TrashRetention > 0 {
+		purger := service.NewPurger(repository.NewPointRepository(dbConn), repository.NewContourRepository(dbConn), conf.TrashRetention)
+		go purger.Run(context.Background(), conf.PurgeInterval)
+	}
+
+	idempotencyPurger := service.NewIdempotencyPurger(repository.NewIdempotencyRepository(dbConn))
+	go idempotencyPurger.Run(context.Background(), conf.IdempotencyPurgeInterval)
+
 	fmt.Println("Setting up router...")
 	r := handler.SetupRouter(conf, dbConn)
 
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/db"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/internal/service"
+	"github.com/malamsyah/geo-service/pkg/config"
+)
+
+// shapefile-import loads the polygons of a zipped shapefile as contours, using
+// the same database settings as the server, into the named layer if any. It
+// exits with status 1 when any record failed.
+func main() {
+	file := flag.String("file", "", "path of the zipped shapefile")
+	mode := flag.String("mode", string(service.BulkModeAtomic), "atomic or best_effort")
+	layer := flag.String("layer", "", "name of the layer of the contours")
+	flag.Parse()
+
+	if *file == "" || !service.BulkMode(*mode).IsValid() {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	results, err := importShapefile(*file, service.BulkMode(*mode), *layer)
+	if err != nil {
+		panic(err)
+	}
+
+	created, failed := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Printf("record %d: %v\n", r.Record, r.Err)
+		case r.ID != 0:
+			created++
+		}
+	}
+
+	fmt.Printf("%d contours created, %d failed\n", created, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func importShapefile(path string, mode service.BulkMode, layerName string) ([]service.BulkResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	features, err := codec.DecodeShapefile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := config.Instance()
+	dbConn, err := db.ConnectPostgres(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Migrate(dbConn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Interrupting the import cancels the chunk being written.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if layerName != "" {
+		layer, err := repository.NewLayerRepository(dbConn).GetLayerByName(ctx, layerName)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = models.WithLayerScope(ctx, models.LayerScope{Contours: &layer.ID})
+	}
+
+	geometryService := service.NewGeometryService(repository.NewPointRepository(dbConn), repository.NewContourRepository(dbConn),
+		service.WithTxManager(repository.NewTxManager(dbConn)),
+	)
+
+	return geometryService.BulkCreateContours(ctx, features, mode)
+}
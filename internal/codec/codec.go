@@ -0,0 +1,96 @@
+package codec
+
+import (
+	"io"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeGeoJSON = "application/geo+json"
+	MediaTypeNDJSON  = "application/x-ndjson"
+	MediaTypeCSV     = "text/csv"
+	MediaTypeZip     = "application/zip"
+	MediaTypeKML     = "application/vnd.google-earth.kml+xml"
+	MediaTypeGPX     = "application/gpx+xml"
+)
+
+// Feature is a single geometry read from an import payload or written to an
+// export. Err is set when the feature itself could not be decoded, so callers
+// can report it without aborting the rest of the payload. Line is the line of
+// the payload the feature starts on, for line oriented formats, and Record the
+// 1-based record number for record oriented ones such as shapefiles.
+type Feature struct {
+	ID         uint
+	Geometry   models.Geometry
+	Properties map[string]any
+	Line       int
+	Record     int
+	Err        error
+}
+
+// Writer streams features to an export one at a time. Close must be called
+// once every feature has been written to terminate the document.
+type Writer interface {
+	Write(f Feature) error
+	Close() error
+}
+
+// Decode reads every feature from r according to the given media type.
+func Decode(mediaType string, r io.Reader) ([]Feature, error) {
+	switch mediaType {
+	case "", MediaTypeJSON, MediaTypeGeoJSON:
+		return DecodeFeatureCollection(r)
+	case MediaTypeNDJSON, "application/ndjson":
+		return DecodeNDJSON(r)
+	case MediaTypeCSV:
+		return DecodeCSV(r, CSVOptions{})
+	case MediaTypeZip, "application/x-zip-compressed":
+		return DecodeShapefile(r)
+	case MediaTypeKML:
+		return DecodeKML(r)
+	case MediaTypeGPX:
+		return DecodeGPX(r)
+	default:
+		return nil, constants.ErrUnsupportedMediaType
+	}
+}
+
+// NewWriter returns a Writer producing the given media type.
+func NewWriter(mediaType string, w io.Writer) (Writer, error) {
+	switch mediaType {
+	case MediaTypeNDJSON:
+		return NewNDJSONWriter(w), nil
+	case MediaTypeGeoJSON, MediaTypeJSON:
+		return NewFeatureCollectionWriter(w), nil
+	case MediaTypeCSV:
+		return NewCSVWriter(w, CSVOptions{WKTColumn: defaultWKTColumn}), nil
+	case MediaTypeKML:
+		return NewKMLWriter(w), nil
+	case MediaTypeGPX:
+		return NewGPXWriter(w), nil
+	default:
+		return nil, constants.ErrUnsupportedMediaType
+	}
+}
+
+// IsMixed reports whether documents of the media type usually mix points and
+// polygons, so that an import keeps only the features of the kind it targets.
+func IsMixed(mediaType string) bool {
+	return mediaType == MediaTypeKML || mediaType == MediaTypeGPX
+}
+
+// OfType drops the features decoded with a geometry type other than t.
+// Features that failed before their type was known are kept.
+func OfType(features []Feature, t models.Type) []Feature {
+	kept := make([]Feature, 0, len(features))
+	for _, f := range features {
+		if f.Geometry.Type == "" || f.Geometry.Type == t {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept
+}
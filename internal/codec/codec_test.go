@@ -0,0 +1,146 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name          string
+		mediaType     string
+		body          string
+		expected      []Feature
+		expectedErrs  []error
+		expectedError error
+	}{
+		{
+			name:      "FeatureCollection",
+			mediaType: MediaTypeGeoJSON,
+			body: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{}},
+				{"type":"Feature","geometry":null},
+				{"type":"Feature","geometry":{"type":"Point","coordinates":"a"}}
+			]}`,
+			expected: []Feature{
+				{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{1, 2}}},
+				{},
+				{},
+			},
+			expectedErrs: []error{nil, constants.ErrInvalidFeature, constants.ErrInvalidFeature},
+		},
+		{
+			name:          "NotAFeatureCollection",
+			mediaType:     MediaTypeJSON,
+			body:          `{"type":"Feature"}`,
+			expectedError: constants.ErrInvalidFeatureCollection,
+		},
+		{
+			name:      "NDJSON",
+			mediaType: MediaTypeNDJSON,
+			body: `{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]}}
+
+{"type":"Feature","geometry":{"type":"Point","coordinates":[3,4]}}
+not json`,
+			expected: []Feature{
+				{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{1, 2}}},
+				{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{3, 4}}},
+				{},
+			},
+			expectedErrs: []error{nil, nil, constants.ErrInvalidFeature},
+		},
+		{
+			name:          "UnsupportedMediaType",
+			mediaType:     "text/plain",
+			body:          "",
+			expectedError: constants.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			features, err := Decode(tt.mediaType, strings.NewReader(tt.body))
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, features, len(tt.expected))
+			for i, f := range features {
+				assert.Equal(t, tt.expected[i].Geometry, f.Geometry)
+				assert.True(t, errors.Is(f.Err, tt.expectedErrs[i]), "feature %d: %v", i, f.Err)
+			}
+		})
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	features := []Feature{
+		{ID: 1, Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{1.5, 2}}},
+		{ID: 2, Geometry: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}},
+	}
+
+	tests := []struct {
+		name          string
+		mediaType     string
+		features      []Feature
+		expected      string
+		expectedError error
+	}{
+		{
+			name:      "NDJSON",
+			mediaType: MediaTypeNDJSON,
+			features:  features[:1],
+			expected:  `{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[1.5,2]},"properties":{}}` + "\n",
+		},
+		{
+			name:      "FeatureCollection",
+			mediaType: MediaTypeGeoJSON,
+			features:  features,
+			expected: `{"type":"FeatureCollection","features":[` +
+				`{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[1.5,2]},"properties":{}},` +
+				`{"type":"Feature","id":2,"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]},"properties":{}}]}`,
+		},
+		{
+			name:      "EmptyFeatureCollection",
+			mediaType: MediaTypeGeoJSON,
+			expected:  `{"type":"FeatureCollection","features":[]}`,
+		},
+		{
+			name:      "CSV",
+			mediaType: MediaTypeCSV,
+			features:  features,
+			expected:  "id,wkt\n1,POINT(1.5 2)\n2,\"POLYGON((0 0,1 0,1 1,0 0))\"\n",
+		},
+		{
+			name:          "UnsupportedMediaType",
+			mediaType:     "application/pdf",
+			expectedError: constants.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(tt.mediaType, &buf)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			for _, f := range tt.features {
+				assert.NoError(t, w.Write(f))
+			}
+			assert.NoError(t, w.Close())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package codec
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+const (
+	defaultLonColumn = "lon"
+	defaultLatColumn = "lat"
+	defaultWKTColumn = "wkt"
+)
+
+// CSVOptions describes the layout of a CSV document. Columns are referenced by
+// header name, or by zero based index when the document has no header. A WKT
+// column takes precedence over lon/lat columns.
+type CSVOptions struct {
+	LonColumn string
+	LatColumn string
+	WKTColumn string
+	// Delimiter is detected from the first line when zero.
+	Delimiter rune
+	// Header is detected from the first record when nil.
+	Header *bool
+}
+
+// csvColumns holds the resolved column indexes of a CSV document, wkt being -1
+// when the geometry is read from lon/lat columns.
+type csvColumns struct {
+	lon int
+	lat int
+	wkt int
+}
+
+// DecodeCSV reads one point or polygon per CSV record. A record that cannot be
+// turned into a geometry is returned with its Err set and its Line pointing at
+// the offending line.
+func DecodeCSV(r io.Reader, opts CSVOptions) ([]Feature, error) {
+	br := bufio.NewReader(r)
+	if opts.Delimiter == 0 {
+		opts.Delimiter = sniffDelimiter(br)
+	}
+
+	reader := csv.NewReader(br)
+	reader.Comma = opts.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	first, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []Feature{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	hasHeader := isCSVHeader(first, opts)
+	if opts.Header != nil {
+		hasHeader = *opts.Header
+	}
+
+	columns, err := resolveCSVColumns(first, hasHeader, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]Feature, 0)
+	if !hasHeader {
+		line, _ := reader.FieldPos(0)
+		features = append(features, columns.feature(first, line))
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			features = append(features, Feature{Line: parseErr.Line, Err: fmt.Errorf("%w: %s", constants.ErrInvalidFeature, parseErr.Err.Error())})
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		features = append(features, columns.feature(record, line))
+	}
+
+	return features, nil
+}
+
+func (cols csvColumns) feature(record []string, line int) Feature {
+	f := Feature{Line: line}
+	if cols.wkt >= 0 {
+		if cols.wkt >= len(record) {
+			f.Err = fmt.Errorf("%w: missing wkt column", constants.ErrInvalidFeature)
+			return f
+		}
+
+		f.Geometry, f.Err = models.ParseWKT(record[cols.wkt])
+
+		return f
+	}
+
+	if cols.lon >= len(record) || cols.lat >= len(record) {
+		f.Err = fmt.Errorf("%w: missing lon/lat column", constants.ErrInvalidFeature)
+		return f
+	}
+
+	lon, err := strconv.ParseFloat(strings.TrimSpace(record[cols.lon]), 64)
+	if err != nil {
+		f.Err = fmt.Errorf("%w: invalid lon %q", constants.ErrInvalidFeature, record[cols.lon])
+		return f
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(record[cols.lat]), 64)
+	if err != nil {
+		f.Err = fmt.Errorf("%w: invalid lat %q", constants.ErrInvalidFeature, record[cols.lat])
+		return f
+	}
+
+	f.Geometry = models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{lon, lat}}
+
+	return f
+}
+
+func resolveCSVColumns(first []string, hasHeader bool, opts CSVOptions) (csvColumns, error) {
+	if !hasHeader {
+		return resolveCSVIndexes(opts)
+	}
+
+	find := func(names ...string) int {
+		for _, name := range names {
+			for i, field := range first {
+				if strings.EqualFold(strings.TrimSpace(field), name) {
+					return i
+				}
+			}
+		}
+
+		return -1
+	}
+
+	if opts.WKTColumn != "" {
+		cols := csvColumns{wkt: find(opts.WKTColumn)}
+		if cols.wkt < 0 {
+			return csvColumns{}, fmt.Errorf("%w: column %q not found", constants.ErrInvalidFeatureCollection, opts.WKTColumn)
+		}
+
+		return cols, nil
+	}
+
+	lonNames := []string{opts.LonColumn}
+	latNames := []string{opts.LatColumn}
+	if opts.LonColumn == "" {
+		lonNames = []string{defaultLonColumn, "lng", "longitude", "x"}
+	}
+
+	if opts.LatColumn == "" {
+		latNames = []string{defaultLatColumn, "latitude", "y"}
+	}
+
+	cols := csvColumns{lon: find(lonNames...), lat: find(latNames...), wkt: -1}
+	if cols.lon >= 0 && cols.lat >= 0 {
+		return cols, nil
+	}
+
+	if opts.LonColumn == "" && opts.LatColumn == "" {
+		if cols.wkt = find(defaultWKTColumn, "geometry", "geom"); cols.wkt >= 0 {
+			return cols, nil
+		}
+	}
+
+	return csvColumns{}, fmt.Errorf("%w: lon/lat columns not found", constants.ErrInvalidFeatureCollection)
+}
+
+func resolveCSVIndexes(opts CSVOptions) (csvColumns, error) {
+	index := func(column string, fallback int) (int, error) {
+		if column == "" {
+			return fallback, nil
+		}
+
+		i, err := strconv.Atoi(column)
+		if err != nil || i < 0 {
+			return 0, fmt.Errorf("%w: column %q must be an index without a header", constants.ErrInvalidFeatureCollection, column)
+		}
+
+		return i, nil
+	}
+
+	if opts.WKTColumn != "" {
+		wkt, err := index(opts.WKTColumn, 0)
+		return csvColumns{wkt: wkt}, err
+	}
+
+	lon, err := index(opts.LonColumn, 0)
+	if err != nil {
+		return csvColumns{}, err
+	}
+
+	lat, err := index(opts.LatColumn, 1)
+	if err != nil {
+		return csvColumns{}, err
+	}
+
+	return csvColumns{lon: lon, lat: lat, wkt: -1}, nil
+}
+
+// isCSVHeader reports whether the first record looks like a header, that is
+// whether it names one of the configured or well known columns, or holds no
+// coordinate at all.
+func isCSVHeader(first []string, opts CSVOptions) bool {
+	names := []string{opts.LonColumn, opts.LatColumn, opts.WKTColumn, defaultLonColumn, defaultLatColumn, defaultWKTColumn}
+	for _, field := range first {
+		for _, name := range names {
+			if name != "" && strings.EqualFold(strings.TrimSpace(field), name) {
+				return true
+			}
+		}
+	}
+
+	for _, field := range first {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(field), 64); err == nil {
+			return false
+		}
+
+		if _, err := models.ParseWKT(field); err == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sniffDelimiter picks the most frequent candidate delimiter outside quotes on
+// the first line, defaulting to a comma.
+func sniffDelimiter(br *bufio.Reader) rune {
+	line, _ := br.Peek(br.Size())
+	if idx := strings.IndexByte(string(line), '\n'); idx >= 0 {
+		line = line[:idx]
+	}
+
+	counts := map[rune]int{}
+	quoted := false
+	for _, c := range string(line) {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ',', ';', '\t', '|':
+			if !quoted {
+				counts[c]++
+			}
+		}
+	}
+
+	delimiter := ','
+	for _, c := range []rune{';', '\t', '|'} {
+		if counts[c] > counts[delimiter] {
+			delimiter = c
+		}
+	}
+
+	return delimiter
+}
+
+// CSVWriter streams features as CSV rows. Points are written as lon/lat
+// columns unless a WKT column is configured, which other geometries require.
+type CSVWriter struct {
+	w             *csv.Writer
+	opts          CSVOptions
+	headerWritten bool
+}
+
+func NewCSVWriter(w io.Writer, opts CSVOptions) *CSVWriter {
+	cw := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		cw.Comma = opts.Delimiter
+	}
+
+	if opts.LonColumn == "" {
+		opts.LonColumn = defaultLonColumn
+	}
+
+	if opts.LatColumn == "" {
+		opts.LatColumn = defaultLatColumn
+	}
+
+	return &CSVWriter{w: cw, opts: opts}
+}
+
+func (cw *CSVWriter) Write(f Feature) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	id := strconv.FormatUint(uint64(f.ID), 10)
+	if cw.opts.WKTColumn != "" {
+		return cw.w.Write([]string{id, f.Geometry.WKT()})
+	}
+
+	if !f.Geometry.IsPoint() {
+		return constants.ErrInvalidGeometryType
+	}
+
+	return cw.w.Write([]string{
+		id,
+		strconv.FormatFloat(f.Geometry.PointCoordinates[0], 'f', -1, 64),
+		strconv.FormatFloat(f.Geometry.PointCoordinates[1], 'f', -1, 64),
+	})
+}
+
+func (cw *CSVWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+func (cw *CSVWriter) writeHeader() error {
+	if cw.headerWritten || (cw.opts.Header != nil && !*cw.opts.Header) {
+		return nil
+	}
+
+	cw.headerWritten = true
+	if cw.opts.WKTColumn != "" {
+		return cw.w.Write([]string{"id", cw.opts.WKTColumn})
+	}
+
+	return cw.w.Write([]string{"id", cw.opts.LonColumn, cw.opts.LatColumn})
+}
@@ -0,0 +1,131 @@
+package codec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	noHeader := false
+	point := func(lon, lat float64) models.Geometry {
+		return models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{lon, lat}}
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		opts          CSVOptions
+		expected      []Feature
+		expectedErrs  []error
+		expectedError error
+	}{
+		{
+			name:         "DetectsHeaderAndDefaultColumns",
+			body:         "name,Longitude,Latitude\nA,1.5,2\nB,x,3\nC,4\n",
+			expected:     []Feature{{Geometry: point(1.5, 2), Line: 2}, {Line: 3}, {Line: 4}},
+			expectedErrs: []error{nil, constants.ErrInvalidFeature, constants.ErrInvalidFeature},
+		},
+		{
+			name:         "ConfiguredColumnsAndSemicolon",
+			body:         "x_coord;y_coord;note\n1;2;\"a;b\"\n",
+			opts:         CSVOptions{LonColumn: "x_coord", LatColumn: "y_coord"},
+			expected:     []Feature{{Geometry: point(1, 2), Line: 2}},
+			expectedErrs: []error{nil},
+		},
+		{
+			name:         "DetectsMissingHeader",
+			body:         "1\t2\n3\t4\n",
+			expected:     []Feature{{Geometry: point(1, 2), Line: 1}, {Geometry: point(3, 4), Line: 2}},
+			expectedErrs: []error{nil, nil},
+		},
+		{
+			name:         "IndexedColumnsWithoutHeader",
+			body:         "a,2,1\n",
+			opts:         CSVOptions{LonColumn: "2", LatColumn: "1", Header: &noHeader},
+			expected:     []Feature{{Geometry: point(1, 2), Line: 1}},
+			expectedErrs: []error{nil},
+		},
+		{
+			name: "WKTColumn",
+			body: "id|shape\n1|POINT(1 2)\n2|POLYGON((0 0,1 0,1 1,0 0))\n3|nope\n",
+			opts: CSVOptions{WKTColumn: "shape"},
+			expected: []Feature{
+				{Geometry: point(1, 2), Line: 2},
+				{Geometry: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}, Line: 3},
+				{Line: 4},
+			},
+			expectedErrs: []error{nil, nil, constants.ErrInvalidWKT},
+		},
+		{
+			name:          "MissingColumns",
+			body:          "name,city\nA,B\n",
+			expectedError: constants.ErrInvalidFeatureCollection,
+		},
+		{
+			name:     "Empty",
+			body:     "",
+			expected: []Feature{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			features, err := DecodeCSV(strings.NewReader(tt.body), tt.opts)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, features, len(tt.expected))
+			for i, f := range features {
+				assert.Equal(t, tt.expected[i].Geometry, f.Geometry)
+				assert.Equal(t, tt.expected[i].Line, f.Line)
+				assert.True(t, errors.Is(f.Err, tt.expectedErrs[i]), "feature %d: %v", i, f.Err)
+			}
+		})
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	noHeader := false
+	features := []Feature{{ID: 1, Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{1.5, 2}}}}
+
+	tests := []struct {
+		name     string
+		opts     CSVOptions
+		expected string
+	}{
+		{
+			name:     "DefaultColumns",
+			expected: "id,lon,lat\n1,1.5,2\n",
+		},
+		{
+			name:     "ConfiguredColumnsAndDelimiter",
+			opts:     CSVOptions{LonColumn: "x", LatColumn: "y", Delimiter: ';'},
+			expected: "id;x;y\n1;1.5;2\n",
+		},
+		{
+			name:     "WKTWithoutHeader",
+			opts:     CSVOptions{WKTColumn: "geom", Header: &noHeader},
+			expected: "1,POINT(1.5 2)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			w := NewCSVWriter(&buf, tt.opts)
+			for _, f := range features {
+				assert.NoError(t, w.Write(f))
+			}
+			assert.NoError(t, w.Close())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+const (
+	featureType           = "Feature"
+	featureCollectionType = "FeatureCollection"
+)
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         uint            `json:"id,omitempty"`
+	Geometry   models.Geometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+func newGeoJSONFeature(f Feature) geoJSONFeature {
+	properties := f.Properties
+	if properties == nil {
+		properties = map[string]any{}
+	}
+
+	return geoJSONFeature{
+		Type:       featureType,
+		ID:         f.ID,
+		Geometry:   f.Geometry,
+		Properties: properties,
+	}
+}
+
+type rawFeature struct {
+	Type       string          `json:"type"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type rawFeatureCollection struct {
+	Type     string            `json:"type"`
+	Features []json.RawMessage `json:"features"`
+}
+
+// DecodeFeatureCollection reads a GeoJSON FeatureCollection. A feature that
+// cannot be decoded is returned with its Err set.
+func DecodeFeatureCollection(r io.Reader) ([]Feature, error) {
+	var fc rawFeatureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, err
+	}
+
+	if fc.Type != featureCollectionType {
+		return nil, constants.ErrInvalidFeatureCollection
+	}
+
+	features := make([]Feature, 0, len(fc.Features))
+	for _, raw := range fc.Features {
+		features = append(features, decodeFeature(raw))
+	}
+
+	return features, nil
+}
+
+func decodeFeature(data []byte) Feature {
+	var raw rawFeature
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Feature{Err: fmt.Errorf("%w: %s", constants.ErrInvalidFeature, err.Error())}
+	}
+
+	if raw.Type != featureType || len(raw.Geometry) == 0 || string(raw.Geometry) == "null" {
+		return Feature{Err: constants.ErrInvalidFeature}
+	}
+
+	var geometry models.Geometry
+	if err := json.Unmarshal(raw.Geometry, &geometry); err != nil {
+		return Feature{Err: fmt.Errorf("%w: %s", constants.ErrInvalidFeature, err.Error())}
+	}
+
+	return Feature{Geometry: geometry, Properties: raw.Properties}
+}
+
+// FeatureCollectionWriter streams features as a single GeoJSON
+// FeatureCollection.
+type FeatureCollectionWriter struct {
+	w       io.Writer
+	written int
+}
+
+func NewFeatureCollectionWriter(w io.Writer) *FeatureCollectionWriter {
+	return &FeatureCollectionWriter{w: w}
+}
+
+func (fw *FeatureCollectionWriter) Write(f Feature) error {
+	prefix := ","
+	if fw.written == 0 {
+		prefix = `{"type":"FeatureCollection","features":[`
+	}
+
+	data, err := json.Marshal(newGeoJSONFeature(f))
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(fw.w, prefix); err != nil {
+		return err
+	}
+
+	if _, err := fw.w.Write(data); err != nil {
+		return err
+	}
+
+	fw.written++
+
+	return nil
+}
+
+func (fw *FeatureCollectionWriter) Close() error {
+	if fw.written == 0 {
+		_, err := io.WriteString(fw.w, `{"type":"FeatureCollection","features":[]}`)
+		return err
+	}
+
+	_, err := io.WriteString(fw.w, "]}")
+
+	return err
+}
@@ -0,0 +1,232 @@
+package codec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+const minTrackRingSize = 3
+
+type gpxDocument struct {
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []gpxRoute `xml:"rte"`
+	Tracks    []gpxTrack `xml:"trk"`
+}
+
+type gpxPoint struct {
+	Lat         string `xml:"lat,attr"`
+	Lon         string `xml:"lon,attr"`
+	Elevation   string `xml:"ele,omitempty"`
+	Time        string `xml:"time,omitempty"`
+	Name        string `xml:"name,omitempty"`
+	Description string `xml:"desc,omitempty"`
+}
+
+type gpxRoute struct {
+	Name        string     `xml:"name,omitempty"`
+	Description string     `xml:"desc,omitempty"`
+	Points      []gpxPoint `xml:"rtept"`
+}
+
+type gpxTrack struct {
+	XMLName     xml.Name     `xml:"trk"`
+	Name        string       `xml:"name,omitempty"`
+	Description string       `xml:"desc,omitempty"`
+	Segments    []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+// DecodeGPX reads the waypoints of a GPX document as points, and its routes
+// and track segments as polygons, closing rings walked around an area when
+// the last position does not repeat the first. Records are numbered across
+// waypoints, routes and tracks in document order.
+func DecodeGPX(r io.Reader) ([]Feature, error) {
+	var doc gpxDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", constants.ErrInvalidFeatureCollection, err.Error())
+	}
+
+	features := make([]Feature, 0, len(doc.Waypoints))
+	record := 0
+
+	for _, wpt := range doc.Waypoints {
+		record++
+		f := Feature{Record: record, Properties: gpxProperties(wpt.Name, wpt.Description)}
+		if wpt.Elevation != "" {
+			f.Properties = withProperty(f.Properties, "elevation", wpt.Elevation)
+		}
+
+		if wpt.Time != "" {
+			f.Properties = withProperty(f.Properties, "time", wpt.Time)
+		}
+
+		position, err := wpt.position()
+		f.Geometry = models.Geometry{Type: models.PointType, PointCoordinates: position}
+		f.Err = err
+		features = append(features, f)
+	}
+
+	for _, rte := range doc.Routes {
+		record++
+		features = append(features, gpxRing(record, gpxProperties(rte.Name, rte.Description), rte.Points))
+	}
+
+	for _, trk := range doc.Tracks {
+		record++
+		for _, segment := range trk.Segments {
+			features = append(features, gpxRing(record, gpxProperties(trk.Name, trk.Description), segment.Points))
+		}
+	}
+
+	return features, nil
+}
+
+func (p gpxPoint) position() ([2]float64, error) {
+	lon, err := strconv.ParseFloat(strings.TrimSpace(p.Lon), 64)
+	if err != nil {
+		return [2]float64{}, fmt.Errorf("%w: invalid lon %q", constants.ErrInvalidFeature, p.Lon)
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(p.Lat), 64)
+	if err != nil {
+		return [2]float64{}, fmt.Errorf("%w: invalid lat %q", constants.ErrInvalidFeature, p.Lat)
+	}
+
+	return [2]float64{lon, lat}, nil
+}
+
+func gpxRing(record int, properties map[string]any, points []gpxPoint) Feature {
+	f := Feature{Record: record, Properties: properties, Geometry: models.Geometry{Type: models.PolygonType}}
+
+	ring := make([][2]float64, 0, len(points)+1)
+	for _, p := range points {
+		position, err := p.position()
+		if err != nil {
+			f.Err = err
+			return f
+		}
+
+		ring = append(ring, position)
+	}
+
+	if len(ring) < minTrackRingSize {
+		f.Err = fmt.Errorf("%w: an area needs at least %d positions", constants.ErrInvalidFeature, minTrackRingSize)
+		return f
+	}
+
+	if ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+
+	f.Geometry.PolygonCoordinates = [][][2]float64{ring}
+
+	return f
+}
+
+func gpxProperties(name, description string) map[string]any {
+	var properties map[string]any
+	if name = strings.TrimSpace(name); name != "" {
+		properties = withProperty(properties, nameProperty, name)
+	}
+
+	if description = strings.TrimSpace(description); description != "" {
+		properties = withProperty(properties, descriptionProperty, description)
+	}
+
+	return properties
+}
+
+func withProperty(properties map[string]any, key string, value any) map[string]any {
+	if properties == nil {
+		properties = make(map[string]any)
+	}
+
+	properties[key] = value
+
+	return properties
+}
+
+// GPXWriter streams points as waypoints and polygons as tracks holding one
+// segment per outer ring, GPX having no notion of holes.
+type GPXWriter struct {
+	w             io.Writer
+	enc           *xml.Encoder
+	headerWritten bool
+}
+
+func NewGPXWriter(w io.Writer) *GPXWriter {
+	return &GPXWriter{w: w, enc: xml.NewEncoder(w)}
+}
+
+func (gw *GPXWriter) Write(f Feature) error {
+	if err := gw.writeHeader(); err != nil {
+		return err
+	}
+
+	name, description, _ := splitProperties(f.Properties)
+
+	switch f.Geometry.Type {
+	case models.PointType:
+		wpt := newGPXPoint(f.Geometry.PointCoordinates)
+		wpt.Name, wpt.Description = name, description
+
+		return gw.enc.EncodeElement(wpt, xml.StartElement{Name: xml.Name{Local: "wpt"}})
+	case models.PolygonType, models.MultiPolygon:
+		polygons := f.Geometry.MultiPolygonCoordinates
+		if f.Geometry.IsPolygon() {
+			polygons = [][][][2]float64{f.Geometry.PolygonCoordinates}
+		}
+
+		trk := gpxTrack{Name: name, Description: description}
+		for _, polygon := range polygons {
+			if len(polygon) == 0 {
+				continue
+			}
+
+			segment := gpxSegment{Points: make([]gpxPoint, len(polygon[0]))}
+			for i, position := range polygon[0] {
+				segment.Points[i] = newGPXPoint(position)
+			}
+
+			trk.Segments = append(trk.Segments, segment)
+		}
+
+		return gw.enc.Encode(trk)
+	default:
+		return constants.ErrInvalidGeometryType
+	}
+}
+
+func (gw *GPXWriter) Close() error {
+	if err := gw.writeHeader(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(gw.w, "</gpx>\n")
+
+	return err
+}
+
+func (gw *GPXWriter) writeHeader() error {
+	if gw.headerWritten {
+		return nil
+	}
+
+	gw.headerWritten = true
+	_, err := io.WriteString(gw.w, xml.Header+`<gpx version="1.1" creator="geo-service" xmlns="http://www.topografix.com/GPX/1/1">`)
+
+	return err
+}
+
+func newGPXPoint(position [2]float64) gpxPoint {
+	return gpxPoint{Lon: formatFloat(position[0]), Lat: formatFloat(position[1])}
+}
@@ -0,0 +1,64 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeGPX(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="handheld" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="-6.2" lon="106.8"><ele>15</ele><time>2024-05-01T08:00:00Z</time><name>Camp</name><desc>Base camp</desc></wpt>
+  <wpt lat="x" lon="106.8"></wpt>
+  <rte><name>Short</name><rtept lat="0" lon="0"/><rtept lat="1" lon="1"/></rte>
+  <trk>
+    <name>Perimeter</name>
+    <trkseg><trkpt lat="0" lon="0"/><trkpt lat="0" lon="1"/><trkpt lat="1" lon="1"/></trkseg>
+    <trkseg><trkpt lat="5" lon="5"/><trkpt lat="5" lon="6"/><trkpt lat="6" lon="6"/><trkpt lat="5" lon="5"/></trkseg>
+  </trk>
+</gpx>`
+
+	features, err := Decode(MediaTypeGPX, strings.NewReader(doc))
+	assert.NoError(t, err)
+	assert.Len(t, features, 5)
+
+	assert.Equal(t, models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{106.8, -6.2}}, features[0].Geometry)
+	assert.Equal(t, map[string]any{"name": "Camp", "description": "Base camp", "elevation": "15", "time": "2024-05-01T08:00:00Z"}, features[0].Properties)
+	assert.ErrorIs(t, features[1].Err, constants.ErrInvalidFeature)
+	assert.ErrorIs(t, features[2].Err, constants.ErrInvalidFeature)
+
+	// The open segment is closed, the closed one is kept as is.
+	assert.Equal(t, [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, features[3].Geometry.PolygonCoordinates)
+	assert.Equal(t, [][][2]float64{{{5, 5}, {6, 5}, {6, 6}, {5, 5}}}, features[4].Geometry.PolygonCoordinates)
+	assert.Equal(t, []int{4, 4}, []int{features[3].Record, features[4].Record})
+	assert.Equal(t, "Perimeter", features[4].Properties["name"])
+
+	assert.Len(t, OfType(features, models.PolygonType), 3)
+}
+
+func TestGPXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewGPXWriter(&buf)
+	assert.NoError(t, w.Write(Feature{
+		ID:         1,
+		Geometry:   models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{1.5, 2}},
+		Properties: map[string]any{"name": "Camp", "description": "Base camp"},
+	}))
+	assert.NoError(t, w.Write(Feature{
+		ID:       2,
+		Geometry: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}, {{0.1, 0.1}, {0.2, 0.1}, {0.2, 0.2}, {0.1, 0.1}}}},
+	}))
+	assert.NoError(t, w.Close())
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<gpx version="1.1" creator="geo-service" xmlns="http://www.topografix.com/GPX/1/1">` +
+		`<wpt lat="2" lon="1.5"><name>Camp</name><desc>Base camp</desc></wpt>` +
+		`<trk><trkseg><trkpt lat="0" lon="0"></trkpt><trkpt lat="0" lon="1"></trkpt><trkpt lat="1" lon="1"></trkpt><trkpt lat="0" lon="0"></trkpt></trkseg></trk>` +
+		"</gpx>\n"
+	assert.Equal(t, expected, buf.String())
+}
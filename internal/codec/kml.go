@@ -0,0 +1,355 @@
+package codec
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+const (
+	nameProperty        = "name"
+	descriptionProperty = "description"
+)
+
+type kmlPlacemark struct {
+	XMLName       xml.Name          `xml:"Placemark"`
+	ID            string            `xml:"id,attr,omitempty"`
+	Name          string            `xml:"name,omitempty"`
+	Description   string            `xml:"description,omitempty"`
+	ExtendedData  *kmlExtendedData  `xml:"ExtendedData,omitempty"`
+	Point         *kmlPoint         `xml:"Point,omitempty"`
+	Polygon       *kmlPolygon       `xml:"Polygon,omitempty"`
+	MultiGeometry *kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+	LineString    *kmlPoint         `xml:"LineString,omitempty"`
+}
+
+type kmlExtendedData struct {
+	Data       []kmlData       `xml:"Data"`
+	SchemaData []kmlSchemaData `xml:"SchemaData"`
+}
+
+type kmlSchemaData struct {
+	SimpleData []kmlSimpleData `xml:"SimpleData"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlSimpleData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	Outer kmlBoundary   `xml:"outerBoundaryIs"`
+	Inner []kmlBoundary `xml:"innerBoundaryIs"`
+}
+
+type kmlBoundary struct {
+	Coordinates string `xml:"LinearRing>coordinates"`
+}
+
+type kmlMultiGeometry struct {
+	Points          []kmlPoint         `xml:"Point"`
+	Polygons        []kmlPolygon       `xml:"Polygon"`
+	LineStrings     []kmlPoint         `xml:"LineString"`
+	MultiGeometries []kmlMultiGeometry `xml:"MultiGeometry"`
+}
+
+// DecodeKML reads every Placemark of a KML document, whatever the folders
+// they are nested in. A MultiGeometry yields one feature per geometry, all
+// sharing the Record of their Placemark. The name, description and extended
+// data of the Placemark become the feature properties.
+func DecodeKML(r io.Reader) ([]Feature, error) {
+	decoder := xml.NewDecoder(r)
+	features := make([]Feature, 0)
+	record := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return features, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", constants.ErrInvalidFeatureCollection, err.Error())
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Placemark" {
+			continue
+		}
+
+		var placemark kmlPlacemark
+		if err := decoder.DecodeElement(&placemark, &start); err != nil {
+			return nil, fmt.Errorf("%w: %s", constants.ErrInvalidFeatureCollection, err.Error())
+		}
+
+		record++
+		features = append(features, placemark.features(record)...)
+	}
+}
+
+func (p kmlPlacemark) features(record int) []Feature {
+	f := Feature{Record: record, Properties: p.properties()}
+
+	geometries := make([]models.Geometry, 0)
+	var err error
+
+	switch {
+	case p.Point != nil:
+		geometries, err = kmlMultiGeometry{Points: []kmlPoint{*p.Point}}.geometries(geometries)
+	case p.Polygon != nil:
+		geometries, err = kmlMultiGeometry{Polygons: []kmlPolygon{*p.Polygon}}.geometries(geometries)
+	case p.MultiGeometry != nil:
+		geometries, err = p.MultiGeometry.geometries(geometries)
+	case p.LineString != nil:
+		err = constants.ErrInvalidGeometryType
+	default:
+		err = fmt.Errorf("%w: placemark without geometry", constants.ErrInvalidFeature)
+	}
+
+	if err != nil {
+		f.Err = err
+		return []Feature{f}
+	}
+
+	features := make([]Feature, len(geometries))
+	for i, g := range geometries {
+		features[i] = f
+		features[i].Geometry = g
+	}
+
+	return features
+}
+
+func (p kmlPlacemark) properties() map[string]any {
+	properties := make(map[string]any)
+	if p.ExtendedData != nil {
+		for _, d := range p.ExtendedData.Data {
+			properties[d.Name] = d.Value
+		}
+
+		for _, schemaData := range p.ExtendedData.SchemaData {
+			for _, d := range schemaData.SimpleData {
+				properties[d.Name] = d.Value
+			}
+		}
+	}
+
+	if name := strings.TrimSpace(p.Name); name != "" {
+		properties[nameProperty] = name
+	}
+
+	if description := strings.TrimSpace(p.Description); description != "" {
+		properties[descriptionProperty] = description
+	}
+
+	if len(properties) == 0 {
+		return nil
+	}
+
+	return properties
+}
+
+// geometries appends the points and polygons of the MultiGeometry, recursing
+// into nested ones. Line strings cannot be stored and fail the Placemark.
+func (m kmlMultiGeometry) geometries(geometries []models.Geometry) ([]models.Geometry, error) {
+	if len(m.LineStrings) > 0 {
+		return nil, constants.ErrInvalidGeometryType
+	}
+
+	for _, p := range m.Points {
+		positions, err := parseKMLCoordinates(p.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(positions) != 1 {
+			return nil, fmt.Errorf("%w: point must have one position", constants.ErrInvalidFeature)
+		}
+
+		geometries = append(geometries, models.Geometry{Type: models.PointType, PointCoordinates: positions[0]})
+	}
+
+	for _, p := range m.Polygons {
+		rings := make([][][2]float64, 0, 1+len(p.Inner))
+		for _, boundary := range append([]kmlBoundary{p.Outer}, p.Inner...) {
+			ring, err := parseKMLCoordinates(boundary.Coordinates)
+			if err != nil {
+				return nil, err
+			}
+
+			rings = append(rings, ring)
+		}
+
+		geometries = append(geometries, models.Geometry{Type: models.PolygonType, PolygonCoordinates: rings})
+	}
+
+	for _, nested := range m.MultiGeometries {
+		var err error
+		if geometries, err = nested.geometries(geometries); err != nil {
+			return nil, err
+		}
+	}
+
+	return geometries, nil
+}
+
+// parseKMLCoordinates reads whitespace separated lon,lat[,alt] tuples.
+func parseKMLCoordinates(s string) ([][2]float64, error) {
+	positions := make([][2]float64, 0)
+	for _, tuple := range strings.Fields(s) {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("%w: invalid coordinates %q", constants.ErrInvalidFeature, tuple)
+		}
+
+		lon, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid coordinates %q", constants.ErrInvalidFeature, tuple)
+		}
+
+		lat, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid coordinates %q", constants.ErrInvalidFeature, tuple)
+		}
+
+		positions = append(positions, [2]float64{lon, lat})
+	}
+
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("%w: missing coordinates", constants.ErrInvalidFeature)
+	}
+
+	return positions, nil
+}
+
+func formatKMLCoordinates(positions [][2]float64) string {
+	tuples := make([]string, len(positions))
+	for i, p := range positions {
+		tuples[i] = formatFloat(p[0]) + "," + formatFloat(p[1])
+	}
+
+	return strings.Join(tuples, " ")
+}
+
+// KMLWriter streams features as the Placemarks of a single KML Document. The
+// name and description properties fill the matching Placemark elements, the
+// other properties go to its ExtendedData.
+type KMLWriter struct {
+	w             io.Writer
+	enc           *xml.Encoder
+	headerWritten bool
+}
+
+func NewKMLWriter(w io.Writer) *KMLWriter {
+	return &KMLWriter{w: w, enc: xml.NewEncoder(w)}
+}
+
+func (kw *KMLWriter) Write(f Feature) error {
+	if err := kw.writeHeader(); err != nil {
+		return err
+	}
+
+	placemark := kmlPlacemark{ID: strconv.FormatUint(uint64(f.ID), 10)}
+	var data []kmlData
+	placemark.Name, placemark.Description, data = splitProperties(f.Properties)
+	if len(data) > 0 {
+		placemark.ExtendedData = &kmlExtendedData{Data: data}
+	}
+
+	switch f.Geometry.Type {
+	case models.PointType:
+		placemark.Point = &kmlPoint{Coordinates: formatKMLCoordinates([][2]float64{f.Geometry.PointCoordinates})}
+	case models.PolygonType:
+		placemark.Polygon = newKMLPolygon(f.Geometry.PolygonCoordinates)
+	case models.MultiPolygon:
+		placemark.MultiGeometry = &kmlMultiGeometry{}
+		for _, polygon := range f.Geometry.MultiPolygonCoordinates {
+			placemark.MultiGeometry.Polygons = append(placemark.MultiGeometry.Polygons, *newKMLPolygon(polygon))
+		}
+	default:
+		return constants.ErrInvalidGeometryType
+	}
+
+	return kw.enc.Encode(placemark)
+}
+
+func (kw *KMLWriter) Close() error {
+	if err := kw.writeHeader(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(kw.w, "</Document></kml>\n")
+
+	return err
+}
+
+func (kw *KMLWriter) writeHeader() error {
+	if kw.headerWritten {
+		return nil
+	}
+
+	kw.headerWritten = true
+	_, err := io.WriteString(kw.w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document>`)
+
+	return err
+}
+
+func newKMLPolygon(rings [][][2]float64) *kmlPolygon {
+	polygon := &kmlPolygon{}
+	for i, ring := range rings {
+		boundary := kmlBoundary{Coordinates: formatKMLCoordinates(ring)}
+		if i == 0 {
+			polygon.Outer = boundary
+			continue
+		}
+
+		polygon.Inner = append(polygon.Inner, boundary)
+	}
+
+	return polygon
+}
+
+// splitProperties extracts the name and description properties, matched case
+// insensitively, and returns the others sorted by key.
+func splitProperties(properties map[string]any) (string, string, []kmlData) {
+	var name, description string
+	data := make([]kmlData, 0, len(properties))
+	for key, value := range properties {
+		if value == nil {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(key, nameProperty) && name == "":
+			name = fmt.Sprint(value)
+		case strings.EqualFold(key, descriptionProperty) && description == "":
+			description = fmt.Sprint(value)
+		default:
+			data = append(data, kmlData{Name: key, Value: fmt.Sprint(value)})
+		}
+	}
+
+	sort.Slice(data, func(i, j int) bool { return data[i].Name < data[j].Name })
+
+	return name, description, data
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeKML(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2"><Document><Folder>
+  <Placemark>
+    <name>Well</name>
+    <description>Hand pump</description>
+    <ExtendedData><Data name="depth"><value>12</value></Data></ExtendedData>
+    <Point><coordinates>106.8,-6.2,15</coordinates></Point>
+  </Placemark>
+  <Placemark>
+    <name>Fields</name>
+    <MultiGeometry>
+      <Polygon>
+        <outerBoundaryIs><LinearRing><coordinates>0,0 10,0 10,10 0,0</coordinates></LinearRing></outerBoundaryIs>
+        <innerBoundaryIs><LinearRing><coordinates>1,1 2,1 2,2 1,1</coordinates></LinearRing></innerBoundaryIs>
+      </Polygon>
+      <MultiGeometry><Point><coordinates>5,5</coordinates></Point></MultiGeometry>
+    </MultiGeometry>
+  </Placemark>
+  <Placemark><name>Road</name><LineString><coordinates>0,0 1,1</coordinates></LineString></Placemark>
+  <Placemark><Point><coordinates>a,b</coordinates></Point></Placemark>
+</Folder></Document></kml>`
+
+	features, err := Decode(MediaTypeKML, strings.NewReader(doc))
+	assert.NoError(t, err)
+	assert.Len(t, features, 5)
+
+	assert.Equal(t, models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{106.8, -6.2}}, features[0].Geometry)
+	assert.Equal(t, map[string]any{"name": "Well", "description": "Hand pump", "depth": "12"}, features[0].Properties)
+	assert.Equal(t, 1, features[0].Record)
+
+	assert.Equal(t, models.PolygonType, features[1].Geometry.Type)
+	assert.Len(t, features[1].Geometry.PolygonCoordinates, 2)
+	assert.Equal(t, models.PointType, features[2].Geometry.Type)
+	assert.Equal(t, []int{2, 2}, []int{features[1].Record, features[2].Record})
+	assert.Equal(t, "Fields", features[2].Properties["name"])
+
+	assert.ErrorIs(t, features[3].Err, constants.ErrInvalidGeometryType)
+	assert.ErrorIs(t, features[4].Err, constants.ErrInvalidFeature)
+
+	points := OfType(features, models.PointType)
+	assert.Len(t, points, 4, "the polygon is dropped, failed placemarks are kept")
+}
+
+func TestDecodeKMLInvalidDocument(t *testing.T) {
+	_, err := DecodeKML(strings.NewReader("<kml><Placemark>"))
+	assert.ErrorIs(t, err, constants.ErrInvalidFeatureCollection)
+}
+
+func TestKMLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewKMLWriter(&buf)
+	assert.NoError(t, w.Write(Feature{
+		ID:         1,
+		Geometry:   models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{1.5, 2}},
+		Properties: map[string]any{"NAME": "Well & pump", "depth": 12, "owner": nil},
+	}))
+	assert.NoError(t, w.Write(Feature{
+		ID:       2,
+		Geometry: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}},
+	}))
+	assert.NoError(t, w.Close())
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<kml xmlns="http://www.opengis.net/kml/2.2"><Document>` +
+		`<Placemark id="1"><name>Well &amp; pump</name><ExtendedData><Data name="depth"><value>12</value></Data></ExtendedData><Point><coordinates>1.5,2</coordinates></Point></Placemark>` +
+		`<Placemark id="2"><Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs></Polygon></Placemark>` +
+		"</Document></kml>\n"
+	assert.Equal(t, expected, buf.String())
+
+	features, err := DecodeKML(&buf)
+	assert.NoError(t, err)
+	assert.Len(t, features, 2)
+	assert.Equal(t, "Well & pump", features[0].Properties["name"])
+}
@@ -0,0 +1,52 @@
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+const maxNDJSONLineSize = 64 * 1024 * 1024
+
+// DecodeNDJSON reads newline delimited GeoJSON features, one per line. Blank
+// lines are skipped.
+func DecodeNDJSON(r io.Reader) ([]Feature, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	features := make([]Feature, 0)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		feature := decodeFeature(line)
+		feature.Line = lineNumber
+		features = append(features, feature)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
+
+// NDJSONWriter streams features as newline delimited GeoJSON.
+type NDJSONWriter struct {
+	enc *json.Encoder
+}
+
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{enc: json.NewEncoder(w)}
+}
+
+func (nw *NDJSONWriter) Write(f Feature) error {
+	return nw.enc.Encode(newGeoJSONFeature(f))
+}
+
+func (nw *NDJSONWriter) Close() error {
+	return nil
+}
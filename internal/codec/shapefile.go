@@ -0,0 +1,98 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/proj"
+	"github.com/malamsyah/geo-service/pkg/shapefile"
+)
+
+const (
+	maxShapefileSize = 512 * 1024 * 1024
+
+	// maxUncompressedShapefileSize bounds the files of an archive once
+	// decompressed, so a small archive cannot exhaust memory.
+	maxUncompressedShapefileSize = 1024 * 1024 * 1024
+)
+
+// DecodeShapefile reads a zipped ESRI Shapefile. Coordinates are reprojected
+// to EPSG:4326 according to the .prj of each layer, and the .dbf attributes
+// become the feature properties. Polygon records holding several outer rings
+// are split into one feature per polygon sharing the same Record.
+func DecodeShapefile(r io.Reader) ([]Feature, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxShapefileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxShapefileSize {
+		return nil, fmt.Errorf("%w: archive larger than %d bytes", constants.ErrPayloadTooLarge, maxShapefileSize)
+	}
+
+	layers, err := shapefile.ReadZip(bytes.NewReader(data), int64(len(data)), maxUncompressedShapefileSize)
+	if errors.Is(err, shapefile.ErrTooLarge) {
+		return nil, fmt.Errorf("%w: %w", constants.ErrPayloadTooLarge, err)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", constants.ErrInvalidFeatureCollection, err)
+	}
+
+	features := make([]Feature, 0)
+	for _, layer := range layers {
+		projection := proj.WGS84()
+		if layer.Projection != "" {
+			projection, err = proj.ParsePRJ(layer.Projection)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", constants.ErrInvalidFeatureCollection, layer.Name, err)
+			}
+		}
+
+		for _, record := range layer.Records {
+			features = append(features, shapeFeatures(record, projection)...)
+		}
+	}
+
+	return features, nil
+}
+
+func shapeFeatures(record shapefile.Record, projection proj.Projection) []Feature {
+	f := Feature{Record: record.Number, Properties: record.Attributes}
+
+	switch {
+	case len(record.Parts) == 0:
+		f.Err = fmt.Errorf("%w: empty shape", constants.ErrInvalidFeature)
+	case record.Type.IsPoint():
+		f.Geometry = models.Geometry{Type: models.PointType, PointCoordinates: reproject(record.Parts[0][0], projection)}
+	case record.Type.IsPolygon():
+		polygons := record.Polygons()
+		features := make([]Feature, 0, len(polygons))
+		for _, polygon := range polygons {
+			for _, ring := range polygon {
+				for i := range ring {
+					ring[i] = reproject(ring[i], projection)
+				}
+			}
+
+			polygonFeature := f
+			polygonFeature.Geometry = models.Geometry{Type: models.PolygonType, PolygonCoordinates: polygon}
+			features = append(features, polygonFeature)
+		}
+
+		return features
+	default:
+		f.Err = constants.ErrInvalidGeometryType
+	}
+
+	return []Feature{f}
+}
+
+func reproject(p [2]float64, projection proj.Projection) [2]float64 {
+	lon, lat := projection.Inverse(p[0], p[1])
+	return [2]float64{lon, lat}
+}
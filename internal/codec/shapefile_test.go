@@ -0,0 +1,47 @@
+package codec
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeShapefile(t *testing.T) {
+	f, err := os.Open("testdata/parcels.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	features, err := Decode(MediaTypeZip, f)
+	assert.NoError(t, err)
+
+	// The first record holds two polygons, the second is a null shape.
+	assert.Len(t, features, 4)
+	assert.Equal(t, []int{1, 1, 2, 3}, []int{features[0].Record, features[1].Record, features[2].Record, features[3].Record})
+
+	first := features[0]
+	assert.NoError(t, first.Err)
+	assert.Equal(t, models.PolygonType, first.Geometry.Type)
+	assert.Len(t, first.Geometry.PolygonCoordinates, 2)
+	assert.InDelta(t, 15, first.Geometry.PolygonCoordinates[0][0][0], 1e-6)
+	assert.InDelta(t, 45, first.Geometry.PolygonCoordinates[0][0][1], 1e-6)
+	assert.Equal(t, "North parcel", first.Properties["NAME"])
+	assert.Equal(t, first.Properties, features[1].Properties)
+
+	assert.ErrorIs(t, features[2].Err, constants.ErrInvalidFeature)
+	assert.Equal(t, "Zürich", features[2].Properties["NAME"])
+
+	// The unclosed ring is left for Geometry.Validate to report.
+	assert.NoError(t, features[3].Err)
+	assert.ErrorIs(t, features[3].Geometry.Validate(), constants.ErrInvalidContours)
+}
+
+func TestDecodeShapefileInvalidArchive(t *testing.T) {
+	_, err := DecodeShapefile(strings.NewReader("not a zip"))
+	assert.ErrorIs(t, err, constants.ErrInvalidFeatureCollection)
+}
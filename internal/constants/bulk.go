@@ -0,0 +1,4 @@
+package constants
+
+// BulkChunkSize is the number of rows inserted per statement by the bulk endpoints.
+const BulkChunkSize = 500
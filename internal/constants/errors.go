@@ -1,12 +1,181 @@
 package constants
 
-import "errors"
-
-var ErrNotFound = errors.New("not found")
-var ErrInternal = errors.New("internal error")
-var ErrInvalidPoint = errors.New("invalid point")
-var ErrCoordinatesOutOfRange = errors.New("coordinates out of range")
-var ErrInvalidGeometryType = errors.New("invalid geometry type")
-var ErrUnsupportedScan = errors.New("unsupported scan")
-var ErrInvalidContours = errors.New("invalid contours")
-var ErrContourNotFound = errors.New("contour not found")
+import (
+	"errors"
+	"net/http"
+)
+
+// StatusClientClosedRequest is the non standard status, borrowed from nginx,
+// of a request the client gave up on before it was answered.
+const StatusClientClosedRequest = 499
+
+// Error is a domain error. Code identifies the kind of failure and Status the
+// HTTP status it maps to. Field is the path of the offending input, Details
+// holds anything else a client needs to fix it and Err the underlying cause.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Field   string
+	Details map[string]any
+	Err     error
+
+	// kind is the more general error this one is a kind of.
+	kind *Error
+}
+
+// NewError returns a domain error of the given status.
+func NewError(status int, code, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+// Kind returns a more specific kind of e, with the same status. errors.Is
+// matches it against e as well.
+func (e *Error) Kind(code, message string) *Error {
+	return &Error{Code: code, Status: e.Status, Message: message, kind: e}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors of the same code, or of a code e is a kind of.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error) //nolint:errorlint // comparing the target itself
+	if !ok {
+		return false
+	}
+
+	for k := e; k != nil; k = k.kind {
+		if k.Code == t.Code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Wrap returns a copy of e caused by err.
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// At returns a copy of e about the input at field. A field already set is
+// taken as relative to it, so paths are built up from the innermost input.
+func (e *Error) At(field string) *Error {
+	c := *e
+	switch {
+	case field == "":
+	case e.Field == "":
+		c.Field = field
+	case e.Field[0] == '[':
+		c.Field = field + e.Field
+	default:
+		c.Field = field + "." + e.Field
+	}
+
+	return &c
+}
+
+// WithDetails returns a copy of e holding details.
+func (e *Error) WithDetails(details map[string]any) *Error {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+// Wrap returns err when it carries a domain error already and kind wrapping it
+// otherwise.
+func Wrap(err error, kind *Error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	return kind.Wrap(err)
+}
+
+// At places the domain error carried by err at field. Other errors are
+// returned as is.
+func At(err error, field string) error {
+	var e *Error
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	return e.At(field)
+}
+
+var ErrNotFound = NewError(http.StatusNotFound, "not_found", "not found")
+var ErrInternal = NewError(http.StatusInternalServerError, "internal", "internal error")
+var ErrTimeout = NewError(http.StatusGatewayTimeout, "timeout", "request timed out")
+var ErrCanceled = NewError(StatusClientClosedRequest, "canceled", "request canceled")
+var ErrInvalidParameter = NewError(http.StatusBadRequest, "invalid_parameter", "invalid parameter")
+var ErrInvalidBody = NewError(http.StatusBadRequest, "invalid_body", "invalid request body")
+var ErrInvalidPoint = NewError(http.StatusBadRequest, "invalid_point", "invalid point")
+var ErrCoordinatesOutOfRange = NewError(http.StatusBadRequest, "coordinates_out_of_range", "coordinates out of range")
+var ErrInvalidGeometryType = NewError(http.StatusBadRequest, "invalid_geometry_type", "invalid geometry type")
+var ErrUnsupportedScan = NewError(http.StatusInternalServerError, "unsupported_scan", "unsupported scan")
+var ErrInvalidContours = NewError(http.StatusBadRequest, "invalid_contours", "invalid contours")
+var ErrPointNotFound = ErrNotFound.Kind("point_not_found", "point not found")
+var ErrContourNotFound = ErrNotFound.Kind("contour_not_found", "contour not found")
+var ErrUnsupportedMediaType = NewError(http.StatusUnsupportedMediaType, "unsupported_media_type", "unsupported media type")
+var ErrNotAcceptable = NewError(http.StatusNotAcceptable, "not_acceptable", "not acceptable")
+var ErrInvalidFeature = NewError(http.StatusBadRequest, "invalid_feature", "invalid feature")
+var ErrInvalidFeatureCollection = NewError(http.StatusBadRequest, "invalid_feature_collection", "invalid feature collection")
+var ErrInvalidBulkMode = NewError(http.StatusBadRequest, "invalid_bulk_mode", "invalid bulk mode")
+var ErrInvalidBBox = NewError(http.StatusBadRequest, "invalid_bbox", "invalid bbox")
+var ErrConflictingFilters = NewError(http.StatusBadRequest, "conflicting_filters", "contour and bbox filters cannot be combined")
+var ErrInvalidWKT = NewError(http.StatusBadRequest, "invalid_wkt", "invalid wkt")
+var ErrInvalidDelimiter = NewError(http.StatusBadRequest, "invalid_delimiter", "invalid delimiter")
+var ErrInvalidTile = NewError(http.StatusBadRequest, "invalid_tile", "invalid tile")
+var ErrInvalidZoom = NewError(http.StatusBadRequest, "invalid_zoom", "invalid zoom")
+var ErrInvalidGrid = NewError(http.StatusBadRequest, "invalid_grid", "invalid grid")
+var ErrInvalidGridSize = NewError(http.StatusBadRequest, "invalid_grid_size", "invalid grid size")
+var ErrTooManyCells = NewError(http.StatusBadRequest, "too_many_cells", "too many grid cells")
+var ErrGridUnsupported = NewError(http.StatusNotImplemented, "grid_unsupported", "grid functions unsupported by the database")
+var ErrInvalidDistance = NewError(http.StatusBadRequest, "invalid_distance", "invalid distance")
+var ErrInvalidGeohash = NewError(http.StatusBadRequest, "invalid_geohash", "invalid geohash")
+var ErrConflictingGeohash = NewError(http.StatusBadRequest, "conflicting_geohash", "geohash filter cannot be combined with contour or bbox filters")
+var ErrBeyondTolerance = NewError(http.StatusUnprocessableEntity, "beyond_tolerance", "point is beyond the snapping tolerance")
+var ErrInvalidSegments = NewError(http.StatusBadRequest, "invalid_segments", "invalid segments")
+var ErrInvalidEndCap = NewError(http.StatusBadRequest, "invalid_end_cap", "invalid end cap")
+var ErrEmptyBuffer = NewError(http.StatusUnprocessableEntity, "empty_buffer", "buffer is empty")
+var ErrBufferNotPolygon = NewError(http.StatusUnprocessableEntity, "buffer_not_polygon", "buffer is not a single polygon")
+var ErrInvalidHull = NewError(http.StatusBadRequest, "invalid_hull", "invalid hull type")
+var ErrInvalidTargetPercent = NewError(http.StatusBadRequest, "invalid_target_percent", "invalid target percent")
+var ErrConflictingHullInput = NewError(http.StatusBadRequest, "conflicting_hull_input", "inline points cannot be combined with filters")
+var ErrDegenerateHull = NewError(http.StatusUnprocessableEntity, "degenerate_hull", "hull needs at least three points not on a line")
+var ErrInvalidEngine = NewError(http.StatusBadRequest, "invalid_engine", "invalid engine")
+var ErrMissingContour = NewError(http.StatusBadRequest, "missing_contour", "contour is required")
+var ErrMissingSeeds = NewError(http.StatusBadRequest, "missing_seeds", "ids or contour is required")
+var ErrVoronoiUnsupported = NewError(http.StatusNotImplemented, "voronoi_unsupported", "voronoi functions unsupported by the database")
+var ErrVersionMismatch = NewError(http.StatusPreconditionFailed, "version_mismatch", "version does not match the current one")
+var ErrInvalidPrecondition = NewError(http.StatusBadRequest, "invalid_precondition", "invalid precondition")
+var ErrContourVersionNotFound = ErrNotFound.Kind("contour_version_not_found", "contour version not found")
+var ErrContourNotDeleted = NewError(http.StatusConflict, "contour_not_deleted", "contour is not in the trash")
+var ErrInvalidPatch = NewError(http.StatusBadRequest, "invalid_patch", "invalid patch")
+var ErrPatchConflict = NewError(http.StatusConflict, "patch_conflict", "patch does not apply to the current document")
+var ErrInvalidIdempotencyKey = NewError(http.StatusBadRequest, "invalid_idempotency_key", "invalid idempotency key")
+var ErrIdempotencyKeyReused = NewError(http.StatusUnprocessableEntity, "idempotency_key_reused", "idempotency key was used for a different request")
+var ErrIdempotencyKeyInProgress = NewError(http.StatusConflict, "idempotency_key_in_progress", "a request with this idempotency key is in progress")
+var ErrInvalidDuplicatePolicy = NewError(http.StatusBadRequest, "invalid_duplicate_policy", "invalid duplicate policy")
+var ErrDuplicatePoint = NewError(http.StatusConflict, "duplicate_point", "point duplicates an existing one")
+var ErrLayerNotFound = ErrNotFound.Kind("layer_not_found", "layer not found")
+var ErrUnknownLayer = NewError(http.StatusBadRequest, "unknown_layer", "layer does not exist")
+var ErrInvalidLayerName = NewError(http.StatusBadRequest, "invalid_layer_name", "invalid layer name")
+var ErrLayerExists = NewError(http.StatusConflict, "layer_exists", "a layer with this name exists")
+var ErrLayerNotEmpty = NewError(http.StatusConflict, "layer_not_empty", "layer still has points or contours")
+var ErrPayloadTooLarge = NewError(http.StatusRequestEntityTooLarge, "payload_too_large", "payload too large")
+var ErrConcaveContour = NewError(http.StatusUnprocessableEntity, "concave_contour", "the go engine only supports convex contours without holes")
+var ErrEmptyBulk = NewError(http.StatusBadRequest, "empty_bulk", "no features to create")
@@ -0,0 +1,53 @@
+package constants
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	err := fmt.Errorf("lookup: %w", ErrContourNotFound.At("id"))
+
+	assert.ErrorIs(t, err, ErrContourNotFound)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrPointNotFound)
+	assert.NotErrorIs(t, ErrNotFound, ErrContourNotFound)
+}
+
+func TestError_Wrap(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := ErrInternal.Wrap(cause)
+
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "internal error: connection reset", err.Error())
+	assert.Nil(t, ErrInternal.Err)
+
+	assert.Equal(t, err, Wrap(err, ErrInvalidBody))
+	assert.ErrorIs(t, Wrap(cause, ErrInvalidBody), ErrInvalidBody)
+}
+
+func TestError_At(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           *Error
+		field         string
+		expectedField string
+	}{
+		{name: "Empty", err: ErrInvalidBBox, field: "bbox", expectedField: "bbox"},
+		{name: "Nested", err: ErrInvalidPoint.At("coordinates"), field: "data", expectedField: "data.coordinates"},
+		{name: "Index", err: ErrInvalidContours.At("[0][3]"), field: "coordinates", expectedField: "coordinates[0][3]"},
+		{name: "NoField", err: ErrInvalidPoint.At("coordinates"), field: "", expectedField: "coordinates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedField, tt.err.At(tt.field).Field)
+		})
+	}
+
+	assert.Empty(t, ErrInvalidBBox.Field)
+	assert.Equal(t, errors.New("plain"), At(errors.New("plain"), "bbox"))
+}
@@ -2,9 +2,11 @@ package db
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/malamsyah/geo-service/internal/models"
 	"github.com/malamsyah/geo-service/pkg/config"
+	"github.com/malamsyah/geo-service/pkg/geohash"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -32,10 +34,36 @@ func ConnectPostgres(conf *config.Config) (*gorm.DB, error) {
 }
 
 func Migrate(db *gorm.DB) error {
-	err := db.AutoMigrate(models.Point{}, models.Contour{})
+	err := db.AutoMigrate(models.Layer{}, models.Point{}, models.Contour{}, models.SimplifiedContour{}, models.ContourHistory{}, models.IdempotentRequest{})
 	if err != nil {
 		return err
 	}
 
+	// Points stored before the geohash column existed are backfilled, the
+	// pattern ops index serves the LIKE prefix queries whatever the collation.
+	err = db.Exec("UPDATE points SET geohash = ST_GeoHash(data, ?) WHERE geohash IS NULL", geohash.MaxPrecision).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_points_geohash ON points (geohash varchar_pattern_ops)").Error
+	if err != nil {
+		return err
+	}
+
+	// The duplicate checks compare points in metres.
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_points_geography ON points USING GIST ((data::geography))").Error
+	if err != nil {
+		return err
+	}
+
+	// The contour history is an audit trail, its rows are never changed.
+	for _, event := range []string{"update", "delete"} {
+		err = db.Exec("CREATE OR REPLACE RULE contour_history_no_" + event + " AS ON " + strings.ToUpper(event) + " TO contour_history DO INSTEAD NOTHING").Error
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,35 @@
+package dto
+
+import "github.com/malamsyah/geo-service/internal/models"
+
+// Feature is a GeoJSON Feature.
+type Feature struct {
+	Type       string          `json:"type"`
+	Geometry   models.Geometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+// AggregateResponse is the GeoJSON FeatureCollection of cells returned by
+// /points/aggregate. Grid cells carry their column i and row j, contour cells
+// their contour_id.
+type AggregateResponse struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+func NewAggregateResponse(grid models.Grid, cells []models.Cell) AggregateResponse {
+	features := make([]Feature, len(cells))
+	for k, cell := range cells {
+		properties := map[string]any{"count": cell.Count}
+		if grid.Type == models.GridContour {
+			properties["contour_id"] = cell.ContourID
+		} else {
+			properties["i"] = cell.I
+			properties["j"] = cell.J
+		}
+
+		features[k] = Feature{Type: "Feature", Geometry: cell.Data, Properties: properties}
+	}
+
+	return AggregateResponse{Type: "FeatureCollection", Features: features}
+}
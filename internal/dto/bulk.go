@@ -0,0 +1,19 @@
+package dto
+
+// BulkItemResult reports the outcome of a single feature of a bulk import.
+type BulkItemResult struct {
+	Index  int    `json:"index"`
+	Line   int    `json:"line,omitempty"`
+	Record int    `json:"record,omitempty"`
+	ID     uint   `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkResponse is the per-feature report returned by the bulk endpoints.
+type BulkResponse struct {
+	Mode    string           `json:"mode"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Failed  int              `json:"failed"`
+	Results []BulkItemResult `json:"results"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/supercluster"
+)
+
+// ClusterProperties follow the supercluster conventions: clusters carry
+// cluster, point_count and expansion_zoom, lone points the ID of the point.
+type ClusterProperties struct {
+	Cluster       bool `json:"cluster"`
+	PointCount    int  `json:"point_count"`
+	ExpansionZoom int  `json:"expansion_zoom,omitempty"`
+	PointID       uint `json:"point_id,omitempty"`
+}
+
+type ClusterFeature struct {
+	Type       string            `json:"type"`
+	Geometry   models.Geometry   `json:"geometry"`
+	Properties ClusterProperties `json:"properties"`
+}
+
+// ClusterResponse is the GeoJSON FeatureCollection returned by
+// /points/clusters.
+type ClusterResponse struct {
+	Type     string           `json:"type"`
+	Features []ClusterFeature `json:"features"`
+}
+
+func NewClusterResponse(clusters []supercluster.Cluster) ClusterResponse {
+	features := make([]ClusterFeature, len(clusters))
+	for i, c := range clusters {
+		features[i] = ClusterFeature{
+			Type:     "Feature",
+			Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{c.Lon, c.Lat}},
+			Properties: ClusterProperties{
+				Cluster:       c.Count > 1,
+				PointCount:    c.Count,
+				ExpansionZoom: c.ExpansionZoom,
+				PointID:       c.PointID,
+			},
+		}
+	}
+
+	return ClusterResponse{Type: "FeatureCollection", Features: features}
+}
@@ -0,0 +1,17 @@
+package dto
+
+// DuplicateGroup is a cluster of near-identical points, merged into Kept.
+type DuplicateGroup struct {
+	Kept   uint   `json:"kept"`
+	Merged []uint `json:"merged"`
+}
+
+// DedupeResponse reports the clusters merged by a dedupe, or those that would
+// be by a dry run.
+type DedupeResponse struct {
+	DryRun    bool             `json:"dry_run"`
+	Tolerance float64          `json:"tolerance"`
+	Groups    int              `json:"groups"`
+	Merged    int              `json:"merged"`
+	Results   []DuplicateGroup `json:"results"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/malamsyah/geo-service/internal/models"
+
+// DistanceResponse reports the signed distance in metres from a point to the
+// boundary of a contour, negative when the point is inside.
+type DistanceResponse struct {
+	PointID      uint            `json:"point_id"`
+	ContourID    uint            `json:"contour_id"`
+	Distance     float64         `json:"distance"`
+	Inside       bool            `json:"inside"`
+	ClosestPoint models.Geometry `json:"closest_point"`
+}
+
+// SnapResponse reports a snapped point and the distance in metres it was
+// moved by.
+type SnapResponse struct {
+	Point    models.Point `json:"point"`
+	Snapped  bool         `json:"snapped"`
+	Distance float64      `json:"distance"`
+}
@@ -10,17 +10,19 @@ type Response struct {
 }
 
 type CreatePointRequest struct {
-	Data models.Geometry `json:"data" binding:"required"`
+	Data       models.Geometry   `json:"data" binding:"required"`
+	Properties models.Properties `json:"properties,omitempty"`
 }
 
 func (r CreatePointRequest) ToModel() models.Point {
-	return models.Point{Data: r.Data}
+	return models.Point{Data: r.Data, Properties: r.Properties}
 }
 
 type CreateContourRequest struct {
-	Data models.Geometry `json:"data" binding:"required"`
+	Data       models.Geometry   `json:"data" binding:"required"`
+	Properties models.Properties `json:"properties,omitempty"`
 }
 
 func (r CreateContourRequest) ToModel() models.Contour {
-	return models.Contour{Data: r.Data}
+	return models.Contour{Data: r.Data, Properties: r.Properties}
 }
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/malamsyah/geo-service/internal/models"
+
+// ContourHistoryResponse lists the versions a contour went through before
+// the current one, oldest first.
+type ContourHistoryResponse struct {
+	ContourID uint                    `json:"contour_id"`
+	Versions  []models.ContourHistory `json:"versions"`
+}
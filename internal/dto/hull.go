@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// HullRequest is the body of /points/hull. The hull covers the inline points,
+// or the stored points matching ids, bbox and contour. Type defaults to
+// convex and target_percent to models.DefaultTargetPercent.
+type HullRequest struct {
+	Type          models.HullType `json:"type"`
+	TargetPercent *float64        `json:"target_percent"`
+	Points        [][2]float64    `json:"points"`
+	IDs           []uint          `json:"ids"`
+	BBox          []float64       `json:"bbox"`
+	Contour       uint            `json:"contour"`
+	Persist       bool            `json:"persist"`
+}
+
+func (r HullRequest) ToModel() (models.Hull, models.HullInput, error) {
+	hull := models.Hull{Type: r.Type, TargetPercent: models.DefaultTargetPercent}
+	if hull.Type == "" {
+		hull.Type = models.HullConvex
+	}
+
+	if r.TargetPercent != nil {
+		hull.TargetPercent = *r.TargetPercent
+	}
+
+	input := models.HullInput{Positions: r.Points, IDs: r.IDs, ContourID: r.Contour}
+	if r.BBox != nil {
+		if len(r.BBox) != 4 {
+			return hull, input, constants.ErrInvalidBBox.At("bbox")
+		}
+
+		input.BBox = &models.BBox{MinLon: r.BBox[0], MinLat: r.BBox[1], MaxLon: r.BBox[2], MaxLat: r.BBox[3]}
+	}
+
+	return hull, input, nil
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/malamsyah/geo-service/internal/models"
+
+type CreateLayerRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description,omitempty"`
+}
+
+func (r CreateLayerRequest) ToModel() models.Layer {
+	return models.Layer{Name: r.Name, Description: r.Description}
+}
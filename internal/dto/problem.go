@@ -0,0 +1,15 @@
+package dto
+
+// Problem is an RFC 7807 problem details body, served as
+// application/problem+json. Code, Field and Details extend it with the domain
+// error behind the problem.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Field    string         `json:"field,omitempty"`
+	Details  map[string]any `json:"details,omitempty"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/malamsyah/geo-service/internal/models"
+
+// TessellationResponse is the GeoJSON FeatureCollection of the cells a
+// contour is tessellated into, carrying their column i, row j and the
+// contour_ids they are stored as.
+type TessellationResponse struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+func NewTessellationResponse(cells []models.TessellationCell) TessellationResponse {
+	features := make([]Feature, len(cells))
+	for k, cell := range cells {
+		properties := map[string]any{"i": cell.I, "j": cell.J}
+		if len(cell.ContourIDs) > 0 {
+			properties["contour_ids"] = cell.ContourIDs
+		}
+
+		features[k] = Feature{Type: "Feature", Geometry: cell.Data, Properties: properties}
+	}
+
+	return TessellationResponse{Type: "FeatureCollection", Features: features}
+}
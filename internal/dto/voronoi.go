@@ -0,0 +1,53 @@
+package dto
+
+import "github.com/malamsyah/geo-service/internal/models"
+
+// VoronoiRequest is the body of /points/voronoi and /points/delaunay. The
+// seeds are the points of ids, or else those within contour, which also
+// clips the Voronoi cells. Engine defaults to postgis.
+type VoronoiRequest struct {
+	Contour uint          `json:"contour"`
+	IDs     []uint        `json:"ids"`
+	Engine  models.Engine `json:"engine"`
+	Persist bool          `json:"persist"`
+}
+
+func (r VoronoiRequest) ToModel() models.VoronoiInput {
+	input := models.VoronoiInput{ContourID: r.Contour, IDs: r.IDs, Engine: r.Engine}
+	if input.Engine == "" {
+		input.Engine = models.EnginePostGIS
+	}
+
+	return input
+}
+
+// VoronoiResponse is the GeoJSON FeatureCollection of Voronoi cells, carrying
+// their seed point_id and the contour_ids they are stored as, or of Delaunay
+// triangles.
+type VoronoiResponse struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+func NewVoronoiResponse(cells []models.VoronoiCell) VoronoiResponse {
+	features := make([]Feature, len(cells))
+	for i, cell := range cells {
+		properties := map[string]any{"point_id": cell.PointID}
+		if len(cell.ContourIDs) > 0 {
+			properties["contour_ids"] = cell.ContourIDs
+		}
+
+		features[i] = Feature{Type: "Feature", Geometry: cell.Data, Properties: properties}
+	}
+
+	return VoronoiResponse{Type: "FeatureCollection", Features: features}
+}
+
+func NewDelaunayResponse(triangles []models.Geometry) VoronoiResponse {
+	features := make([]Feature, len(triangles))
+	for i, triangle := range triangles {
+		features[i] = Feature{Type: "Feature", Geometry: triangle, Properties: map[string]any{}}
+	}
+
+	return VoronoiResponse{Type: "FeatureCollection", Features: features}
+}
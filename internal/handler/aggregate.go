@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// AggregatePoints serves point counts per grid cell as a GeoJSON
+// FeatureCollection. grid is hex (the default), square or contour, size the
+// cell size of hex and square grids in metres.
+func (h *GeometryHandler) AggregatePoints(c *gin.Context) {
+	grid := models.Grid{Type: models.GridType(c.DefaultQuery("grid", string(models.GridHex)))}
+	if grid.Type != models.GridContour {
+		size, err := parseDistance(c.Query("size"))
+		if err != nil {
+			logger.Errorf("Failed to parse size: %v", err)
+			respondError(c, constants.ErrInvalidGridSize.At("size"))
+			return
+		}
+
+		grid.Size = size
+	}
+
+	bbox, err := h.parseBBox(c)
+	if err != nil {
+		logger.Errorf("Failed to parse bbox: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	var contourID int
+	if contourIDStr := c.Query("contour"); contourIDStr != "" {
+		if bbox != nil {
+			respondError(c, constants.ErrConflictingFilters)
+			return
+		}
+
+		if contourID, err = strconv.Atoi(contourIDStr); err != nil {
+			logger.Errorf("Failed to parse contour id: %v", err)
+			respondError(c, invalidParameter("contour", err))
+			return
+		}
+	}
+
+	cells, err := h.geometryService.AggregatePoints(c.Request.Context(), grid, bbox, uint(contourID))
+	if err != nil {
+		logger.Errorf("Failed to aggregate points: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewAggregateResponse(grid, cells))
+}
+
+// parseDistance reads a distance in metres, written as a plain number or with
+// an m or km unit.
+func parseDistance(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	factor := 1.0
+
+	switch {
+	case strings.HasSuffix(s, "km"):
+		s, factor = strings.TrimSuffix(s, "km"), 1000
+	case strings.HasSuffix(s, "m"):
+		s = strings.TrimSuffix(s, "m")
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, constants.ErrInvalidDistance
+	}
+
+	return value * factor, nil
+}
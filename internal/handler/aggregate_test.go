@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAggregatePoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bbox := &models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6}
+	square := models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:               "Aggregate points returns OK",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature",` +
+				`"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":{"count":4,"i":3,"j":-2}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().AggregatePoints(gomock.Any(), models.Grid{Type: models.GridHex, Size: 1500}, bbox, uint(0)).
+					Return([]models.Cell{{I: 3, J: -2, Data: square, Count: 4}}, nil)
+				return mock
+			},
+			requestPath: "?size=1.5km&bbox=4,4,6,6",
+		},
+		{
+			name:               "Aggregate points per contour returns OK",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature",` +
+				`"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":{"contour_id":7,"count":0}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().AggregatePoints(gomock.Any(), models.Grid{Type: models.GridContour}, nil, uint(0)).
+					Return([]models.Cell{{ContourID: 7, Data: square}}, nil)
+				return mock
+			},
+			requestPath: "?grid=contour",
+		},
+		{
+			name:                 "Aggregate points inside a contour returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().AggregatePoints(gomock.Any(), models.Grid{Type: models.GridSquare, Size: 250}, nil, uint(3)).
+					Return([]models.Cell{}, nil)
+				return mock
+			},
+			requestPath: "?grid=square&size=250m&contour=3",
+		},
+		{
+			name:                 "Aggregate points returns BadRequest invalid size",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid grid size","instance":"/points/aggregate","code":"invalid_grid_size","field":"size"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "?size=big&bbox=4,4,6,6",
+		},
+		{
+			name:                 "Aggregate points returns BadRequest conflicting filters",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"contour and bbox filters cannot be combined","instance":"/points/aggregate","code":"conflicting_filters"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "?size=1000&bbox=4,4,6,6&contour=3",
+		},
+		{
+			name:                 "Aggregate points returns BadRequest too many cells",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"too many grid cells","instance":"/points/aggregate","code":"too_many_cells"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().AggregatePoints(gomock.Any(), models.Grid{Type: models.GridHex, Size: 1}, bbox, uint(0)).
+					Return(nil, constants.ErrTooManyCells)
+				return mock
+			},
+			requestPath: "?size=1m&bbox=4,4,6,6",
+		},
+		{
+			name:                 "Aggregate points returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/points/aggregate","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().AggregatePoints(gomock.Any(), models.Grid{Type: models.GridHex, Size: 1000}, nil, uint(3)).
+					Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			requestPath: "?size=1000&contour=3",
+		},
+		{
+			name:                 "Aggregate points returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/aggregate","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().AggregatePoints(gomock.Any(), models.Grid{Type: models.GridHex, Size: 1000}, bbox, uint(0)).
+					Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "?size=1000&bbox=4,4,6,6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, "/points/aggregate"+tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
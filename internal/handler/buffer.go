@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// BufferPoint returns the buffer of a point as a contour, stored when persist
+// is set.
+func (h *GeometryHandler) BufferPoint(c *gin.Context) {
+	h.buffer(c, h.geometryService.BufferPoint)
+}
+
+// BufferContour returns the buffer of a contour as a new contour, stored when
+// persist is set. A negative distance shrinks the contour.
+func (h *GeometryHandler) BufferContour(c *gin.Context) {
+	h.buffer(c, h.geometryService.BufferContour)
+}
+
+func (h *GeometryHandler) buffer(c *gin.Context, fn func(context.Context, uint, models.Buffer, bool) (*models.Contour, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	buffer, persist, err := parseBuffer(c)
+	if err != nil {
+		logger.Errorf("Failed to parse buffer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	contour, err := fn(c.Request.Context(), uint(id), buffer, persist)
+	if err != nil {
+		logger.Errorf("Failed to buffer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	if persist {
+		c.JSON(http.StatusCreated, contour)
+		return
+	}
+
+	c.JSON(http.StatusOK, contour)
+}
+
+// parseBuffer reads the distance, segments, endcap and persist query
+// parameters. Segments default to 8 and the end cap to round.
+func parseBuffer(c *gin.Context) (models.Buffer, bool, error) {
+	buffer := models.Buffer{
+		Segments: models.DefaultBufferSegments,
+		EndCap:   models.EndCap(c.DefaultQuery("endcap", string(models.EndCapRound))),
+	}
+
+	distance, err := parseDistance(c.Query("distance"))
+	if err != nil {
+		return buffer, false, invalidParameter("distance", err)
+	}
+
+	buffer.Distance = distance
+
+	if value, ok := c.GetQuery("segments"); ok {
+		if buffer.Segments, err = strconv.Atoi(value); err != nil {
+			return buffer, false, constants.ErrInvalidSegments.At("segments")
+		}
+	}
+
+	persist, err := strconv.ParseBool(c.DefaultQuery("persist", "false"))
+	if err != nil {
+		return buffer, false, invalidParameter("persist", err)
+	}
+
+	return buffer, persist, nil
+}
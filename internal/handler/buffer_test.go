@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func bufferContour(id uint) *models.Contour {
+	return &models.Contour{ID: id, Data: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+	}}
+}
+
+func TestBuffer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:                 "Buffer point returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BufferPoint(gomock.Any(), uint(1), models.Buffer{Distance: 500, Segments: 8, EndCap: models.EndCapRound}, false).Return(bufferContour(0), nil)
+				return mock
+			},
+			requestPath: "/points/1/buffer?distance=0.5km",
+		},
+		{
+			name:                 "Buffer contour returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":4,"data":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BufferContour(gomock.Any(), uint(2), models.Buffer{Distance: -20, Segments: 4, EndCap: models.EndCapSquare}, true).Return(bufferContour(4), nil)
+				return mock
+			},
+			requestPath: "/contours/2/buffer?distance=-20m&segments=4&endcap=square&persist=true",
+		},
+		{
+			name:                 "Buffer returns BadRequest on distance",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid distance","instance":"/contours/2/buffer","code":"invalid_distance","field":"distance"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/contours/2/buffer",
+		},
+		{
+			name:                 "Buffer returns BadRequest on segments",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid segments","instance":"/contours/2/buffer","code":"invalid_segments","field":"segments"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/contours/2/buffer?distance=10&segments=many",
+		},
+		{
+			name:                 "Buffer returns BadRequest on end cap",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid end cap","instance":"/contours/2/buffer","code":"invalid_end_cap"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BufferContour(gomock.Any(), uint(2), models.Buffer{Distance: 10, Segments: 8, EndCap: "butt"}, false).Return(nil, constants.ErrInvalidEndCap)
+				return mock
+			},
+			requestPath: "/contours/2/buffer?distance=10&endcap=butt",
+		},
+		{
+			name:                 "Buffer returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"point not found","instance":"/points/1/buffer","code":"point_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BufferPoint(gomock.Any(), uint(1), gomock.Any(), false).Return(nil, constants.ErrPointNotFound)
+				return mock
+			},
+			requestPath: "/points/1/buffer?distance=10",
+		},
+		{
+			name:                 "Buffer returns UnprocessableEntity",
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"buffer is empty","instance":"/contours/2/buffer","code":"empty_buffer"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BufferContour(gomock.Any(), uint(2), gomock.Any(), false).Return(nil, constants.ErrEmptyBuffer)
+				return mock
+			},
+			requestPath: "/contours/2/buffer?distance=-5km",
+		},
+		{
+			name:                 "Buffer returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours/2/buffer","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BufferContour(gomock.Any(), uint(2), gomock.Any(), false).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/contours/2/buffer?distance=5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/service"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+const bulkAction = ":bulk"
+
+// PointsAction serves the custom methods on the points collection. gin treats
+// a colon as the start of a wildcard, so /points:bulk is routed here with the
+// suffix in the action parameter.
+func (h *GeometryHandler) PointsAction(c *gin.Context) {
+	switch c.Param("action") {
+	case bulkAction:
+		h.BulkCreatePoints(c)
+	default:
+		respondError(c, constants.ErrNotFound)
+	}
+}
+
+// ContoursAction serves the custom methods on the contours collection.
+func (h *GeometryHandler) ContoursAction(c *gin.Context) {
+	switch c.Param("action") {
+	case bulkAction:
+		h.BulkCreateContours(c)
+	default:
+		respondError(c, constants.ErrNotFound)
+	}
+}
+
+func (h *GeometryHandler) BulkCreatePoints(c *gin.Context) {
+	h.bulkCreate(c, models.PointType, h.geometryService.BulkCreatePoints)
+}
+
+func (h *GeometryHandler) BulkCreateContours(c *gin.Context) {
+	h.bulkCreate(c, models.PolygonType, h.geometryService.BulkCreateContours)
+}
+
+func (h *GeometryHandler) bulkCreate(c *gin.Context, geometryType models.Type, create func(context.Context, []codec.Feature, service.BulkMode) ([]service.BulkResult, error)) {
+	mode := service.BulkMode(c.DefaultQuery("mode", string(service.BulkModeAtomic)))
+	if !mode.IsValid() {
+		respondError(c, constants.ErrInvalidBulkMode.At("mode"))
+		return
+	}
+
+	features, err := decodeFeatures(c, geometryType)
+	if err != nil {
+		logger.Errorf("Failed to decode features: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	if len(features) == 0 {
+		respondError(c, constants.ErrEmptyBulk)
+		return
+	}
+
+	results, err := create(c.Request.Context(), features, mode)
+	if err != nil {
+		logger.Errorf("Failed to bulk create: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	resp := buildBulkResponse(mode, results)
+	switch resp.Created {
+	case resp.Total:
+		c.JSON(http.StatusCreated, resp)
+	case 0:
+		c.JSON(http.StatusUnprocessableEntity, resp)
+	default:
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// decodeFeatures reads the request body according to its content type, or the
+// file field of a multipart upload according to its extension. CSV layouts
+// are described with the lon, lat, wkt, delimiter and header query parameters.
+// Formats mixing points and polygons only keep the geometries of the given
+// type.
+func decodeFeatures(c *gin.Context, geometryType models.Type) ([]codec.Feature, error) {
+	if c.ContentType() != gin.MIMEMultipartPOSTForm {
+		return decode(c, c.ContentType(), c.Request.Body, geometryType)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType := uploadMediaType(fileHeader.Filename)
+	if mediaType == "" {
+		mediaType, _, _ = strings.Cut(fileHeader.Header.Get("Content-Type"), ";")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return decode(c, mediaType, file, geometryType)
+}
+
+// uploadMediaType maps the extension of an uploaded file to its media type.
+func uploadMediaType(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".zip":
+		return codec.MediaTypeZip
+	case ".json", ".geojson":
+		return codec.MediaTypeGeoJSON
+	case ".ndjson", ".jsonl":
+		return codec.MediaTypeNDJSON
+	case ".csv":
+		return codec.MediaTypeCSV
+	case ".kml":
+		return codec.MediaTypeKML
+	case ".gpx":
+		return codec.MediaTypeGPX
+	default:
+		return ""
+	}
+}
+
+func decode(c *gin.Context, mediaType string, r io.Reader, geometryType models.Type) ([]codec.Feature, error) {
+	if mediaType == codec.MediaTypeCSV {
+		opts, err := parseCSVOptions(c)
+		if err != nil {
+			return nil, err
+		}
+
+		return codec.DecodeCSV(r, opts)
+	}
+
+	features, err := codec.Decode(mediaType, r)
+	if err != nil || !codec.IsMixed(mediaType) {
+		return features, err
+	}
+
+	return codec.OfType(features, geometryType), nil
+}
+
+func parseCSVOptions(c *gin.Context) (codec.CSVOptions, error) {
+	opts := codec.CSVOptions{
+		LonColumn: c.Query("lon"),
+		LatColumn: c.Query("lat"),
+		WKTColumn: c.Query("wkt"),
+	}
+
+	if delimiter := c.Query("delimiter"); delimiter != "" {
+		if delimiter == "tab" {
+			delimiter = "\t"
+		}
+
+		runes := []rune(delimiter)
+		if len(runes) != 1 {
+			return opts, constants.ErrInvalidDelimiter.At("delimiter")
+		}
+
+		opts.Delimiter = runes[0]
+	}
+
+	if header := c.Query("header"); header != "" {
+		hasHeader, err := strconv.ParseBool(header)
+		if err != nil {
+			return opts, invalidParameter("header", err)
+		}
+
+		opts.Header = &hasHeader
+	}
+
+	return opts, nil
+}
+
+func buildBulkResponse(mode service.BulkMode, results []service.BulkResult) dto.BulkResponse {
+	resp := dto.BulkResponse{
+		Mode:    string(mode),
+		Total:   len(results),
+		Results: make([]dto.BulkItemResult, len(results)),
+	}
+
+	for i, r := range results {
+		resp.Results[i] = dto.BulkItemResult{Index: r.Index, Line: r.Line, Record: r.Record, ID: r.ID}
+		if r.Err != nil {
+			resp.Results[i].Error = r.Err.Error()
+			resp.Failed++
+			continue
+		}
+
+		if r.ID != 0 {
+			resp.Created++
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/service"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBulkCreatePoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	featureCollection := `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]}},{"type":"Feature","geometry":{"type":"Point","coordinates":[1,200]}}]}`
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+		contentType          string
+		requestBody          string
+	}{
+		{
+			name:                 "Bulk create points returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"mode":"atomic","total":2,"created":2,"failed":0,"results":[{"index":0,"id":1},{"index":1,"id":2}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreatePoints(gomock.Any(), gomock.Len(2), service.BulkModeAtomic).Return([]service.BulkResult{
+					{Index: 0, ID: 1},
+					{Index: 1, ID: 2},
+				}, nil)
+				return mock
+			},
+			requestPath: "/points:bulk",
+			contentType: codec.MediaTypeGeoJSON,
+			requestBody: featureCollection,
+		},
+		{
+			name:                 "Bulk create points in best effort mode returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"mode":"best_effort","total":2,"created":1,"failed":1,"results":[{"index":0,"id":1},{"index":1,"error":"coordinates out of range"}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreatePoints(gomock.Any(), gomock.Len(2), service.BulkModeBestEffort).Return([]service.BulkResult{
+					{Index: 0, ID: 1},
+					{Index: 1, Err: constants.ErrCoordinatesOutOfRange},
+				}, nil)
+				return mock
+			},
+			requestPath: "/points:bulk?mode=best_effort",
+			contentType: codec.MediaTypeNDJSON,
+			requestBody: `{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]}}
+{"type":"Feature","geometry":{"type":"Point","coordinates":[1,200]}}`,
+		},
+		{
+			name:                 "Bulk create points in atomic mode returns UnprocessableEntity",
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"mode":"atomic","total":2,"created":0,"failed":1,"results":[{"index":0},{"index":1,"error":"coordinates out of range"}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreatePoints(gomock.Any(), gomock.Len(2), service.BulkModeAtomic).Return([]service.BulkResult{
+					{Index: 0},
+					{Index: 1, Err: constants.ErrCoordinatesOutOfRange},
+				}, nil)
+				return mock
+			},
+			requestPath: "/points:bulk",
+			contentType: codec.MediaTypeJSON,
+			requestBody: featureCollection,
+		},
+		{
+			name:                 "Bulk create points from CSV reports lines",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"mode":"best_effort","total":2,"created":1,"failed":1,"results":[{"index":0,"line":2,"id":1},{"index":1,"line":3,"error":"invalid feature: invalid lon \"x\""}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreatePoints(gomock.Any(), gomock.Len(2), service.BulkModeBestEffort).DoAndReturn(
+					func(_ context.Context, features []codec.Feature, _ service.BulkMode) ([]service.BulkResult, error) {
+						return []service.BulkResult{
+							{Index: 0, Line: features[0].Line, ID: 1},
+							{Index: 1, Line: features[1].Line, Err: features[1].Err},
+						}, nil
+					})
+				return mock
+			},
+			requestPath: "/points:bulk?mode=best_effort&lon=x_coord&lat=y_coord&delimiter=%3B",
+			contentType: codec.MediaTypeCSV,
+			requestBody: "name;x_coord;y_coord\na;1;2\nb;x;3\n",
+		},
+		{
+			name:                 "Bulk create points from CSV returns BadRequest for invalid delimiter",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid delimiter","instance":"/points:bulk","code":"invalid_delimiter","field":"delimiter"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points:bulk?delimiter=ab",
+			contentType: codec.MediaTypeCSV,
+			requestBody: "lon,lat\n1,2\n",
+		},
+		{
+			name:                 "Bulk create points from KML keeps points only",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"mode":"atomic","total":1,"created":1,"failed":0,"results":[{"index":0,"record":1,"id":1}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreatePoints(gomock.Any(), gomock.Len(1), service.BulkModeAtomic).DoAndReturn(
+					func(_ context.Context, features []codec.Feature, _ service.BulkMode) ([]service.BulkResult, error) {
+						return []service.BulkResult{{Index: 0, Record: features[0].Record, ID: 1}}, nil
+					})
+				return mock
+			},
+			requestPath: "/points:bulk",
+			contentType: codec.MediaTypeKML,
+			requestBody: `<kml xmlns="http://www.opengis.net/kml/2.2"><Document>` +
+				`<Placemark><name>Well</name><Point><coordinates>1,2</coordinates></Point></Placemark>` +
+				`<Placemark><Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs></Polygon></Placemark>` +
+				`</Document></kml>`,
+		},
+		{
+			name:                 "Bulk create points returns BadRequest for invalid mode",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid bulk mode","instance":"/points:bulk","code":"invalid_bulk_mode","field":"mode"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points:bulk?mode=sometimes",
+			contentType: codec.MediaTypeJSON,
+			requestBody: featureCollection,
+		},
+		{
+			name:                 "Bulk create points returns BadRequest for empty collection",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"no features to create","instance":"/points:bulk","code":"empty_bulk"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points:bulk",
+			contentType: codec.MediaTypeJSON,
+			requestBody: `{"type":"FeatureCollection","features":[]}`,
+		},
+		{
+			name:                 "Bulk create points returns UnsupportedMediaType",
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedResponseBody: `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"unsupported media type","instance":"/points:bulk","code":"unsupported_media_type"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points:bulk",
+			contentType: "text/plain",
+			requestBody: featureCollection,
+		},
+		{
+			name:                 "Bulk create points returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points:bulk","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreatePoints(gomock.Any(), gomock.Any(), service.BulkModeAtomic).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/points:bulk",
+			contentType: codec.MediaTypeJSON,
+			requestBody: featureCollection,
+		},
+		{
+			name:                 "Unknown custom method returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/points:unknown","code":"not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points:unknown",
+			contentType: codec.MediaTypeJSON,
+			requestBody: featureCollection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBulkCreateContours(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestBody          string
+	}{
+		{
+			name:                 "Bulk create contours returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"mode":"atomic","total":1,"created":1,"failed":0,"results":[{"index":0,"id":4}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().BulkCreateContours(gomock.Any(), gomock.Len(1), service.BulkModeAtomic).Return([]service.BulkResult{
+					{Index: 0, ID: 4},
+				}, nil)
+				return mock
+			},
+			requestBody: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[30,10]]]}}]}`,
+		},
+		{
+			name:                 "Bulk create contours returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid feature collection","instance":"/contours:bulk","code":"invalid_feature_collection"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestBody: `{"type":"Feature"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, "/contours:bulk", strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBulkCreateContoursShapefileUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	archive, err := os.ReadFile("../codec/testdata/parcels.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "parcels.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(archive); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := gomock.NewController(t)
+	mock := mock_service.NewMockGeometryService(ctrl)
+	mock.EXPECT().BulkCreateContours(gomock.Any(), gomock.Len(4), service.BulkModeBestEffort).DoAndReturn(
+		func(_ context.Context, features []codec.Feature, _ service.BulkMode) ([]service.BulkResult, error) {
+			results := make([]service.BulkResult, len(features))
+			for i, f := range features {
+				results[i] = service.BulkResult{Index: i, Record: f.Record, ID: uint(i + 1), Err: f.Err}
+				if f.Err != nil {
+					results[i].ID = 0
+				}
+			}
+			return results, nil
+		})
+
+	router := gin.Default()
+	handler := NewGeometryHandler(mock, "localhost")
+	handler.RegisterRoutes(router.Group("/"))
+
+	req, err := http.NewRequest(http.MethodPost, "/contours:bulk?mode=best_effort", &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, w.Code)
+	}
+
+	expected := `{"mode":"best_effort","total":4,"created":3,"failed":1,"results":[{"index":0,"record":1,"id":1},{"index":1,"record":1,"id":2},{"index":2,"record":2,"error":"invalid feature: empty shape"},{"index":3,"record":3,"id":4}]}`
+	if w.Body.String() != expected {
+		t.Errorf("Expected body %s, got %s", expected, w.Body.String())
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// GetPointClusters serves the point clusters of a zoom level as a GeoJSON
+// FeatureCollection. The bbox defaults to the whole world.
+func (h *GeometryHandler) GetPointClusters(c *gin.Context) {
+	zoom, err := strconv.Atoi(c.Query("zoom"))
+	if err != nil {
+		logger.Errorf("Failed to parse zoom: %v", err)
+		respondError(c, constants.ErrInvalidZoom.At("zoom"))
+		return
+	}
+
+	bbox, err := h.parseBBox(c)
+	if err != nil {
+		logger.Errorf("Failed to parse bbox: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	if bbox == nil {
+		bbox = &models.BBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+	}
+
+	clusters, err := h.geometryService.GetPointClusters(c.Request.Context(), *bbox, zoom)
+	if err != nil {
+		logger.Errorf("Failed to get point clusters: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewClusterResponse(clusters))
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"github.com/malamsyah/geo-service/pkg/supercluster"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPointClusters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bbox := models.BBox{MinLon: 100, MinLat: -10, MaxLon: 110, MaxLat: 0}
+	world := models.BBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:               "Get point clusters returns OK",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[` +
+				`{"type":"Feature","geometry":{"type":"Point","coordinates":[106.8,-6.2]},"properties":{"cluster":true,"point_count":12,"expansion_zoom":5}},` +
+				`{"type":"Feature","geometry":{"type":"Point","coordinates":[104,-2]},"properties":{"cluster":false,"point_count":1,"point_id":7}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointClusters(gomock.Any(), bbox, 4).Return([]supercluster.Cluster{
+					{Lon: 106.8, Lat: -6.2, Count: 12, ExpansionZoom: 5},
+					{Lon: 104, Lat: -2, Count: 1, PointID: 7},
+				}, nil)
+				return mock
+			},
+			requestPath: "?bbox=100,-10,110,0&zoom=4",
+		},
+		{
+			name:                 "Get point clusters defaults to the world",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointClusters(gomock.Any(), world, 0).Return([]supercluster.Cluster{}, nil)
+				return mock
+			},
+			requestPath: "?zoom=0",
+		},
+		{
+			name:                 "Get point clusters returns BadRequest missing zoom",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid zoom","instance":"/points/clusters","code":"invalid_zoom","field":"zoom"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "?bbox=100,-10,110,0",
+		},
+		{
+			name:                 "Get point clusters returns BadRequest invalid bbox",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid bbox","instance":"/points/clusters","code":"invalid_bbox","field":"bbox"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "?bbox=100,-10&zoom=4",
+		},
+		{
+			name:                 "Get point clusters returns BadRequest zoom out of range",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid zoom","instance":"/points/clusters","code":"invalid_zoom"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointClusters(gomock.Any(), world, 99).Return(nil, constants.ErrInvalidZoom)
+				return mock
+			},
+			requestPath: "?zoom=99",
+		},
+		{
+			name:                 "Get point clusters returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/clusters","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointClusters(gomock.Any(), world, 2).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "?zoom=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, "/points/clusters"+tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
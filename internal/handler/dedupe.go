@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/service"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// DedupePoints merges the clusters of points within the tolerance query
+// parameter of one another, exact duplicates when it is not given. With
+// dry_run, it only reports them.
+func (h *GeometryHandler) DedupePoints(c *gin.Context) {
+	tolerance, err := parseTolerance(c)
+	if err != nil {
+		logger.Errorf("Failed to parse tolerance: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		logger.Errorf("Failed to parse dry_run: %v", err)
+		respondError(c, invalidParameter("dry_run", err))
+		return
+	}
+
+	groups, err := h.geometryService.DedupePoints(c.Request.Context(), tolerance, dryRun)
+	if err != nil {
+		logger.Errorf("Failed to dedupe points: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	resp := dto.DedupeResponse{DryRun: dryRun, Tolerance: tolerance, Groups: len(groups), Results: make([]dto.DuplicateGroup, len(groups))}
+	for i, group := range groups {
+		resp.Merged += len(group.Merged)
+		resp.Results[i] = dto.DuplicateGroup{Kept: group.Kept, Merged: group.Merged}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseDuplicatePolicy reads the duplicates and tolerance query parameters of
+// a point creation, allowing duplicates by default.
+func parseDuplicatePolicy(c *gin.Context) (service.DuplicatePolicy, float64, error) {
+	policy := service.DuplicatePolicy(c.DefaultQuery("duplicates", string(service.DuplicateAllow)))
+	if !policy.IsValid() {
+		return "", 0, constants.ErrInvalidDuplicatePolicy.At("duplicates")
+	}
+
+	tolerance, err := parseTolerance(c)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return policy, tolerance, nil
+}
+
+// parseTolerance reads the tolerance query parameter, a distance defaulting
+// to zero.
+func parseTolerance(c *gin.Context) (float64, error) {
+	tolerance, err := parseDistance(c.DefaultQuery("tolerance", "0"))
+	if err != nil || tolerance < 0 {
+		return 0, constants.ErrInvalidDistance.At("tolerance")
+	}
+
+	return tolerance, nil
+}
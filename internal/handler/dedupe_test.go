@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/service"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDuplicates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	point := models.Geometry{Type: "Point", PointCoordinates: [2]float64{30, 10}}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		method               string
+		requestPath          string
+		requestBody          string
+	}{
+		{
+			name:                 "Dedupe Points returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"dry_run":false,"tolerance":5,"groups":2,"merged":3,"results":[{"kept":1,"merged":[2,3]},{"kept":4,"merged":[5]}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DedupePoints(gomock.Any(), 5.0, false).
+					Return([]service.DuplicateGroup{{Kept: 1, Merged: []uint{2, 3}}, {Kept: 4, Merged: []uint{5}}}, nil)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/points/dedupe?tolerance=5m",
+		},
+		{
+			name:                 "Dedupe Points dry run returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"dry_run":true,"tolerance":0,"groups":0,"merged":0,"results":[]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DedupePoints(gomock.Any(), 0.0, true).Return(nil, nil)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/points/dedupe?dry_run=true",
+		},
+		{
+			name:                 "Dedupe Points returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid distance","instance":"/points/dedupe","code":"invalid_distance","field":"tolerance"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/points/dedupe?tolerance=-1",
+		},
+		{
+			name:                 "Create duplicate Point returns Conflict",
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"point duplicates an existing one","instance":"/points","code":"duplicate_point","details":{"id":1}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().CreateUniquePoint(gomock.Any(), gomock.Any(), service.DuplicateReject, 1000.0).
+					Return(false, constants.ErrDuplicatePoint.WithDetails(map[string]any{"id": 1}))
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/points?duplicates=reject&tolerance=1km",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+		{
+			name:                 "Create duplicate Point returns existing Point",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Point","coordinates":[30,10]},"version":2}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().CreateUniquePoint(gomock.Any(), gomock.Any(), service.DuplicateReturn, 0.0).
+					DoAndReturn(func(_ any, p *models.Point, _ service.DuplicatePolicy, _ float64) (bool, error) {
+						*p = models.Point{ID: 1, Data: point, Version: 2}
+						return false, nil
+					})
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/points?duplicates=return",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+		{
+			name:                 "Create Point with invalid duplicate policy returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid duplicate policy","instance":"/points","code":"invalid_duplicate_policy","field":"duplicates"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/points?duplicates=merge",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(tt.method, tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
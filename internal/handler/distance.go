@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+func (h *GeometryHandler) GetPointContourDistance(c *gin.Context) {
+	pointID, contourID, err := parsePointContour(c)
+	if err != nil {
+		logger.Errorf("Failed to parse ids: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	distance, err := h.geometryService.GetPointContourDistance(c.Request.Context(), pointID, contourID)
+	if err != nil {
+		logger.Errorf("Failed to get distance: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DistanceResponse{
+		PointID:      pointID,
+		ContourID:    contourID,
+		Distance:     distance.Meters,
+		Inside:       distance.Inside,
+		ClosestPoint: distance.Closest,
+	})
+}
+
+// SnapPoint moves a point onto the boundary of the contour when it lies
+// outside it within the tolerance, given in metres.
+func (h *GeometryHandler) SnapPoint(c *gin.Context) {
+	pointID, contourID, err := parsePointContour(c)
+	if err != nil {
+		logger.Errorf("Failed to parse ids: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	tolerance, err := parseDistance(c.Query("tolerance"))
+	if err != nil || tolerance < 0 {
+		logger.Errorf("Failed to parse tolerance: %v", err)
+		respondError(c, constants.ErrInvalidDistance.At("tolerance"))
+		return
+	}
+
+	point, moved, err := h.geometryService.SnapPoint(c.Request.Context(), pointID, contourID, tolerance)
+	if err != nil {
+		logger.Errorf("Failed to snap point: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SnapResponse{Point: *point, Snapped: moved > 0, Distance: moved})
+}
+
+// parsePointContour reads the point id path parameter and the contour query
+// parameter.
+func parsePointContour(c *gin.Context) (uint, uint, error) {
+	pointID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, invalidParameter("id", err)
+	}
+
+	contourID, err := strconv.Atoi(c.Query("contour"))
+	if err != nil {
+		return 0, 0, invalidParameter("contour", err)
+	}
+
+	return uint(pointID), uint(contourID), nil
+}
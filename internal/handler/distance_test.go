@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPointContourDistance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:                 "Get distance returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"point_id":1,"contour_id":2,"distance":-12.5,"inside":true,"closest_point":{"type":"Point","coordinates":[10,5]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(&models.Distance{
+					Meters:  -12.5,
+					Inside:  true,
+					Closest: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{10, 5}},
+				}, nil)
+				return mock
+			},
+			requestPath: "/points/1/distance?contour=2",
+		},
+		{
+			name:                 "Get distance returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"\": invalid syntax","instance":"/points/1/distance","code":"invalid_parameter","field":"contour"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points/1/distance",
+		},
+		{
+			name:                 "Get distance returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/points/1/distance","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			requestPath: "/points/1/distance?contour=2",
+		},
+		{
+			name:                 "Get distance returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/1/distance","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/points/1/distance?contour=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSnapPoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	point := &models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{10, 5}}}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:                 "Snap point returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"point":{"id":1,"data":{"type":"Point","coordinates":[10,5]}},"snapped":true,"distance":33.3}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().SnapPoint(gomock.Any(), uint(1), uint(2), float64(50)).Return(point, 33.3, nil)
+				return mock
+			},
+			requestPath: "/points/1/snap?contour=2&tolerance=50m",
+		},
+		{
+			name:                 "Snap point inside returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"point":{"id":1,"data":{"type":"Point","coordinates":[10,5]}},"snapped":false,"distance":0}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().SnapPoint(gomock.Any(), uint(1), uint(2), float64(1000)).Return(point, 0.0, nil)
+				return mock
+			},
+			requestPath: "/points/1/snap?contour=2&tolerance=1km",
+		},
+		{
+			name:                 "Snap point returns BadRequest invalid tolerance",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid distance","instance":"/points/1/snap","code":"invalid_distance","field":"tolerance"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/points/1/snap?contour=2&tolerance=-5",
+		},
+		{
+			name:                 "Snap point returns UnprocessableEntity",
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"point is beyond the snapping tolerance","instance":"/points/1/snap","code":"beyond_tolerance"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().SnapPoint(gomock.Any(), uint(1), uint(2), float64(5)).Return(nil, 0.0, constants.ErrBeyondTolerance)
+				return mock
+			},
+			requestPath: "/points/1/snap?contour=2&tolerance=5",
+		},
+		{
+			name:                 "Snap point returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"point not found","instance":"/points/1/snap","code":"point_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().SnapPoint(gomock.Any(), uint(1), uint(2), float64(5)).Return(nil, 0.0, constants.ErrPointNotFound)
+				return mock
+			},
+			requestPath: "/points/1/snap?contour=2&tolerance=5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
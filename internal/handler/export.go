@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bufio"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+const exportBufferSize = 32 * 1024
+
+func (h *GeometryHandler) ExportPoints(c *gin.Context) {
+	bbox, err := h.parseBBox(c)
+	if err != nil {
+		logger.Errorf("Failed to parse bbox: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	var contourID int
+	if contourIDStr := c.Query("contour"); contourIDStr != "" {
+		if bbox != nil {
+			respondError(c, constants.ErrConflictingFilters)
+			return
+		}
+
+		contourID, err = strconv.Atoi(contourIDStr)
+		if err != nil {
+			logger.Errorf("Failed to parse contour id: %v", err)
+			respondError(c, invalidParameter("contour", err))
+			return
+		}
+	}
+
+	h.export(c, "points", func(w codec.Writer) error {
+		return h.geometryService.ExportPoints(c.Request.Context(), bbox, uint(contourID), func(p *models.Point) error {
+			return w.Write(codec.Feature{ID: p.ID, Geometry: p.Data, Properties: p.Properties})
+		})
+	})
+}
+
+func (h *GeometryHandler) ExportContours(c *gin.Context) {
+	bbox, err := h.parseBBox(c)
+	if err != nil {
+		logger.Errorf("Failed to parse bbox: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.export(c, "contours", func(w codec.Writer) error {
+		return h.geometryService.ExportContours(c.Request.Context(), bbox, func(contour *models.Contour) error {
+			return w.Write(codec.Feature{ID: contour.ID, Geometry: contour.Data, Properties: contour.Properties})
+		})
+	})
+}
+
+// export streams the features produced by write in the negotiated format.
+// Output is buffered, so an error raised before the first flush is still
+// reported with a proper status code. Once data has been sent the response is
+// simply cut short.
+func (h *GeometryHandler) export(c *gin.Context, name string, write func(codec.Writer) error) {
+	mediaType, extension := exportFormat(c)
+	if mediaType == "" {
+		respondError(c, constants.ErrNotAcceptable)
+		return
+	}
+
+	buf := bufio.NewWriterSize(c.Writer, exportBufferSize)
+	w, err := codec.NewWriter(mediaType, buf)
+	if err != nil {
+		respondError(c, constants.ErrNotAcceptable.Wrap(err))
+		return
+	}
+
+	c.Header("Content-Type", mediaType)
+	c.Header("Content-Disposition", "attachment; filename="+name+"."+extension)
+
+	err = write(w)
+	if err == nil {
+		err = w.Close()
+	}
+
+	if err == nil {
+		err = buf.Flush()
+	}
+
+	if err == nil {
+		return
+	}
+
+	logger.Errorf("Failed to export %s: %v", name, err)
+	if c.Writer.Written() {
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+
+	respondError(c, err)
+}
+
+// exportFormat picks the export media type from the format query parameter,
+// falling back to the Accept header. NDJSON is the default.
+func exportFormat(c *gin.Context) (string, string) {
+	format := c.Query("format")
+	if format == "" {
+		switch c.NegotiateFormat(codec.MediaTypeNDJSON, codec.MediaTypeGeoJSON, codec.MediaTypeJSON, codec.MediaTypeCSV, codec.MediaTypeKML, codec.MediaTypeGPX) {
+		case codec.MediaTypeNDJSON:
+			format = "ndjson"
+		case codec.MediaTypeGeoJSON, codec.MediaTypeJSON:
+			format = "geojson"
+		case codec.MediaTypeCSV:
+			format = "csv"
+		case codec.MediaTypeKML:
+			format = "kml"
+		case codec.MediaTypeGPX:
+			format = "gpx"
+		}
+	}
+
+	switch format {
+	case "ndjson":
+		return codec.MediaTypeNDJSON, "ndjson"
+	case "geojson":
+		return codec.MediaTypeGeoJSON, "geojson"
+	case "csv":
+		return codec.MediaTypeCSV, "csv"
+	case "kml":
+		return codec.MediaTypeKML, "kml"
+	case "gpx":
+		return codec.MediaTypeGPX, "gpx"
+	default:
+		return "", ""
+	}
+}
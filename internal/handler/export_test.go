@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportPoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	points := []models.Point{
+		{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{5.5, 10}}},
+		{ID: 2, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{6, 11}}},
+	}
+	streamPoints := func(_ context.Context, _ *models.BBox, _ uint, fn func(*models.Point) error) error {
+		for i := range points {
+			if err := fn(&points[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestParams        string
+		accept               string
+	}{
+		{
+			name:                "Export points as NDJSON by default",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedResponseBody: `{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[5.5,10]},"properties":{}}
+{"type":"Feature","id":2,"geometry":{"type":"Point","coordinates":[6,11]},"properties":{}}
+`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().ExportPoints(gomock.Any(), nil, uint(0), gomock.Any()).DoAndReturn(streamPoints)
+				return mock
+			},
+		},
+		{
+			name:                 "Export points as a FeatureCollection",
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/geo+json",
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[5.5,10]},"properties":{}},{"type":"Feature","id":2,"geometry":{"type":"Point","coordinates":[6,11]},"properties":{}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().ExportPoints(gomock.Any(), nil, uint(3), gomock.Any()).DoAndReturn(streamPoints)
+				return mock
+			},
+			requestParams: "contour=3",
+			accept:        "application/geo+json",
+		},
+		{
+			name:                 "Export points as CSV",
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "text/csv",
+			expectedResponseBody: "id,wkt\n1,POINT(5.5 10)\n2,POINT(6 11)\n",
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().ExportPoints(gomock.Any(), nil, uint(0), gomock.Any()).DoAndReturn(streamPoints)
+				return mock
+			},
+			requestParams: "format=csv",
+		},
+		{
+			name:                 "Export points returns NotFound for an unknown contour",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedContentType:  MediaTypeProblem,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/points/export","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().ExportPoints(gomock.Any(), nil, uint(9), gomock.Any()).Return(constants.ErrContourNotFound)
+				return mock
+			},
+			requestParams: "contour=9",
+		},
+		{
+			name:                 "Export points returns BadRequest for an invalid bbox",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  MediaTypeProblem,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid bbox","instance":"/points/export","code":"invalid_bbox","field":"bbox"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestParams: "bbox=10,0,0,20",
+		},
+		{
+			name:                 "Export points returns BadRequest for a contour and a bbox",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedContentType:  MediaTypeProblem,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"contour and bbox filters cannot be combined","instance":"/points/export","code":"conflicting_filters"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestParams: "bbox=0,0,10,20&contour=3",
+		},
+		{
+			name:                 "Export points returns NotAcceptable",
+			expectedStatusCode:   http.StatusNotAcceptable,
+			expectedContentType:  MediaTypeProblem,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Acceptable","status":406,"detail":"not acceptable","instance":"/points/export","code":"not_acceptable"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestParams: "format=xlsx",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, "/points/export?"+tt.requestParams, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Header().Get("Content-Type") != tt.expectedContentType {
+				t.Errorf("Expected content type %s, got %s", tt.expectedContentType, w.Header().Get("Content-Type"))
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestExportContours(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestParams        string
+	}{
+		{
+			name:                 "Export contours as CSV",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "id,wkt\n1,\"POLYGON((30 10,40 40,20 40,30 10))\"\n",
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().ExportContours(gomock.Any(), nil, gomock.Any()).DoAndReturn(func(_ context.Context, _ *models.BBox, fn func(*models.Contour) error) error {
+					return fn(&models.Contour{ID: 1, Data: models.Geometry{
+						Type:               models.PolygonType,
+						PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {30, 10}}},
+					}})
+				})
+				return mock
+			},
+			requestParams: "format=csv",
+		},
+		{
+			name:                 "Export contours returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours/export","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().ExportContours(gomock.Any(), nil, gomock.Any()).Return(constants.ErrInternal)
+				return mock
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, "/contours/export?"+tt.requestParams, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/geohash"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// GetGeohash serves the cell of a geohash as a GeoJSON Feature, its centre
+// and bounds as properties.
+func (h *GeometryHandler) GetGeohash(c *gin.Context) {
+	hash := c.Param("hash")
+
+	box, err := geohash.Decode(hash)
+	if err != nil {
+		logger.Errorf("Failed to decode geohash: %v", err)
+		respondError(c, constants.ErrInvalidGeohash.At("hash"))
+		return
+	}
+
+	lon, lat := box.Center()
+	ring := [][2]float64{
+		{box.MinLon, box.MinLat}, {box.MaxLon, box.MinLat}, {box.MaxLon, box.MaxLat}, {box.MinLon, box.MaxLat}, {box.MinLon, box.MinLat},
+	}
+
+	c.JSON(http.StatusOK, dto.Feature{
+		Type:     "Feature",
+		Geometry: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{ring}},
+		Properties: map[string]any{
+			"geohash": hash,
+			"center":  [2]float64{lon, lat},
+			"bbox":    [4]float64{box.MinLon, box.MinLat, box.MaxLon, box.MaxLat},
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetGeohash(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		requestPath          string
+	}{
+		{
+			name:               "Get geohash returns OK",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[45,0],[45,45],[0,45],[0,0]]]},` +
+				`"properties":{"bbox":[0,0,45,45],"center":[22.5,22.5],"geohash":"s"}}`,
+			requestPath: "/s",
+		},
+		{
+			name:               "Get geohash returns OK for a longer hash",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[-1.40625,43.59375],[0,43.59375],[0,45],[-1.40625,45],[-1.40625,43.59375]]]},` +
+				`"properties":{"bbox":[-1.40625,43.59375,0,45],"center":[-0.703125,44.296875],"geohash":"ezz"}}`,
+			requestPath: "/ezz",
+		},
+		{
+			name:                 "Get geohash returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid geohash","instance":"/geohash/w3ga","code":"invalid_geohash","field":"hash"}`,
+			requestPath:          "/w3ga",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(mock_service.NewMockGeometryService(gomock.NewController(t)), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, "/geohash"+tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,14 +1,15 @@
 package handler
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
 	"github.com/malamsyah/geo-service/internal/service"
 	"github.com/malamsyah/geo-service/pkg/logger"
 )
@@ -30,28 +31,82 @@ func NewGeometryHandler(geometryService service.GeometryService, host string) *G
 func (h *GeometryHandler) RegisterRoutes(r *gin.RouterGroup) {
 	r.POST("/points", h.CreatePoint)
 	r.GET("/points", h.GetPoints)
+	r.GET("/points/export", h.ExportPoints)
+	r.GET("/points/clusters", h.GetPointClusters)
+	r.GET("/points/aggregate", h.AggregatePoints)
+	r.POST("/points/hull", h.GetPointsHull)
+	r.POST("/points/voronoi", h.GetVoronoiCells)
+	r.POST("/points/delaunay", h.GetDelaunayTriangles)
+	r.POST("/points/dedupe", h.DedupePoints)
+	r.GET("/points/:id", h.GetPointByID)
+	r.PUT("/points/:id", h.UpdatePoint)
+	r.PATCH("/points/:id", h.PatchPoint)
+	r.DELETE("/points/:id", h.DeletePoint)
+	r.GET("/points/:id/distance", h.GetPointContourDistance)
+	r.POST("/points/:id/snap", h.SnapPoint)
+	r.POST("/points/:id/buffer", h.BufferPoint)
+	r.POST("/points:action", h.PointsAction)
 	r.POST("/contours", h.CreateContour)
+	r.POST("/contours:action", h.ContoursAction)
 	r.GET("/contours", h.GetContours)
+	r.GET("/contours/export", h.ExportContours)
+	r.GET("/contours/trash", h.GetDeletedContours)
 	r.GET("/contours/:id", h.GetContourByID)
 	r.PUT("/contours/:id", h.UpdateContour)
+	r.PATCH("/contours/:id", h.PatchContour)
 	r.DELETE("/contours/:id", h.DeleteContour)
+	r.GET("/contours/:id/simplified", h.GetSimplifiedContour)
+	r.PUT("/contours/:id/simplified", h.SimplifyContour)
+	r.GET("/contours/:id/history", h.GetContourHistory)
+	r.POST("/contours/:id/restore", h.RestoreContour)
+	r.POST("/contours/:id/buffer", h.BufferContour)
+	r.POST("/contours/:id/tessellate", h.TessellateContour)
 	r.GET("/intersections", h.Intersect)
+	r.GET("/tiles/:z/:x/:y", h.GetTile)
+	r.GET("/geohash/:hash", h.GetGeohash)
+	r.POST("/layers", h.CreateLayer)
+	r.GET("/layers", h.GetLayers)
+	r.GET("/layers/:id", h.GetLayerByID)
+	r.PUT("/layers/:id", h.UpdateLayer)
+	r.DELETE("/layers/:id", h.DeleteLayer)
 }
 
+// CreatePoint creates a point. The duplicates query parameter tells whether
+// to reject a point duplicating an existing one within tolerance, or to
+// return the existing point instead.
 func (h *GeometryHandler) CreatePoint(c *gin.Context) {
+	policy, tolerance, err := parseDuplicatePolicy(c)
+	if err != nil {
+		logger.Errorf("Failed to parse duplicate policy: %v", err)
+		respondError(c, err)
+		return
+	}
+
 	var req dto.CreatePointRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("Failed to bind request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidBody(err))
 		return
 	}
 
 	point := req.ToModel()
 
-	err := h.geometryService.CreatePoint(&point)
+	created := true
+	if policy == service.DuplicateAllow {
+		err = h.geometryService.CreatePoint(c.Request.Context(), &point)
+	} else {
+		created, err = h.geometryService.CreateUniquePoint(c.Request.Context(), &point, policy, tolerance)
+	}
+
 	if err != nil {
 		logger.Errorf("Failed to create point: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	if !created {
+		setVersionETag(c, point.Version)
+		c.JSON(http.StatusOK, point)
 		return
 	}
 
@@ -62,68 +117,149 @@ func (h *GeometryHandler) GetPoints(c *gin.Context) {
 	page, offset, limit, err := h.parseOffsetLimit(c)
 	if err != nil {
 		logger.Errorf("Failed to parse page: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	bbox, err := h.parseBBox(c)
+	if err != nil {
+		logger.Errorf("Failed to parse bbox: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	var points []models.Point
+
 	conourIDStr := c.Query("contour")
-	if conourIDStr != "" {
-		contourID, err := strconv.Atoi(conourIDStr)
-		if err != nil {
-			logger.Errorf("Failed to parse contour id: %v", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	geohashPrefix := c.Query("geohash")
+	switch {
+	case geohashPrefix != "" && (conourIDStr != "" || bbox != nil):
+		respondError(c, constants.ErrConflictingGeohash)
+		return
+	case conourIDStr != "" && bbox != nil:
+		respondError(c, constants.ErrConflictingFilters)
+		return
+	case geohashPrefix != "":
+		points, err = h.geometryService.GetPointsByGeohash(c.Request.Context(), geohashPrefix, offset, limit)
+	case conourIDStr != "":
+		contourID, parseErr := strconv.Atoi(conourIDStr)
+		if parseErr != nil {
+			logger.Errorf("Failed to parse contour id: %v", parseErr)
+			respondError(c, invalidParameter("contour", parseErr))
 			return
 		}
 
-		points, err := h.geometryService.GetPointsByContourID(uint(contourID))
-		if err != nil {
-			logger.Errorf("Failed to get points: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
+		points, err = h.geometryService.GetPointsByContourID(c.Request.Context(), uint(contourID))
+	case bbox != nil:
+		points, err = h.geometryService.GetPointsByBBox(c.Request.Context(), *bbox, offset, limit)
+	default:
+		points, err = h.geometryService.GetPoints(c.Request.Context(), offset, limit)
+	}
 
-		resp := dto.Response{
-			Count:    len(points),
-			Next:     h.buildNextURL("/points", page),
-			Previous: h.buildPreviousURL("/points", page),
-			Results:  points,
-		}
+	if err != nil {
+		logger.Errorf("Failed to get points: %v", err)
+		respondError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusOK, resp)
+	h.respondPoints(c, page, points)
+}
+
+func (h *GeometryHandler) GetPointByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
 		return
 	}
 
-	points, err := h.geometryService.GetPoints(offset, limit)
+	point, err := h.geometryService.GetPointByID(c.Request.Context(), uint(id))
 	if err != nil {
-		logger.Errorf("Failed to get points: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		logger.Errorf("Failed to get point: %v", err)
+		respondError(c, err)
 		return
 	}
 
-	resp := dto.Response{
-		Count:    len(points),
-		Next:     h.buildNextURL("/points", page),
-		Previous: h.buildPreviousURL("/points", page),
-		Results:  points,
+	setVersionETag(c, point.Version)
+	c.JSON(http.StatusOK, point)
+}
+
+func (h *GeometryHandler) UpdatePoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	version, err := parseIfMatch(c)
+	if err != nil {
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	var req dto.CreatePointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	point := req.ToModel()
+	point.ID = uint(id)
+	point.Version = version
+
+	err = h.geometryService.UpdatePoint(c.Request.Context(), &point)
+	if err != nil {
+		logger.Errorf("Failed to update point: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	setVersionETag(c, point.Version)
+	c.JSON(http.StatusOK, point)
+}
+
+func (h *GeometryHandler) DeletePoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	err = h.geometryService.DeletePoint(c.Request.Context(), uint(id), version)
+	if err != nil {
+		logger.Errorf("Failed to delete point: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
 }
 
 func (h *GeometryHandler) CreateContour(c *gin.Context) {
 	var req dto.CreateContourRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("Failed to bind request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidBody(err))
 		return
 	}
 
 	Contour := req.ToModel()
 
-	err := h.geometryService.CreateContour(&Contour)
+	err := h.geometryService.CreateContour(c.Request.Context(), &Contour)
 	if err != nil {
 		logger.Errorf("Failed to create Contour: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -134,47 +270,81 @@ func (h *GeometryHandler) GetContours(c *gin.Context) {
 	page, offset, limit, err := h.parseOffsetLimit(c)
 	if err != nil {
 		logger.Errorf("Failed to parse page: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	contours, err := h.geometryService.GetContours(offset, limit)
+	bbox, err := h.parseBBox(c)
+	if err != nil {
+		logger.Errorf("Failed to parse bbox: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	tolerance, err := parseSimplify(c)
+	if err != nil {
+		logger.Errorf("Failed to parse simplify: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	var contours []models.Contour
+	if bbox != nil {
+		contours, err = h.geometryService.GetContoursByBBox(c.Request.Context(), *bbox, offset, limit)
+	} else {
+		contours, err = h.geometryService.GetContours(c.Request.Context(), offset, limit)
+	}
+
 	if err != nil {
 		logger.Errorf("Failed to get contours: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	resp := dto.Response{
-		Count:    len(contours),
-		Next:     h.buildNextURL("/contours", page),
-		Previous: h.buildPreviousURL("/contours", page),
-		Results:  contours,
+	for i := range contours {
+		contours[i].Data = contours[i].Data.Simplify(tolerance)
 	}
 
-	c.JSON(http.StatusOK, resp)
+	h.respondContours(c, page, contours)
 }
 
 func (h *GeometryHandler) GetContourByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		logger.Errorf("Failed to parse id: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidParameter("id", err))
 		return
 	}
 
-	contour, err := h.geometryService.GetContourByID(uint(id))
+	tolerance, err := parseSimplify(c)
 	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
+		logger.Errorf("Failed to parse simplify: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	asOf, err := parseAsOf(c)
+	if err != nil {
+		logger.Errorf("Failed to parse as_of: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	var contour *models.Contour
+	if asOf != nil {
+		contour, err = h.geometryService.GetContourAsOf(c.Request.Context(), uint(id), *asOf)
+	} else {
+		contour, err = h.geometryService.GetContourByID(c.Request.Context(), uint(id))
+	}
 
+	if err != nil {
 		logger.Errorf("Failed to get contour: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	contour.Data = contour.Data.Simplify(tolerance)
+	setVariantETag(c, contour.Version, "simplify", "as_of")
 	c.JSON(http.StatusOK, contour)
 }
 
@@ -182,32 +352,36 @@ func (h *GeometryHandler) UpdateContour(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		logger.Errorf("Failed to parse id: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
 		return
 	}
 
 	var req dto.CreateContourRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		logger.Errorf("Failed to bind request: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidBody(err))
 		return
 	}
 
 	contour := req.ToModel()
 	contour.ID = uint(id)
+	contour.Version = version
 
-	err = h.geometryService.UpdateContour(&contour)
+	err = h.geometryService.UpdateContour(c.Request.Context(), &contour)
 	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-
 		logger.Errorf("Failed to update contour: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	setVersionETag(c, contour.Version)
 	c.JSON(http.StatusOK, contour)
 }
 
@@ -215,19 +389,21 @@ func (h *GeometryHandler) DeleteContour(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		logger.Errorf("Failed to parse id: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidParameter("id", err))
 		return
 	}
 
-	err = h.geometryService.DeleteContour(uint(id))
+	version, err := parseIfMatch(c)
 	if err != nil {
-		if errors.Is(err, constants.ErrNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
+		return
+	}
 
+	err = h.geometryService.DeleteContour(c.Request.Context(), uint(id), version)
+	if err != nil {
 		logger.Errorf("Failed to delete contour: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -238,21 +414,21 @@ func (h *GeometryHandler) Intersect(c *gin.Context) {
 	contourIDA, err := strconv.Atoi(c.Query("contour_1"))
 	if err != nil {
 		logger.Errorf("Failed to parse contourA: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidParameter("contour_1", err))
 		return
 	}
 
 	contourIDB, err := strconv.Atoi(c.Query("contour_2"))
 	if err != nil {
 		logger.Errorf("Failed to parse contourB: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, invalidParameter("contour_2", err))
 		return
 	}
 
-	contours, err := h.geometryService.GetContoursIntersectArea(uint(contourIDA), uint(contourIDB))
+	contours, err := h.geometryService.GetContoursIntersectArea(c.Request.Context(), uint(contourIDA), uint(contourIDB))
 	if err != nil {
 		logger.Errorf("Failed to get contours: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -263,7 +439,7 @@ func (h *GeometryHandler) parseOffsetLimit(c *gin.Context) (int, int, int, error
 	pageStr := c.DefaultQuery("page", "0")
 	page, err := strconv.Atoi(pageStr)
 	if err != nil {
-		return 0, 0, 0, err
+		return 0, 0, 0, invalidParameter("page", err)
 	}
 
 	offset := page * DefaultLimit
@@ -272,6 +448,37 @@ func (h *GeometryHandler) parseOffsetLimit(c *gin.Context) (int, int, int, error
 	return page, offset, limit, nil
 }
 
+// parseBBox reads the optional bbox query parameter, formatted as
+// minLon,minLat,maxLon,maxLat. It returns nil when the parameter is absent.
+func (h *GeometryHandler) parseBBox(c *gin.Context) (*models.BBox, error) {
+	bboxStr := c.Query("bbox")
+	if bboxStr == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(bboxStr, ",")
+	if len(parts) != 4 {
+		return nil, constants.ErrInvalidBBox.At("bbox")
+	}
+
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, constants.ErrInvalidBBox.At("bbox")
+		}
+
+		values[i] = value
+	}
+
+	bbox := &models.BBox{MinLon: values[0], MinLat: values[1], MaxLon: values[2], MaxLat: values[3]}
+	if err := bbox.Validate(); err != nil {
+		return nil, constants.At(err, "bbox")
+	}
+
+	return bbox, nil
+}
+
 func (h *GeometryHandler) buildNextURL(path string, page int) *string {
 	res := h.host + path + "?page=" + fmt.Sprint(page+1)
 	return &res
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -29,7 +30,41 @@ func TestCreatePoint(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().CreatePoint(gomock.Any()).Return(nil)
+				mock.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).Return(nil)
+				return mock
+			},
+			requestBody: `{"data":{"type":"Point","coordinates":[5.123456,10.123456]}}`,
+		},
+		{
+			name:                 "Create point in a layer returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":0,"data":{"type":"Point","coordinates":[5.123456,10.123456]},"layer_id":2}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+					// The layer comes from the scope of the request, not
+					// from the body.
+					if point.LayerID != nil {
+						t.Errorf("Expected no layer, got %d", *point.LayerID)
+					}
+
+					layer := uint(2)
+					point.LayerID = &layer
+					return nil
+				})
+				return mock
+			},
+			requestBody: `{"data":{"type":"Point","coordinates":[5.123456,10.123456]},"layer_id":9}`,
+		},
+		{
+			name:                 "Create point in an unknown layer returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"layer does not exist","instance":"/points","code":"unknown_layer","field":"layer_id"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).Return(constants.ErrUnknownLayer.At("layer_id"))
 				return mock
 			},
 			requestBody: `{"data":{"type":"Point","coordinates":[5.123456,10.123456]}}`,
@@ -37,7 +72,7 @@ func TestCreatePoint(t *testing.T) {
 		{
 			name:                 "Create point returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"unexpected EOF"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: unexpected EOF","instance":"/points","code":"invalid_body"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -45,14 +80,27 @@ func TestCreatePoint(t *testing.T) {
 			},
 			requestBody: `{"data":{"type":"Point","coordinates":[5.123456,10.123456]}`,
 		},
+		{
+			name:                 "Create point returns BadRequest invalid point",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid point: coordinates out of range","instance":"/points","code":"invalid_point","field":"data.coordinates","details":{"position":[5,100]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				err := constants.ErrInvalidPoint.Wrap(constants.ErrCoordinatesOutOfRange).At("data.coordinates").WithDetails(map[string]any{"position": [2]float64{5, 100}})
+				mock.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).Return(err)
+				return mock
+			},
+			requestBody: `{"data":{"type":"Point","coordinates":[5,100]}}`,
+		},
 		{
 			name:                 "Create point returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().CreatePoint(gomock.Any()).Return(constants.ErrInternal)
+				mock.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).Return(constants.ErrInternal)
 				return mock
 			},
 			requestBody: `{"data":{"type":"Point","coordinates":[5.123456,10.123456]}}`,
@@ -100,7 +148,7 @@ func TestGetPoints(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetPoints(0, 10).Return([]models.Point{}, nil)
+				mock.EXPECT().GetPoints(gomock.Any(), 0, 10).Return([]models.Point{}, nil)
 				return mock
 			},
 			requestParams: "page=0",
@@ -112,7 +160,7 @@ func TestGetPoints(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetPoints(10, 10).Return([]models.Point{
+				mock.EXPECT().GetPoints(gomock.Any(), 10, 10).Return([]models.Point{
 					{
 						ID: 1,
 						Data: models.Geometry{
@@ -125,10 +173,30 @@ func TestGetPoints(t *testing.T) {
 			},
 			requestParams: "page=1",
 		},
+		{
+			name:                 "Get points returns CSV",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "id;x;y\n1;5.123456;10.123456\n",
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPoints(gomock.Any(), 10, 10).Return([]models.Point{
+					{
+						ID: 1,
+						Data: models.Geometry{
+							Type:             "Point",
+							PointCoordinates: [2]float64{5.123456, 10.123456},
+						},
+					},
+				}, nil)
+				return mock
+			},
+			requestParams: "page=1&format=csv&lon=x&lat=y&delimiter=%3B",
+		},
 		{
 			name:                 "Get points returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/points","code":"invalid_parameter","field":"page"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -139,11 +207,11 @@ func TestGetPoints(t *testing.T) {
 		{
 			name:                 "Get points returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetPoints(0, 10).Return(nil, constants.ErrInternal)
+				mock.EXPECT().GetPoints(gomock.Any(), 0, 10).Return(nil, constants.ErrInternal)
 				return mock
 			},
 			requestParams: "page=0",
@@ -155,7 +223,7 @@ func TestGetPoints(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetPointsByContourID(uint(1)).Return([]models.Point{}, nil)
+				mock.EXPECT().GetPointsByContourID(gomock.Any(), uint(1)).Return([]models.Point{}, nil)
 				return mock
 			},
 			requestParams: "contour=1",
@@ -167,7 +235,7 @@ func TestGetPoints(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetPointsByContourID(uint(1)).Return([]models.Point{
+				mock.EXPECT().GetPointsByContourID(gomock.Any(), uint(1)).Return([]models.Point{
 					{
 						ID: 1,
 						Data: models.Geometry{
@@ -183,7 +251,7 @@ func TestGetPoints(t *testing.T) {
 		{
 			name:                 "Get points with contour ID returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/points","code":"invalid_parameter","field":"contour"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -194,15 +262,98 @@ func TestGetPoints(t *testing.T) {
 		{
 			name:                 "Get points with contour ID returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetPointsByContourID(uint(1)).Return(nil, constants.ErrInternal)
+				mock.EXPECT().GetPointsByContourID(gomock.Any(), uint(1)).Return(nil, constants.ErrInternal)
 				return mock
 			},
 			requestParams: "contour=1",
 		},
+		{
+			name:                 "Get points with bbox returns OK with data",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"count":1,"next":"http://localhost/points?page=1","previous":null,"results":[{"id":1,"data":{"type":"Point","coordinates":[5.123456,10.123456]}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsByBBox(gomock.Any(), models.BBox{MinLon: 0, MinLat: 0, MaxLon: 10, MaxLat: 20}, 0, 10).Return([]models.Point{
+					{
+						ID: 1,
+						Data: models.Geometry{
+							Type:             "Point",
+							PointCoordinates: [2]float64{5.123456, 10.123456},
+						},
+					},
+				}, nil)
+				return mock
+			},
+			requestParams: "bbox=0,0,10,20",
+		},
+		{
+			name:                 "Get points with bbox returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid bbox","instance":"/points","code":"invalid_bbox","field":"bbox"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestParams: "bbox=0,0,10",
+		},
+		{
+			name:                 "Get points by geohash returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"count":1,"next":"http://localhost/points?page=1","previous":null,"results":[{"id":1,"data":{"type":"Point","coordinates":[106.66,10.76]},"geohash":"w3gvk1td8"}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsByGeohash(gomock.Any(), "w3gv", 0, 10).Return([]models.Point{
+					{
+						ID:      1,
+						Data:    models.Geometry{Type: "Point", PointCoordinates: [2]float64{106.66, 10.76}},
+						Geohash: "w3gvk1td8",
+					},
+				}, nil)
+				return mock
+			},
+			requestParams: "geohash=w3gv",
+		},
+		{
+			name:                 "Get points by invalid geohash returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid geohash","instance":"/points","code":"invalid_geohash"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsByGeohash(gomock.Any(), "w3ga", 0, 10).Return(nil, constants.ErrInvalidGeohash)
+				return mock
+			},
+			requestParams: "geohash=w3ga",
+		},
+		{
+			name:                 "Get points with geohash and bbox returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"geohash filter cannot be combined with contour or bbox filters","instance":"/points","code":"conflicting_geohash"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestParams: "geohash=w3gv&bbox=0,0,10,10",
+		},
+		{
+			name:                 "Get points with contour and bbox returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"contour and bbox filters cannot be combined","instance":"/points","code":"conflicting_filters"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestParams: "contour=1&bbox=0,0,10,20",
+		},
 	}
 
 	for _, tt := range tests {
@@ -230,6 +381,190 @@ func TestGetPoints(t *testing.T) {
 	}
 }
 
+func TestUpdatePoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+		requestBody          string
+	}{
+		{
+			name:                 "Update Point returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Point","coordinates":[30,10]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).Return(nil)
+				return mock
+			},
+			requestPath: "/1",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+		{
+			name:                 "Update Point returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: unexpected EOF","instance":"/points/1","code":"invalid_body"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestPath: "/1",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}`,
+		},
+		{
+			name:                 "Update Point returns BadRequest invalid params",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/points/a","code":"invalid_parameter","field":"id"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestPath: "/a",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+		{
+			name:                 "Update Point returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/points/1","code":"not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).Return(constants.ErrNotFound)
+				return mock
+			},
+			requestPath: "/1",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+		{
+			name:                 "Update Point returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/1","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).Return(constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/1",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPut, "/points"+tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeletePoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:                 "Delete Point returns NoContent",
+			expectedStatusCode:   http.StatusNoContent,
+			expectedResponseBody: "",
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeletePoint(gomock.Any(), uint(1), uint(0)).Return(nil)
+				return mock
+			},
+			requestPath: "/1",
+		},
+		{
+			name:                 "Delete Point returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/points/a","code":"invalid_parameter","field":"id"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestPath: "/a",
+		},
+		{
+			name:                 "Delete Point returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/points/1","code":"not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeletePoint(gomock.Any(), uint(1), uint(0)).Return(constants.ErrNotFound)
+				return mock
+			},
+			requestPath: "/1",
+		},
+		{
+			name:                 "Delete Point returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/1","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeletePoint(gomock.Any(), uint(1), uint(0)).Return(constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodDelete, "/points"+tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestCreateContour(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tests := []struct {
@@ -246,7 +581,7 @@ func TestCreateContour(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().CreateContour(gomock.Any()).Return(nil)
+				mock.EXPECT().CreateContour(gomock.Any(), gomock.Any()).Return(nil)
 				return mock
 			},
 			requestBody: `{"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]}}`,
@@ -254,7 +589,7 @@ func TestCreateContour(t *testing.T) {
 		{
 			name:                 "Create Contour returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"unexpected EOF"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: unexpected EOF","instance":"/contours","code":"invalid_body"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -265,11 +600,11 @@ func TestCreateContour(t *testing.T) {
 		{
 			name:                 "Create Contour returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().CreateContour(gomock.Any()).Return(constants.ErrInternal)
+				mock.EXPECT().CreateContour(gomock.Any(), gomock.Any()).Return(constants.ErrInternal)
 				return mock
 			},
 			requestBody: `{"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]}}`,
@@ -317,7 +652,7 @@ func TestGetContours(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContours(0, 10).Return([]models.Contour{}, nil)
+				mock.EXPECT().GetContours(gomock.Any(), 0, 10).Return([]models.Contour{}, nil)
 				return mock
 			},
 			requestParams: "page=0",
@@ -329,7 +664,7 @@ func TestGetContours(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContours(10, 10).Return([]models.Contour{
+				mock.EXPECT().GetContours(gomock.Any(), 10, 10).Return([]models.Contour{
 					{
 						ID: 1,
 						Data: models.Geometry{
@@ -342,10 +677,33 @@ func TestGetContours(t *testing.T) {
 			},
 			requestParams: "page=1",
 		},
+		{
+			name:               "Get Contours returns KML",
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><Placemark id="1"><name>Field</name><Polygon><outerBoundaryIs><LinearRing>` +
+				`<coordinates>30,10 40,40 20,40 30,10</coordinates></LinearRing></outerBoundaryIs></Polygon></Placemark></Document></kml>` + "\n",
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContours(gomock.Any(), 0, 10).Return([]models.Contour{
+					{
+						ID: 1,
+						Data: models.Geometry{
+							Type:               "Polygon",
+							PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {30, 10}}},
+						},
+						Properties: models.Properties{"name": "Field"},
+					},
+				}, nil)
+				return mock
+			},
+			requestParams: "page=0&format=kml",
+		},
 		{
 			name:                 "Get Contours returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/contours","code":"invalid_parameter","field":"page"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -356,15 +714,38 @@ func TestGetContours(t *testing.T) {
 		{
 			name:                 "Get Contours returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContours(0, 10).Return(nil, constants.ErrInternal)
+				mock.EXPECT().GetContours(gomock.Any(), 0, 10).Return(nil, constants.ErrInternal)
 				return mock
 			},
 			requestParams: "page=0",
 		},
+		{
+			name:                 "Get Contours with bbox returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"count":0,"next":"http://localhost/contours?page=1","previous":null,"results":[]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContoursByBBox(gomock.Any(), models.BBox{MinLon: -10, MinLat: -10, MaxLon: 10, MaxLat: 10}, 0, 10).Return([]models.Contour{}, nil)
+				return mock
+			},
+			requestParams: "bbox=-10,-10,10,10",
+		},
+		{
+			name:                 "Get Contours with bbox returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"coordinates out of range","instance":"/contours","code":"coordinates_out_of_range","field":"bbox"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestParams: "bbox=-200,-10,10,10",
+		},
 	}
 
 	for _, tt := range tests {
@@ -408,7 +789,7 @@ func TestGetContourByID(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{
+				mock.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{
 					ID: uint(1),
 					Data: models.Geometry{
 						Type:               "Polygon",
@@ -422,7 +803,7 @@ func TestGetContourByID(t *testing.T) {
 		{
 			name:                 "Get Contour by ID returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/contours/a","code":"invalid_parameter","field":"id"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -433,11 +814,11 @@ func TestGetContourByID(t *testing.T) {
 		{
 			name:                 "Get Contour by ID returns NotFound",
 			expectedStatusCode:   http.StatusNotFound,
-			expectedResponseBody: `{"error":"not found"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/contours/1","code":"contour_not_found"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{}, constants.ErrNotFound)
+				mock.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound)
 				return mock
 			},
 			requestPath: "/1",
@@ -445,11 +826,11 @@ func TestGetContourByID(t *testing.T) {
 		{
 			name:                 "Get Contour by ID returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours/1","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{}, constants.ErrInternal)
+				mock.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{}, constants.ErrInternal)
 				return mock
 			},
 			requestPath: "/1",
@@ -498,7 +879,7 @@ func TestUpdateContour(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().UpdateContour(gomock.Any()).Return(nil)
+				mock.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).Return(nil)
 				return mock
 			},
 			requestPath: "/1",
@@ -507,7 +888,7 @@ func TestUpdateContour(t *testing.T) {
 		{
 			name:                 "Update Contour returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"unexpected EOF"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid request body: unexpected EOF","instance":"/contours/1","code":"invalid_body"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -519,7 +900,7 @@ func TestUpdateContour(t *testing.T) {
 		{
 			name:                 "Update Contour returns BadRequest invalid params",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/contours/a","code":"invalid_parameter","field":"id"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -531,11 +912,11 @@ func TestUpdateContour(t *testing.T) {
 		{
 			name:                 "Update Contour returns NotFound",
 			expectedStatusCode:   http.StatusNotFound,
-			expectedResponseBody: `{"error":"not found"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/contours/1","code":"not_found"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().UpdateContour(gomock.Any()).Return(constants.ErrNotFound)
+				mock.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).Return(constants.ErrNotFound)
 				return mock
 			},
 			requestPath: "/1",
@@ -544,11 +925,11 @@ func TestUpdateContour(t *testing.T) {
 		{
 			name:                 "Update Contour returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours/1","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().UpdateContour(gomock.Any()).Return(constants.ErrInternal)
+				mock.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).Return(constants.ErrInternal)
 				return mock
 			},
 			requestPath: "/1",
@@ -597,7 +978,7 @@ func TestDeleteContour(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().DeleteContour(uint(1)).Return(nil)
+				mock.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(0)).Return(nil)
 				return mock
 			},
 			requestPath: "/1",
@@ -605,7 +986,7 @@ func TestDeleteContour(t *testing.T) {
 		{
 			name:                 "Delete Contour returns BadRequest",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/contours/a","code":"invalid_parameter","field":"id"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -616,11 +997,11 @@ func TestDeleteContour(t *testing.T) {
 		{
 			name:                 "Delete Contour returns NotFound",
 			expectedStatusCode:   http.StatusNotFound,
-			expectedResponseBody: `{"error":"not found"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/contours/1","code":"not_found"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().DeleteContour(uint(1)).Return(constants.ErrNotFound)
+				mock.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(0)).Return(constants.ErrNotFound)
 				return mock
 			},
 			requestPath: "/1",
@@ -628,11 +1009,11 @@ func TestDeleteContour(t *testing.T) {
 		{
 			name:                 "Delete Contour returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours/1","code":"internal"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().DeleteContour(uint(1)).Return(constants.ErrInternal)
+				mock.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(0)).Return(constants.ErrInternal)
 				return mock
 			},
 			requestPath: "/1",
@@ -681,7 +1062,7 @@ func TestIntersect(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContoursIntersectArea(uint(1), uint(2)).Return([]models.Contour{}, nil)
+				mock.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return([]models.Contour{}, nil)
 				return mock
 			},
 			requestParams: "contour_1=1&contour_2=2",
@@ -693,7 +1074,7 @@ func TestIntersect(t *testing.T) {
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContoursIntersectArea(uint(1), uint(2)).Return([]models.Contour{
+				mock.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return([]models.Contour{
 					{
 						ID: 1,
 						Data: models.Geometry{
@@ -709,7 +1090,7 @@ func TestIntersect(t *testing.T) {
 		{
 			name:                 "Intersect returns BadRequest contour_1",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/intersections","code":"invalid_parameter","field":"contour_1"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -720,7 +1101,7 @@ func TestIntersect(t *testing.T) {
 		{
 			name:                 "Intersect returns BadRequest contour_2",
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"strconv.Atoi: parsing \"a\": invalid syntax"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/intersections","code":"invalid_parameter","field":"contour_2"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
@@ -731,11 +1112,23 @@ func TestIntersect(t *testing.T) {
 		{
 			name:                 "Intersect returns InternalServerError",
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"internal error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/intersections","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestParams: "contour_1=1&contour_2=2",
+		},
+		{
+			name:                 "Intersect returns GatewayTimeout",
+			expectedStatusCode:   http.StatusGatewayTimeout,
+			expectedResponseBody: `{"type":"about:blank","title":"Gateway Timeout","status":504,"detail":"context deadline exceeded","instance":"/intersections","code":"timeout"}`,
 			mocks: func() *mock_service.MockGeometryService {
 				ctrl := gomock.NewController(t)
 				mock := mock_service.NewMockGeometryService(ctrl)
-				mock.EXPECT().GetContoursIntersectArea(uint(1), uint(2)).Return(nil, constants.ErrInternal)
+				mock.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return(nil, context.DeadlineExceeded)
 				return mock
 			},
 			requestParams: "contour_1=1&contour_2=2",
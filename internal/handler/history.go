@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// GetContourHistory lists the versions a contour went through, oldest first.
+func (h *GeometryHandler) GetContourHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	history, err := h.geometryService.GetContourHistory(c.Request.Context(), uint(id))
+	if err != nil {
+		logger.Errorf("Failed to get contour history: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ContourHistoryResponse{ContourID: uint(id), Versions: history})
+}
+
+// RestoreContour reverts a contour to the version of its history given by the
+// version query parameter. Like an update, it honours If-Match. Without a
+// version, it takes the contour out of the trash.
+func (h *GeometryHandler) RestoreContour(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	value, ok := c.GetQuery("version")
+	if !ok {
+		h.undeleteContour(c, uint(id))
+		return
+	}
+
+	version, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		logger.Errorf("Failed to parse version: %v", err)
+		respondError(c, invalidParameter("version", err))
+		return
+	}
+
+	expected, err := parseIfMatch(c)
+	if err != nil {
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	contour, err := h.geometryService.RestoreContour(c.Request.Context(), uint(id), uint(version), expected)
+	if err != nil {
+		logger.Errorf("Failed to restore contour: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	setVersionETag(c, contour.Version)
+	c.JSON(http.StatusOK, contour)
+}
+
+func (h *GeometryHandler) undeleteContour(c *gin.Context, id uint) {
+	contour, err := h.geometryService.UndeleteContour(c.Request.Context(), id)
+	if err != nil {
+		logger.Errorf("Failed to undelete contour: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	setVersionETag(c, contour.Version)
+	c.JSON(http.StatusOK, contour)
+}
+
+// parseAsOf reads the optional as_of RFC 3339 timestamp, nil when the current
+// version is wanted.
+func parseAsOf(c *gin.Context) (*time.Time, error) {
+	value, ok := c.GetQuery("as_of")
+	if !ok {
+		return nil, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, invalidParameter("as_of", err)
+	}
+
+	return &asOf, nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestContourHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	polygon := models.Geometry{
+		Type:               "Polygon",
+		PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {10, 20}, {30, 10}}},
+	}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedETag         string
+		mocks                func() *mock_service.MockGeometryService
+		method               string
+		requestPath          string
+		ifMatch              string
+	}{
+		{
+			name:                 "Get Contour history returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"contour_id":1,"versions":[{"contour_id":1,"version":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"operation":"update","actor":"alice","valid_from":"2024-01-01T00:00:00Z","valid_to":"2024-02-01T00:00:00Z"}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return([]models.ContourHistory{{
+					ContourID: 1,
+					Version:   1,
+					Data:      polygon,
+					Operation: models.OperationUpdate,
+					Actor:     "alice",
+					ValidFrom: &validFrom,
+					ValidTo:   validFrom.AddDate(0, 1, 0),
+				}}, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1/history",
+		},
+		{
+			name:                 "Get Contour history returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/contours/1/history","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1/history",
+		},
+		{
+			name:                 "Get Contour as of returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"version":1,"updated_at":"2024-01-01T00:00:00Z"}`,
+			expectedETag:         `"1-caa7bee502c85c7f"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContourAsOf(gomock.Any(), uint(1), validFrom.AddDate(0, 0, 1)).
+					Return(&models.Contour{ID: 1, Data: polygon, Version: 1, UpdatedAt: &validFrom}, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1?as_of=2024-01-02T00:00:00Z",
+		},
+		{
+			name:                 "Get Contour as of returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"","instance":"/contours/1","code":"invalid_parameter","field":"as_of"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1?as_of=yesterday",
+		},
+		{
+			name:                 "Restore Contour returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"version":4}`,
+			expectedETag:         `"4"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().RestoreContour(gomock.Any(), uint(1), uint(1), uint(3)).
+					Return(&models.Contour{ID: 1, Data: polygon, Version: 4}, nil)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/contours/1/restore?version=1",
+			ifMatch:     `"3"`,
+		},
+		{
+			name:                 "Restore Contour returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.ParseUint: parsing \"\": invalid syntax","instance":"/contours/1/restore","code":"invalid_parameter","field":"version"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/contours/1/restore?version=",
+		},
+		{
+			name:                 "Restore Contour returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour version not found","instance":"/contours/1/restore","code":"contour_version_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().RestoreContour(gomock.Any(), uint(1), uint(7), uint(0)).Return(nil, constants.ErrContourVersionNotFound)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/contours/1/restore?version=7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(tt.method, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.expectedETag {
+				t.Errorf("Expected ETag %s, got %s", tt.expectedETag, got)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// GetPointsHull returns the convex or concave hull of a point set as a
+// contour, stored when persist is set.
+func (h *GeometryHandler) GetPointsHull(c *gin.Context) {
+	var req dto.HullRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	hull, input, err := req.ToModel()
+	if err != nil {
+		logger.Errorf("Failed to parse hull request: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	contour, err := h.geometryService.GetPointsHull(c.Request.Context(), hull, input, req.Persist)
+	if err != nil {
+		logger.Errorf("Failed to get hull: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	if req.Persist {
+		c.JSON(http.StatusCreated, contour)
+		return
+	}
+
+	c.JSON(http.StatusOK, contour)
+}
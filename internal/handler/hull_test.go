@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPointsHull(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestBody          string
+	}{
+		{
+			name:                 "Hull of inline points returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsHull(gomock.Any(),
+					models.Hull{Type: models.HullConvex, TargetPercent: models.DefaultTargetPercent},
+					models.HullInput{Positions: [][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}}},
+					false,
+				).Return(bufferContour(0), nil)
+				return mock
+			},
+			requestBody: `{"points":[[0,0],[1,0],[1,1],[0,1]]}`,
+		},
+		{
+			name:                 "Concave hull of stored points returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":9,"data":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsHull(gomock.Any(),
+					models.Hull{Type: models.HullConcave, TargetPercent: 0.3},
+					models.HullInput{IDs: []uint{1, 2, 3}, BBox: &models.BBox{MinLon: 0, MinLat: 0, MaxLon: 1, MaxLat: 1}},
+					true,
+				).Return(bufferContour(9), nil)
+				return mock
+			},
+			requestBody: `{"type":"concave","target_percent":0.3,"ids":[1,2,3],"bbox":[0,0,1,1],"persist":true}`,
+		},
+		{
+			name:                 "Hull returns BadRequest on bbox",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid bbox","instance":"/points/hull","code":"invalid_bbox","field":"bbox"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestBody: `{"bbox":[0,0,1]}`,
+		},
+		{
+			name:                 "Hull returns BadRequest on conflicting input",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"inline points cannot be combined with filters","instance":"/points/hull","code":"conflicting_hull_input"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsHull(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, constants.ErrConflictingHullInput)
+				return mock
+			},
+			requestBody: `{"points":[[0,0]],"contour":2}`,
+		},
+		{
+			name:                 "Hull returns UnprocessableEntity",
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"hull needs at least three points not on a line","instance":"/points/hull","code":"degenerate_hull"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsHull(gomock.Any(), gomock.Any(), models.HullInput{ContourID: 2}, false).Return(nil, constants.ErrDegenerateHull)
+				return mock
+			},
+			requestBody: `{"contour":2}`,
+		},
+		{
+			name:                 "Hull returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/hull","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointsHull(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestBody: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, "/points/hull", strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
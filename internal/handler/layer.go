@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+func (h *GeometryHandler) CreateLayer(c *gin.Context) {
+	var req dto.CreateLayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	layer := req.ToModel()
+
+	err := h.geometryService.CreateLayer(c.Request.Context(), &layer)
+	if err != nil {
+		logger.Errorf("Failed to create layer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, layer)
+}
+
+func (h *GeometryHandler) GetLayers(c *gin.Context) {
+	page, offset, limit, err := h.parseOffsetLimit(c)
+	if err != nil {
+		logger.Errorf("Failed to parse page: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	layers, err := h.geometryService.GetLayers(c.Request.Context(), offset, limit)
+	if err != nil {
+		logger.Errorf("Failed to get layers: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.Response{
+		Count:    len(layers),
+		Next:     h.buildNextURL("/layers", page),
+		Previous: h.buildPreviousURL("/layers", page),
+		Results:  layers,
+	})
+}
+
+func (h *GeometryHandler) GetLayerByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	layer, err := h.geometryService.GetLayerByID(c.Request.Context(), uint(id))
+	if err != nil {
+		logger.Errorf("Failed to get layer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, layer)
+}
+
+// UpdateLayer renames a layer and replaces its description. Points and
+// contours keep belonging to it.
+func (h *GeometryHandler) UpdateLayer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	var req dto.CreateLayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	layer := req.ToModel()
+	layer.ID = uint(id)
+
+	err = h.geometryService.UpdateLayer(c.Request.Context(), &layer)
+	if err != nil {
+		logger.Errorf("Failed to update layer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, layer)
+}
+
+// DeleteLayer deletes a layer, which fails while it still has points or
+// contours.
+func (h *GeometryHandler) DeleteLayer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	err = h.geometryService.DeleteLayer(c.Request.Context(), uint(id))
+	if err != nil {
+		logger.Errorf("Failed to delete layer: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLayers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stores := models.Layer{ID: 1, Name: "stores", Description: "Store locations", CreatedAt: at, UpdatedAt: at}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		method               string
+		requestPath          string
+		requestBody          string
+	}{
+		{
+			name:                 "Create Layer returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"id":1,"name":"stores","description":"Store locations","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().CreateLayer(gomock.Any(), &models.Layer{Name: "stores", Description: "Store locations"}).
+					DoAndReturn(func(_ any, layer *models.Layer) error {
+						*layer = stores
+						return nil
+					})
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/layers",
+			requestBody: `{"name":"stores","description":"Store locations"}`,
+		},
+		{
+			name:                 "Create Layer returns Conflict",
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"a layer with this name exists","instance":"/layers","code":"layer_exists","field":"name"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().CreateLayer(gomock.Any(), gomock.Any()).Return(constants.ErrLayerExists.At("name"))
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/layers",
+			requestBody: `{"name":"stores"}`,
+		},
+		{
+			name:                 "Get Layers returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"count":1,"next":"http://localhost/layers?page=1","previous":null,"results":[{"id":1,"name":"stores","description":"Store locations","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetLayers(gomock.Any(), 0, 10).Return([]models.Layer{stores}, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/layers",
+		},
+		{
+			name:                 "Get Layer returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"layer not found","instance":"/layers/2","code":"layer_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetLayerByID(gomock.Any(), uint(2)).Return(nil, constants.ErrLayerNotFound)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/layers/2",
+		},
+		{
+			name:                 "Update Layer returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"name":"shops","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdateLayer(gomock.Any(), &models.Layer{ID: 1, Name: "shops"}).
+					DoAndReturn(func(_ any, layer *models.Layer) error {
+						layer.CreatedAt, layer.UpdatedAt = at, at
+						return nil
+					})
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/layers/1",
+			requestBody: `{"name":"shops"}`,
+		},
+		{
+			name:                 "Update Layer returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid layer name","instance":"/layers/1","code":"invalid_layer_name","field":"name"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdateLayer(gomock.Any(), gomock.Any()).Return(constants.ErrInvalidLayerName.At("name"))
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/layers/1",
+			requestBody: `{"name":"Shops"}`,
+		},
+		{
+			name:               "Delete Layer returns NoContent",
+			expectedStatusCode: http.StatusNoContent,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeleteLayer(gomock.Any(), uint(1)).Return(nil)
+				return mock
+			},
+			method:      http.MethodDelete,
+			requestPath: "/layers/1",
+		},
+		{
+			name:                 "Delete Layer returns Conflict",
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"layer still has points or contours","instance":"/layers/1","code":"layer_not_empty","details":{"count":3}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeleteLayer(gomock.Any(), uint(1)).Return(constants.ErrLayerNotEmpty.WithDetails(map[string]any{"count": 3}))
+				return mock
+			},
+			method:      http.MethodDelete,
+			requestPath: "/layers/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(tt.method, tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// listFormat picks the representation of a list response from the format
+// query parameter, falling back to the Accept header. JSON is the default.
+func listFormat(c *gin.Context) string {
+	switch c.Query("format") {
+	case "csv":
+		return codec.MediaTypeCSV
+	case "kml":
+		return codec.MediaTypeKML
+	case "gpx":
+		return codec.MediaTypeGPX
+	case "json":
+		return gin.MIMEJSON
+	}
+
+	if mediaType := c.NegotiateFormat(gin.MIMEJSON, codec.MediaTypeCSV, codec.MediaTypeKML, codec.MediaTypeGPX); mediaType != "" {
+		return mediaType
+	}
+
+	return gin.MIMEJSON
+}
+
+// respondPoints writes a page of points in the negotiated format.
+func (h *GeometryHandler) respondPoints(c *gin.Context, page int, points []models.Point) {
+	h.respondList(c, "/points", page, points, pointFeatures(points), "")
+}
+
+// respondContours writes a page of contours in the negotiated format. CSV
+// holds their WKT, polygons having no lon/lat representation.
+func (h *GeometryHandler) respondContours(c *gin.Context, page int, contours []models.Contour) {
+	h.respondList(c, "/contours", page, contours, contourFeatures(contours), "wkt")
+}
+
+// respondList writes results as a JSON page, or features in the negotiated
+// format with the pagination links in a Link header.
+func (h *GeometryHandler) respondList(c *gin.Context, path string, page int, results any, features []codec.Feature, wktColumn string) {
+	mediaType := listFormat(c)
+	if mediaType == gin.MIMEJSON {
+		resp := dto.Response{
+			Count:    len(features),
+			Next:     h.buildNextURL(path, page),
+			Previous: h.buildPreviousURL(path, page),
+			Results:  results,
+		}
+
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	w, err := listWriter(c, mediaType, wktColumn)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.setLinkHeader(c, path, page)
+	h.writeFeatures(c, w, mediaType, features)
+}
+
+// listWriter returns the writer of a list response. CSV layouts are described
+// with the same query parameters as CSV imports.
+func listWriter(c *gin.Context, mediaType, wktColumn string) (codec.Writer, error) {
+	if mediaType != codec.MediaTypeCSV {
+		return codec.NewWriter(mediaType, c.Writer)
+	}
+
+	opts, err := parseCSVOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.WKTColumn == "" {
+		opts.WKTColumn = wktColumn
+	}
+
+	return codec.NewCSVWriter(c.Writer, opts), nil
+}
+
+func (h *GeometryHandler) writeFeatures(c *gin.Context, w codec.Writer, mediaType string, features []codec.Feature) {
+	c.Header("Content-Type", mediaType)
+	c.Status(http.StatusOK)
+
+	for _, f := range features {
+		if err := w.Write(f); err != nil {
+			logger.Errorf("Failed to write feature: %v", err)
+			c.Abort()
+			return
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		logger.Errorf("Failed to write features: %v", err)
+		c.Abort()
+	}
+}
+
+func (h *GeometryHandler) setLinkHeader(c *gin.Context, path string, page int) {
+	link := "<" + *h.buildNextURL(path, page) + `>; rel="next"`
+	if previous := h.buildPreviousURL(path, page); previous != nil {
+		link += ", <" + *previous + `>; rel="prev"`
+	}
+
+	c.Header("Link", link)
+}
+
+func pointFeatures(points []models.Point) []codec.Feature {
+	features := make([]codec.Feature, len(points))
+	for i, p := range points {
+		features[i] = codec.Feature{ID: p.ID, Geometry: p.Data, Properties: p.Properties}
+	}
+
+	return features
+}
+
+func contourFeatures(contours []models.Contour) []codec.Feature {
+	features := make([]codec.Feature, len(contours))
+	for i, contour := range contours {
+		features[i] = codec.Feature{ID: contour.ID, Geometry: contour.Data, Properties: contour.Properties}
+	}
+
+	return features
+}
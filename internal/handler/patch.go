@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// acceptPatch lists the media types of the patches accepted, as advertised
+// by the Accept-Patch header.
+const acceptPatch = models.MediaTypeMergePatch + ", " + models.MediaTypeJSONPatch
+
+// PatchPoint applies a merge patch or a JSON Patch, as told by the content
+// type, to the data and properties of a point. Like an update, it honours
+// If-Match.
+func (h *GeometryHandler) PatchPoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	patch, err := readPatch(c)
+	if err != nil {
+		logger.Errorf("Failed to read patch: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	point, err := h.geometryService.PatchPoint(c.Request.Context(), uint(id), version, patch)
+	if err != nil {
+		logger.Errorf("Failed to patch point: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	setVersionETag(c, point.Version)
+	c.JSON(http.StatusOK, point)
+}
+
+// PatchContour applies a patch to the data and properties of a contour like
+// PatchPoint.
+func (h *GeometryHandler) PatchContour(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		logger.Errorf("Failed to parse If-Match: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	patch, err := readPatch(c)
+	if err != nil {
+		logger.Errorf("Failed to read patch: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	contour, err := h.geometryService.PatchContour(c.Request.Context(), uint(id), version, patch)
+	if err != nil {
+		logger.Errorf("Failed to patch contour: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	setVersionETag(c, contour.Version)
+	c.JSON(http.StatusOK, contour)
+}
+
+// readPatch reads the patch in the request body. Other content types are
+// refused, with the accepted ones in Accept-Patch as RFC 5789 suggests.
+func readPatch(c *gin.Context) (models.Patch, error) {
+	mediaType := c.ContentType()
+	if mediaType != models.MediaTypeMergePatch && mediaType != models.MediaTypeJSONPatch {
+		c.Header("Accept-Patch", acceptPatch)
+		return models.Patch{}, constants.ErrUnsupportedMediaType
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return models.Patch{}, invalidBody(err)
+	}
+
+	return models.Patch{MediaType: mediaType, Body: body}, nil
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	polygon := models.Geometry{
+		Type:               "Polygon",
+		PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {10, 20}, {30, 10}}},
+	}
+	mergePatch := `{"properties":{"name":"Field"}}`
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedETag         string
+		expectedAcceptPatch  string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+		contentType          string
+		requestBody          string
+		ifMatch              string
+	}{
+		{
+			name:                 "Patch Contour returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"properties":{"name":"Field"},"version":4}`,
+			expectedETag:         `"4"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().PatchContour(gomock.Any(), uint(1), uint(3), models.Patch{MediaType: models.MediaTypeMergePatch, Body: []byte(mergePatch)}).
+					Return(&models.Contour{ID: 1, Data: polygon, Properties: models.Properties{"name": "Field"}, Version: 4}, nil)
+				return mock
+			},
+			requestPath: "/contours/1",
+			contentType: models.MediaTypeMergePatch,
+			requestBody: mergePatch,
+			ifMatch:     `"3"`,
+		},
+		{
+			name:                 "Patch Contour returns Conflict",
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"patch does not apply to the current document","instance":"/contours/1","code":"patch_conflict"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().PatchContour(gomock.Any(), uint(1), uint(0), gomock.Any()).Return(nil, constants.ErrPatchConflict)
+				return mock
+			},
+			requestPath: "/contours/1",
+			contentType: models.MediaTypeJSONPatch,
+			requestBody: `[{"op":"test","path":"/properties/name","value":"Field"}]`,
+		},
+		{
+			name:                 "Patch Contour with stale If-Match returns PreconditionFailed",
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"version does not match the current one","instance":"/contours/1","code":"version_mismatch","details":{"version":4}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().PatchContour(gomock.Any(), uint(1), uint(3), gomock.Any()).
+					Return(nil, constants.ErrVersionMismatch.WithDetails(map[string]any{"version": 4}))
+				return mock
+			},
+			requestPath: "/contours/1",
+			contentType: models.MediaTypeMergePatch,
+			requestBody: mergePatch,
+			ifMatch:     `"3"`,
+		},
+		{
+			name:                 "Patch Point returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Point","coordinates":[31,11]},"version":2}`,
+			expectedETag:         `"2"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().PatchPoint(gomock.Any(), uint(1), uint(0), gomock.Any()).
+					Return(&models.Point{ID: 1, Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{31, 11}}, Version: 2}, nil)
+				return mock
+			},
+			requestPath: "/points/1",
+			contentType: models.MediaTypeJSONPatch + "; charset=utf-8",
+			requestBody: `[{"op":"replace","path":"/data/coordinates","value":[31,11]}]`,
+		},
+		{
+			name:                 "Patch Point with JSON returns UnsupportedMediaType",
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedResponseBody: `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"unsupported media type","instance":"/points/1","code":"unsupported_media_type"}`,
+			expectedAcceptPatch:  "application/merge-patch+json, application/json-patch+json",
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestPath: "/points/1",
+			contentType: "application/json",
+			requestBody: mergePatch,
+		},
+		{
+			name:                 "Patch Point returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/points/a","code":"invalid_parameter","field":"id"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			requestPath: "/points/a",
+			contentType: models.MediaTypeMergePatch,
+			requestBody: mergePatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPatch, tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.expectedETag {
+				t.Errorf("Expected ETag %s, got %s", tt.expectedETag, got)
+			}
+
+			if got := w.Header().Get("Accept-Patch"); got != tt.expectedAcceptPatch {
+				t.Errorf("Expected Accept-Patch %s, got %s", tt.expectedAcceptPatch, got)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
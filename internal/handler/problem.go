@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+)
+
+// MediaTypeProblem is the media type of error responses.
+const MediaTypeProblem = "application/problem+json"
+
+// respondError writes err as a problem details body, with the status of the
+// domain error it carries.
+func respondError(c *gin.Context, err error) {
+	problem := newProblem(c, err)
+
+	c.Header("Content-Type", MediaTypeProblem)
+	c.JSON(problem.Status, problem)
+}
+
+func newProblem(c *gin.Context, err error) dto.Problem {
+	domainErr := domainError(c, err)
+
+	title := http.StatusText(domainErr.Status)
+	if title == "" {
+		title = domainErr.Message
+	}
+
+	return dto.Problem{
+		Type:     "about:blank",
+		Title:    title,
+		Status:   domainErr.Status,
+		Detail:   err.Error(),
+		Instance: c.Request.URL.Path,
+		Code:     domainErr.Code,
+		Field:    domainErr.Field,
+		Details:  domainErr.Details,
+	}
+}
+
+// domainError returns the domain error carried by err. Anything else is an
+// internal error, unless the request timed out or the client went away. The
+// request context is checked as well, as a cancelled query may come back as
+// any database error.
+func domainError(c *gin.Context, err error) *constants.Error {
+	var domainErr *constants.Error
+	if errors.As(err, &domainErr) && !errors.Is(domainErr, constants.ErrInternal) {
+		return domainErr
+	}
+
+	for _, err := range []error{err, c.Request.Context().Err()} {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return constants.ErrTimeout
+		case errors.Is(err, context.Canceled):
+			return constants.ErrCanceled
+		}
+	}
+
+	return constants.ErrInternal
+}
+
+// invalidParameter blames the named query or path parameter for err, which
+// is reported as constants.ErrInvalidParameter unless it is a domain error.
+func invalidParameter(name string, err error) error {
+	return constants.At(constants.Wrap(err, constants.ErrInvalidParameter), name)
+}
+
+// invalidBody reports a request body that could not be bound. Type mismatches
+// point at the offending field.
+func invalidBody(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return constants.ErrInvalidBody.Wrap(err).At(typeErr.Field)
+	}
+
+	return constants.Wrap(err, constants.ErrInvalidBody)
+}
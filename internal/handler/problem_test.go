@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name                 string
+		ctx                  context.Context
+		err                  error
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Domain",
+			ctx:                  context.Background(),
+			err:                  fmt.Errorf("%w: missing lon/lat column", constants.ErrInvalidFeature),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid feature: missing lon/lat column","instance":"/points","code":"invalid_feature"}`,
+		},
+		{
+			name:                 "Kind",
+			ctx:                  context.Background(),
+			err:                  constants.ErrPointNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"point not found","instance":"/points","code":"point_not_found"}`,
+		},
+		{
+			name:                 "Field",
+			ctx:                  context.Background(),
+			err:                  invalidParameter("page", fmt.Errorf("bad page")),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: bad page","instance":"/points","code":"invalid_parameter","field":"page"}`,
+		},
+		{
+			name:                 "Unknown",
+			ctx:                  context.Background(),
+			err:                  fmt.Errorf("connection refused"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"connection refused","instance":"/points","code":"internal"}`,
+		},
+		{
+			name:                 "Timeout",
+			ctx:                  context.Background(),
+			err:                  constants.ErrInternal.Wrap(fmt.Errorf("query: %w", context.DeadlineExceeded)),
+			expectedStatusCode:   http.StatusGatewayTimeout,
+			expectedResponseBody: `{"type":"about:blank","title":"Gateway Timeout","status":504,"detail":"internal error: query: context deadline exceeded","instance":"/points","code":"timeout"}`,
+		},
+		{
+			name:                 "Canceled",
+			ctx:                  cancelled,
+			err:                  constants.ErrInternal,
+			expectedStatusCode:   constants.StatusClientClosedRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"request canceled","status":499,"detail":"internal error","instance":"/points","code":"canceled"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/points?page=a", nil).WithContext(tt.ctx)
+
+			respondError(c, tt.err)
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, MediaTypeProblem, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -15,12 +15,29 @@ func SetupRouter(conf *config.Config, db *gorm.DB) *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(cors.Default())
 	r.Use(middleware.JSONLoggerMiddleware())
+	r.Use(middleware.TimeoutMiddleware(conf.RequestTimeout, conf.RouteTimeouts))
 	r.GET("/health", Health)
 
+	// Routes registered from here on are authenticated, the actor of their
+	// requests being the subject of the token.
+	if conf.AuthIssuerURL != "" {
+		r.Use(middleware.AuthMiddleware(conf.AuthIssuerURL, conf.AuthClientID))
+	}
+
+	r.Use(middleware.ActorMiddleware())
+	r.Use(middleware.IdempotencyMiddleware(repository.NewIdempotencyRepository(db), conf.IdempotencyTTL,
+		"POST /points", "POST /contours", "POST /points:action", "POST /contours:action"))
+	layerRepository := repository.NewLayerRepository(db)
+	r.Use(middleware.LayerMiddleware(layerRepository))
+
 	// Setup geometry handler
 	pointRepository := repository.NewPointRepository(db)
 	contourRepository := repository.NewContourRepository(db)
-	geometryService := service.NewGeometryService(pointRepository, contourRepository)
+	geometryService := service.NewGeometryService(pointRepository, contourRepository,
+		service.WithGeohashPrecision(conf.GeohashPrecision),
+		service.WithTxManager(repository.NewTxManager(db)),
+		service.WithLayerRepository(layerRepository),
+	)
 	geometryHandler := NewGeometryHandler(geometryService, conf.Host)
 
 	defaultGroup := r.Group("/")
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// SimplifyContour stores the contour simplified to the tolerance, in metres,
+// as its simplified variant.
+func (h *GeometryHandler) SimplifyContour(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	tolerance, err := parseDistance(c.Query("tolerance"))
+	if err != nil || tolerance <= 0 {
+		logger.Errorf("Failed to parse tolerance: %v", err)
+		respondError(c, constants.ErrInvalidDistance.At("tolerance"))
+		return
+	}
+
+	variant, err := h.geometryService.SimplifyContour(c.Request.Context(), uint(id), tolerance)
+	if err != nil {
+		logger.Errorf("Failed to simplify contour: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, variant)
+}
+
+func (h *GeometryHandler) GetSimplifiedContour(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	variant, err := h.geometryService.GetSimplifiedContour(c.Request.Context(), uint(id))
+	if err != nil {
+		logger.Errorf("Failed to get simplified contour: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, variant)
+}
+
+// parseSimplify reads the optional simplify tolerance in metres, 0 when
+// contours are not to be simplified.
+func parseSimplify(c *gin.Context) (float64, error) {
+	value, ok := c.GetQuery("simplify")
+	if !ok {
+		return 0, nil
+	}
+
+	tolerance, err := parseDistance(value)
+	if err != nil || tolerance <= 0 {
+		return 0, constants.ErrInvalidDistance.At("simplify")
+	}
+
+	return tolerance, nil
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+// notchedSquare returns a square contour with a vertex 11m off its bottom edge.
+func notchedSquare() *models.Contour {
+	return &models.Contour{
+		ID: 1,
+		Data: models.Geometry{
+			Type:               models.PolygonType,
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0.0001}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}},
+		},
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		method               string
+		requestPath          string
+	}{
+		{
+			name:                 "Get contour simplified returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(notchedSquare(), nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1?simplify=1km",
+		},
+		{
+			name:                 "Get contour simplified returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid distance","instance":"/contours/1","code":"invalid_distance","field":"simplify"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1?simplify=-5",
+		},
+		{
+			name:                 "Get contours simplified returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"count":1,"next":"http://localhost/contours?page=1","previous":null,"results":[{"id":1,"data":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContours(gomock.Any(), 0, 10).Return([]models.Contour{*notchedSquare()}, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours?simplify=1000",
+		},
+		{
+			name:                 "Simplify contour returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"contour_id":1,"tolerance":1500,"data":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().SimplifyContour(gomock.Any(), uint(1), 1500.0).Return(&models.SimplifiedContour{
+					ContourID: 1,
+					Tolerance: 1500,
+					Data:      notchedSquare().Data.Simplify(1500),
+				}, nil)
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/contours/1/simplified?tolerance=1.5km",
+		},
+		{
+			name:                 "Simplify contour returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid distance","instance":"/contours/1/simplified","code":"invalid_distance","field":"tolerance"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			method:      http.MethodPut,
+			requestPath: "/contours/1/simplified",
+		},
+		{
+			name:                 "Simplify contour returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/contours/1/simplified","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().SimplifyContour(gomock.Any(), uint(1), 100.0).Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/contours/1/simplified?tolerance=100m",
+		},
+		{
+			name:                 "Get simplified contour returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/contours/1/simplified","code":"not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(nil, constants.ErrNotFound)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1/simplified",
+		},
+		{
+			name:                 "Get simplified contour returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/contours/1/simplified","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1/simplified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(tt.method, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
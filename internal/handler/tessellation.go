@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// TessellateContour splits a contour into cells of equal area clipped to it,
+// stored as its children when persist is set. grid is hex (the default) or
+// square and size the edge of the cells.
+func (h *GeometryHandler) TessellateContour(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Errorf("Failed to parse id: %v", err)
+		respondError(c, invalidParameter("id", err))
+		return
+	}
+
+	tessellation, persist, err := parseTessellation(c)
+	if err != nil {
+		logger.Errorf("Failed to parse tessellation: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	cells, err := h.geometryService.TessellateContour(c.Request.Context(), uint(id), tessellation, persist)
+	if err != nil {
+		logger.Errorf("Failed to tessellate contour: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	if persist {
+		c.JSON(http.StatusCreated, dto.NewTessellationResponse(cells))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTessellationResponse(cells))
+}
+
+// parseTessellation reads the grid, size, engine and persist query
+// parameters. The grid defaults to hex and the engine to postgis.
+func parseTessellation(c *gin.Context) (models.Tessellation, bool, error) {
+	tessellation := models.Tessellation{
+		Type:   models.GridType(c.DefaultQuery("grid", string(models.GridHex))),
+		Engine: models.Engine(c.DefaultQuery("engine", string(models.EnginePostGIS))),
+	}
+
+	size, err := parseDistance(c.Query("size"))
+	if err != nil {
+		return tessellation, false, constants.ErrInvalidGridSize.At("size")
+	}
+
+	tessellation.Size = size
+
+	persist, err := strconv.ParseBool(c.DefaultQuery("persist", "false"))
+	if err != nil {
+		return tessellation, false, invalidParameter("persist", err)
+	}
+
+	return tessellation, persist, nil
+}
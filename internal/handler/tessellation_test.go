@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTessellateContour(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+	}{
+		{
+			name:                 "Tessellate returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":{"i":-1,"j":2}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().TessellateContour(gomock.Any(), uint(2), models.Tessellation{Type: models.GridHex, Size: 500, Engine: models.EnginePostGIS}, false).
+					Return([]models.TessellationCell{{I: -1, J: 2, Data: bufferContour(0).Data}}, nil)
+				return mock
+			},
+			requestPath: "/contours/2/tessellate?size=500",
+		},
+		{
+			name:                 "Tessellate returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":{"contour_ids":[9],"i":0,"j":0}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().TessellateContour(gomock.Any(), uint(2), models.Tessellation{Type: models.GridSquare, Size: 1500, Engine: models.EngineGo}, true).
+					Return([]models.TessellationCell{{ContourIDs: []uint{9}, Data: bufferContour(0).Data}}, nil)
+				return mock
+			},
+			requestPath: "/contours/2/tessellate?grid=square&size=1.5km&engine=go&persist=true",
+		},
+		{
+			name:                 "Tessellate returns BadRequest on size",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid grid size","instance":"/contours/2/tessellate","code":"invalid_grid_size","field":"size"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/contours/2/tessellate?size=big",
+		},
+		{
+			name:                 "Tessellate returns BadRequest on grid",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid grid","instance":"/contours/2/tessellate","code":"invalid_grid"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().TessellateContour(gomock.Any(), uint(2), gomock.Any(), false).Return(nil, constants.ErrInvalidGrid)
+				return mock
+			},
+			requestPath: "/contours/2/tessellate?grid=triangle&size=500",
+		},
+		{
+			name:                 "Tessellate returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/contours/3/tessellate","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().TessellateContour(gomock.Any(), uint(3), gomock.Any(), false).Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			requestPath: "/contours/3/tessellate?size=500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+const (
+	mediaTypeMVT     = "application/vnd.mapbox-vector-tile"
+	tileExtension    = ".mvt"
+	tileCacheControl = "no-cache"
+)
+
+// GetTile serves /tiles/:z/:x/:y.mvt. gin cannot route on a suffix, so the
+// extension is part of the y parameter. Tiles carry an ETag derived from
+// their content, letting clients revalidate them with If-None-Match.
+func (h *GeometryHandler) GetTile(c *gin.Context) {
+	tile, err := parseTile(c)
+	if err != nil {
+		logger.Errorf("Failed to parse tile: %v", err)
+		respondError(c, constants.ErrInvalidTile)
+		return
+	}
+
+	data, err := h.geometryService.GetTile(c.Request.Context(), tile)
+	if err != nil {
+		logger.Errorf("Failed to get tile: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	if len(data) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", tileCacheControl)
+
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, mediaTypeMVT, data)
+}
+
+func parseTile(c *gin.Context) (models.Tile, error) {
+	y, ok := strings.CutSuffix(c.Param("y"), tileExtension)
+	if !ok {
+		return models.Tile{}, constants.ErrInvalidTile
+	}
+
+	var tile models.Tile
+	var err error
+
+	if tile.Z, err = strconv.Atoi(c.Param("z")); err != nil {
+		return models.Tile{}, err
+	}
+
+	if tile.X, err = strconv.Atoi(c.Param("x")); err != nil {
+		return models.Tile{}, err
+	}
+
+	if tile.Y, err = strconv.Atoi(y); err != nil {
+		return models.Tile{}, err
+	}
+
+	return tile, nil
+}
+
+// matchesETag reports whether an If-None-Match header lists etag, comparing
+// weakly as RFC 9110 requires for this header.
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
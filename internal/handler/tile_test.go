@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tile := models.Tile{Z: 3, X: 4, Y: 2}
+	etag := `"8b668b8994aa845107399994593d0ca8"`
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedETag         string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+		ifNoneMatch          string
+	}{
+		{
+			name:                 "Get tile returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "tile",
+			expectedETag:         etag,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetTile(gomock.Any(), tile).Return([]byte("tile"), nil)
+				return mock
+			},
+			requestPath: "/tiles/3/4/2.mvt",
+		},
+		{
+			name:               "Get tile returns NotModified",
+			expectedStatusCode: http.StatusNotModified,
+			expectedETag:       etag,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetTile(gomock.Any(), tile).Return([]byte("tile"), nil)
+				return mock
+			},
+			requestPath: "/tiles/3/4/2.mvt",
+			ifNoneMatch: `"other", W/` + etag,
+		},
+		{
+			name:               "Get tile returns NoContent",
+			expectedStatusCode: http.StatusNoContent,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetTile(gomock.Any(), tile).Return([]byte{}, nil)
+				return mock
+			},
+			requestPath: "/tiles/3/4/2.mvt",
+		},
+		{
+			name:                 "Get tile returns BadRequest without extension",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid tile","instance":"/tiles/3/4/2","code":"invalid_tile"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				return mock_service.NewMockGeometryService(ctrl)
+			},
+			requestPath: "/tiles/3/4/2",
+		},
+		{
+			name:                 "Get tile returns BadRequest out of range",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid tile","instance":"/tiles/3/8/2.mvt","code":"invalid_tile"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetTile(gomock.Any(), models.Tile{Z: 3, X: 8, Y: 2}).Return(nil, constants.ErrInvalidTile)
+				return mock
+			},
+			requestPath: "/tiles/3/8/2.mvt",
+		},
+		{
+			name:                 "Get tile returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/tiles/3/4/2.mvt","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetTile(gomock.Any(), tile).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/tiles/3/4/2.mvt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodGet, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.expectedETag {
+				t.Errorf("Expected ETag %s, got %s", tt.expectedETag, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// GetDeletedContours lists the contours in the trash, which are purged once
+// they have been there for longer than the retention.
+func (h *GeometryHandler) GetDeletedContours(c *gin.Context) {
+	page, offset, limit, err := h.parseOffsetLimit(c)
+	if err != nil {
+		logger.Errorf("Failed to parse page: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	contours, err := h.geometryService.GetDeletedContours(c.Request.Context(), offset, limit)
+	if err != nil {
+		logger.Errorf("Failed to get deleted contours: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	h.respondList(c, "/contours/trash", page, contours, contourFeatures(contours), "wkt")
+}
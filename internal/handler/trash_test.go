@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTrash(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	polygon := models.Geometry{
+		Type:               "Polygon",
+		PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {10, 20}, {30, 10}}},
+	}
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedETag         string
+		mocks                func() *mock_service.MockGeometryService
+		method               string
+		requestPath          string
+	}{
+		{
+			name:                 "Get deleted Contours returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"count":1,"next":"http://localhost/contours/trash?page=1","previous":null,"results":[{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"version":2,"deleted_at":"2024-01-01T00:00:00Z"}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetDeletedContours(gomock.Any(), 0, 10).
+					Return([]models.Contour{{ID: 1, Data: polygon, Version: 2, DeletedAt: &deletedAt}}, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/trash",
+		},
+		{
+			name:                 "Get deleted Contours returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid parameter: strconv.Atoi: parsing \"a\": invalid syntax","instance":"/contours/trash","code":"invalid_parameter","field":"page"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/trash?page=a",
+		},
+		{
+			name:                 "Undelete Contour returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"version":3}`,
+			expectedETag:         `"3"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UndeleteContour(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1, Data: polygon, Version: 3}, nil)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/contours/1/restore",
+		},
+		{
+			name:                 "Undelete Contour returns Conflict",
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"contour is not in the trash","instance":"/contours/1/restore","code":"contour_not_deleted"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UndeleteContour(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotDeleted)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/contours/1/restore",
+		},
+		{
+			name:                 "Undelete Contour returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/contours/1/restore","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UndeleteContour(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			method:      http.MethodPost,
+			requestPath: "/contours/1/restore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(tt.method, tt.requestPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.expectedETag {
+				t.Errorf("Expected ETag %s, got %s", tt.expectedETag, got)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+// setVersionETag sets the ETag of a point or contour, a strong tag of its
+// version. Nothing is set for an unknown version.
+func setVersionETag(c *gin.Context, version uint) {
+	if version == 0 {
+		return
+	}
+
+	c.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// setVariantETag sets the ETag of a point or contour served in a variant
+// picked by the query parameters params, such as a simplified contour. The
+// version is followed by a hash of the parameters given, for each variant to
+// carry its own tag, and only the plain representation carries the tag of
+// setVersionETag. Tags of variants never match If-Match.
+func setVariantETag(c *gin.Context, version uint, params ...string) {
+	variant := url.Values{}
+	for _, param := range params {
+		if value, ok := c.GetQuery(param); ok {
+			variant.Set(param, value)
+		}
+	}
+
+	if version == 0 || len(variant) == 0 {
+		setVersionETag(c, version)
+		return
+	}
+
+	sum := sha256.Sum256([]byte(variant.Encode()))
+	c.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+"-"+hex.EncodeToString(sum[:8])+`"`)
+}
+
+// parseIfMatch returns the version an If-Match header requires to be current,
+// zero when there is no header or it is "*". Only a single tag is accepted.
+// Weak tags never match, If-Match comparing strongly as RFC 9110 requires,
+// and neither do tags that are not those of a version.
+func parseIfMatch(c *gin.Context) (uint, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	switch {
+	case header == "" || header == "*":
+		return 0, nil
+	case strings.Contains(header, ","):
+		return 0, constants.ErrInvalidPrecondition.At("If-Match")
+	case strings.HasPrefix(header, "W/"):
+		return 0, constants.ErrVersionMismatch
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, constants.ErrInvalidPrecondition.At("If-Match")
+	}
+
+	version, err := strconv.ParseUint(header[1:len(header)-1], 10, 0)
+	if err != nil || version == 0 {
+		return 0, constants.ErrVersionMismatch
+	}
+
+	return uint(version), nil
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	point := models.Point{ID: 1, Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{30, 10}}, Version: 3}
+	contourBody := `{"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]}}`
+
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedETag         string
+		mocks                func() *mock_service.MockGeometryService
+		method               string
+		requestPath          string
+		requestBody          string
+		ifMatch              string
+	}{
+		{
+			name:                 "Get Point by ID returns ETag",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Point","coordinates":[30,10]},"version":3}`,
+			expectedETag:         `"3"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(&point, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/points/1",
+		},
+		{
+			name:                 "Get Point by ID returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"point not found","instance":"/points/1","code":"point_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(nil, constants.ErrPointNotFound)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/points/1",
+		},
+		{
+			name:                 "Get Contour simplified returns ETag of the variant",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"version":3}`,
+			expectedETag:         `"3-e811420de1618b9e"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{
+					ID:      1,
+					Data:    models.Geometry{Type: "Polygon", PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {10, 20}, {30, 10}}}},
+					Version: 3,
+				}, nil)
+				return mock
+			},
+			method:      http.MethodGet,
+			requestPath: "/contours/1?simplify=50m",
+		},
+		{
+			name:                 "Update Contour with If-Match returns new ETag",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"data":{"type":"Polygon","coordinates":[[[30,10],[40,40],[20,40],[10,20],[30,10]]]},"version":4}`,
+			expectedETag:         `"4"`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, contour *models.Contour) error {
+					if contour.Version != 3 {
+						t.Errorf("Expected version 3, got %d", contour.Version)
+					}
+
+					contour.Version++
+					return nil
+				})
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/contours/1",
+			requestBody: contourBody,
+			ifMatch:     `"3"`,
+		},
+		{
+			name:                 "Update Contour with stale If-Match returns PreconditionFailed",
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"version does not match the current one","instance":"/contours/1","code":"version_mismatch","details":{"version":4}}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).Return(constants.ErrVersionMismatch.WithDetails(map[string]any{"version": 4}))
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/contours/1",
+			requestBody: contourBody,
+			ifMatch:     `"3"`,
+		},
+		{
+			name:                 "Update Point with weak If-Match returns PreconditionFailed",
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"version does not match the current one","instance":"/points/1","code":"version_mismatch"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodPut,
+			requestPath: "/points/1",
+			requestBody: `{"data":{"type":"Point","coordinates":[30,10]}}`,
+			ifMatch:     `W/"3"`,
+		},
+		{
+			name:                 "Delete Point with If-Match list returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid precondition","instance":"/points/1","code":"invalid_precondition","field":"If-Match"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				return mock
+			},
+			method:      http.MethodDelete,
+			requestPath: "/points/1",
+			ifMatch:     `"3", "4"`,
+		},
+		{
+			name:               "Delete Contour with If-Match passes the version",
+			expectedStatusCode: http.StatusNoContent,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(3)).Return(nil)
+				return mock
+			},
+			method:      http.MethodDelete,
+			requestPath: "/contours/1",
+			ifMatch:     `"3"`,
+		},
+		{
+			name:               "Delete Contour with If-Match any passes no version",
+			expectedStatusCode: http.StatusNoContent,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(0)).Return(nil)
+				return mock
+			},
+			method:      http.MethodDelete,
+			requestPath: "/contours/1",
+			ifMatch:     "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(tt.method, tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.expectedETag {
+				t.Errorf("Expected ETag %s, got %s", tt.expectedETag, got)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
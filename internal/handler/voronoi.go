@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// GetVoronoiCells returns the Voronoi cells of the seed points clipped to the
+// contour, stored as contours when persist is set.
+func (h *GeometryHandler) GetVoronoiCells(c *gin.Context) {
+	var req dto.VoronoiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	cells, err := h.geometryService.GetVoronoiCells(c.Request.Context(), req.ToModel(), req.Persist)
+	if err != nil {
+		logger.Errorf("Failed to compute voronoi: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	if req.Persist {
+		c.JSON(http.StatusCreated, dto.NewVoronoiResponse(cells))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewVoronoiResponse(cells))
+}
+
+func (h *GeometryHandler) GetDelaunayTriangles(c *gin.Context) {
+	var req dto.VoronoiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Errorf("Failed to bind request: %v", err)
+		respondError(c, invalidBody(err))
+		return
+	}
+
+	triangles, err := h.geometryService.GetDelaunayTriangles(c.Request.Context(), req.ToModel())
+	if err != nil {
+		logger.Errorf("Failed to compute delaunay: %v", err)
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDelaunayResponse(triangles))
+}
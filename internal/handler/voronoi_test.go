@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_service"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVoronoi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                 string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mocks                func() *mock_service.MockGeometryService
+		requestPath          string
+		requestBody          string
+	}{
+		{
+			name:                 "Voronoi returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":{"point_id":4}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetVoronoiCells(gomock.Any(), models.VoronoiInput{ContourID: 2, Engine: models.EnginePostGIS}, false).
+					Return([]models.VoronoiCell{{PointID: 4, Data: bufferContour(0).Data}}, nil)
+				return mock
+			},
+			requestPath: "/points/voronoi",
+			requestBody: `{"contour":2}`,
+		},
+		{
+			name:                 "Voronoi returns Created",
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]},"properties":{"contour_ids":[8],"point_id":4}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetVoronoiCells(gomock.Any(), models.VoronoiInput{ContourID: 2, IDs: []uint{4, 5}, Engine: models.EngineGo}, true).
+					Return([]models.VoronoiCell{{PointID: 4, ContourIDs: []uint{8}, Data: bufferContour(0).Data}}, nil)
+				return mock
+			},
+			requestPath: "/points/voronoi",
+			requestBody: `{"contour":2,"ids":[4,5],"engine":"go","persist":true}`,
+		},
+		{
+			name:                 "Voronoi returns BadRequest",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"contour is required","instance":"/points/voronoi","code":"missing_contour"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetVoronoiCells(gomock.Any(), gomock.Any(), false).Return(nil, constants.ErrMissingContour)
+				return mock
+			},
+			requestPath: "/points/voronoi",
+			requestBody: `{"ids":[1,2]}`,
+		},
+		{
+			name:                 "Voronoi returns NotFound",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"contour not found","instance":"/points/voronoi","code":"contour_not_found"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetVoronoiCells(gomock.Any(), gomock.Any(), false).Return(nil, constants.ErrContourNotFound)
+				return mock
+			},
+			requestPath: "/points/voronoi",
+			requestBody: `{"contour":2,"engine":"go"}`,
+		},
+		{
+			name:                 "Delaunay returns OK",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[1,2],[0,0]]]},"properties":{}}]}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetDelaunayTriangles(gomock.Any(), models.VoronoiInput{IDs: []uint{1, 2, 3}, Engine: models.EnginePostGIS}).Return([]models.Geometry{{
+					Type:               models.PolygonType,
+					PolygonCoordinates: [][][2]float64{{{0, 0}, {2, 0}, {1, 2}, {0, 0}}},
+				}}, nil)
+				return mock
+			},
+			requestPath: "/points/delaunay",
+			requestBody: `{"ids":[1,2,3]}`,
+		},
+		{
+			name:                 "Delaunay returns InternalServerError",
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points/delaunay","code":"internal"}`,
+			mocks: func() *mock_service.MockGeometryService {
+				ctrl := gomock.NewController(t)
+				mock := mock_service.NewMockGeometryService(ctrl)
+				mock.EXPECT().GetDelaunayTriangles(gomock.Any(), gomock.Any()).Return(nil, constants.ErrInternal)
+				return mock
+			},
+			requestPath: "/points/delaunay",
+			requestBody: `{"contour":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.Default()
+
+			handler := NewGeometryHandler(tt.mocks(), "http://localhost")
+			handler.RegisterRoutes(router.Group("/"))
+
+			req, err := http.NewRequest(http.MethodPost, tt.requestPath, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tt.expectedResponseBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
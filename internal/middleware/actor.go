@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// ActorMiddleware puts the actor of every request in its context, to be
+// recorded in the contour history: the subject of the token verified by
+// AuthMiddleware, which must run first. Requests that are not authenticated
+// are left anonymous, as nothing else they carry can be trusted.
+func ActorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, ok := c.Get(TokenKey); ok {
+			if idToken, ok := token.(*oidc.IDToken); ok && idToken.Subject != "" {
+				c.Request = c.Request.WithContext(models.WithActor(c.Request.Context(), idToken.Subject))
+			}
+		}
+
+		c.Next()
+	}
+}
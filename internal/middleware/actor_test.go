@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+func TestActorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			c.Set(TokenKey, &oidc.IDToken{Subject: "subject"})
+		}
+	})
+	router.Use(ActorMiddleware())
+
+	var actor string
+	router.GET("/", func(c *gin.Context) {
+		actor = models.ActorFrom(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected string
+	}{
+		{name: "Anonymous", expected: models.AnonymousActor},
+		{name: "IgnoresHeader", headers: map[string]string{"X-Actor": "alice"}, expected: models.AnonymousActor},
+		{name: "Token", headers: map[string]string{"X-Actor": "alice", "Authorization": "Bearer token"}, expected: "subject"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			router.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.expected, actor)
+		})
+	}
+}
@@ -9,14 +9,19 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware(url string) gin.HandlerFunc {
+// TokenKey is the key of the verified *oidc.IDToken in the gin context.
+const TokenKey = "token"
+
+// AuthMiddleware rejects the requests that do not carry a bearer token issued
+// by the OIDC provider at url for clientID.
+func AuthMiddleware(url, clientID string) gin.HandlerFunc {
 	provider, err := oidc.NewProvider(context.Background(), url)
 	if err != nil {
 		panic("Failed to create OIDC provider: " + err.Error())
 	}
 
 	verifier := provider.Verifier(&oidc.Config{
-		ClientID: "example-app",
+		ClientID: clientID,
 	})
 
 	return func(c *gin.Context) {
@@ -40,7 +45,7 @@ func AuthMiddleware(url string) gin.HandlerFunc {
 			return
 		}
 
-		c.Set("token", idToken)
+		c.Set(TokenKey, idToken)
 		c.Next()
 	}
 }
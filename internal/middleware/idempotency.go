@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/dto"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+const (
+	// IdempotencyKeyHeader names the key clients send to retry a request
+	// safely.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on the responses replayed to retries.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL is how long responses are kept for retries by
+	// default.
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// DefaultIdempotencyClaim is how long the requests without a deadline
+	// hold their key before a retry may take it over, should they never end.
+	DefaultIdempotencyClaim = 5 * time.Minute
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyMiddleware makes the given routes, keyed by method and route
+// pattern as in TimeoutMiddleware, safe to retry with an Idempotency-Key. The
+// response to the first request with a key is kept for ttl,
+// DefaultIdempotencyTTL when it is not positive, and replayed to the requests
+// retrying it without running the handler again. The key reused for another
+// request, as told by a hash of its method, URI and body, fails with 422, and
+// a retry made while the first request is in progress with 409, until the
+// deadline of the first request or DefaultIdempotencyClaim when it has none.
+// Only the responses of the requests the handler completed are kept: server
+// errors, panics and requests whose client went away or that timed out
+// release the key, so they can be retried. Keys are scoped to the caller, the actor put in the
+// context by ActorMiddleware, which must run first, or the client IP for
+// anonymous requests.
+func IdempotencyMiddleware(repo repository.IdempotencyRepository, ttl time.Duration, routes ...string) gin.HandlerFunc {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	idempotent := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		idempotent[route] = true
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !idempotent[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, constants.ErrInvalidIdempotencyKey.At(IdempotencyKeyHeader))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWithError(c, constants.ErrInvalidBody.Wrap(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		claimExpiresAt, ok := c.Request.Context().Deadline()
+		if !ok {
+			claimExpiresAt = now.Add(DefaultIdempotencyClaim)
+		}
+
+		request := &models.IdempotentRequest{
+			Scope:          idempotencyScope(c),
+			Key:            key,
+			RequestHash:    requestHash(c.Request, body),
+			CreatedAt:      now,
+			ClaimExpiresAt: claimExpiresAt,
+			ExpiresAt:      now.Add(ttl),
+		}
+
+		existing, err := repo.ClaimIdempotencyKey(c.Request.Context(), request)
+		if err != nil {
+			logger.Errorf("Failed to claim idempotency key: %v", err)
+			abortWithError(c, constants.ErrInternal)
+			return
+		}
+
+		if existing != nil {
+			replay(c, request, existing)
+			return
+		}
+
+		// The outcome is stored even when the client went away, which is
+		// when it is most likely to retry.
+		ctx := context.WithoutCancel(c.Request.Context())
+		release := func() {
+			if err := repo.ReleaseIdempotencyKey(ctx, request); err != nil {
+				logger.Errorf("Failed to release idempotency key: %v", err)
+			}
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					release()
+					panic(r)
+				}
+			}()
+
+			c.Next()
+		}()
+
+		if c.Request.Context().Err() != nil || recorder.Status() == constants.StatusClientClosedRequest ||
+			recorder.Status() >= http.StatusInternalServerError {
+			release()
+			return
+		}
+
+		request.StatusCode = recorder.Status()
+		request.ContentType = recorder.Header().Get("Content-Type")
+		request.Location = recorder.Header().Get("Location")
+		request.ETag = recorder.Header().Get("ETag")
+		request.Body = recorder.body.Bytes()
+		if err := repo.SaveIdempotentResponse(ctx, request); err != nil {
+			logger.Errorf("Failed to save idempotent response: %v", err)
+		}
+	}
+}
+
+// idempotencyScope returns the caller the idempotency keys of c are scoped
+// to.
+func idempotencyScope(c *gin.Context) string {
+	if actor := models.ActorFrom(c.Request.Context()); actor != models.AnonymousActor {
+		return "actor:" + actor
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// replay answers a retry with the response kept for the request holding its
+// key.
+func replay(c *gin.Context, request, existing *models.IdempotentRequest) {
+	switch {
+	case existing.RequestHash != request.RequestHash:
+		abortWithError(c, constants.ErrIdempotencyKeyReused.At(IdempotencyKeyHeader))
+	case !existing.Done():
+		abortWithError(c, constants.ErrIdempotencyKeyInProgress.At(IdempotencyKeyHeader))
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		if existing.Location != "" {
+			c.Header("Location", existing.Location)
+		}
+
+		if existing.ETag != "" {
+			c.Header("ETag", existing.ETag)
+		}
+
+		c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+		c.Abort()
+	}
+}
+
+// requestHash tells requests apart by their method, URI and body.
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// abortWithError answers err as a problem details body, as the handlers do.
+func abortWithError(c *gin.Context, err *constants.Error) {
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(err.Status, dto.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(err.Status),
+		Status:   err.Status,
+		Detail:   err.Error(),
+		Instance: c.Request.URL.Path,
+		Code:     err.Code,
+		Field:    err.Field,
+	})
+}
+
+// responseRecorder keeps a copy of the body written to the response.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
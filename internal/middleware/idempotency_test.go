@@ -0,0 +1,273 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	created := `{"id":1}`
+
+	// claimedBy answers a claim with the request holding the key, the
+	// claiming request itself when hash is empty.
+	claimedBy := func(hash string, status int) func(context.Context, *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+		return func(_ context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+			existing := *request
+			if hash != "" {
+				existing.RequestHash = hash
+			}
+
+			existing.StatusCode, existing.ContentType, existing.Body = status, "application/json; charset=utf-8", []byte(created)
+			existing.Location, existing.ETag = "/points/1", `"1"`
+			return &existing, nil
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		method               string
+		key                  string
+		actor                string
+		status               int
+		panics               bool
+		cancels              bool
+		mocks                func(*mock_repository.MockIdempotencyRepository)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedReplayed     string
+		expectedLocation     string
+		expectedCalls        int
+	}{
+		{
+			name:                 "NoKey",
+			method:               http.MethodPost,
+			status:               http.StatusCreated,
+			mocks:                func(*mock_repository.MockIdempotencyRepository) {},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:                 "OtherRoute",
+			method:               http.MethodPut,
+			key:                  "key",
+			status:               http.StatusOK,
+			mocks:                func(*mock_repository.MockIdempotencyRepository) {},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:   "First",
+			method: http.MethodPost,
+			key:    "key",
+			status: http.StatusCreated,
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+					assert.Equal(t, "ip:192.0.2.1", request.Scope)
+					assert.Equal(t, "key", request.Key)
+					assert.Len(t, request.RequestHash, 64)
+					assert.WithinDuration(t, time.Now().Add(DefaultIdempotencyClaim), request.ClaimExpiresAt, time.Minute)
+					assert.WithinDuration(t, time.Now().Add(time.Hour), request.ExpiresAt, time.Minute)
+					return nil, nil
+				}).Times(1)
+				repo.EXPECT().SaveIdempotentResponse(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *models.IdempotentRequest) error {
+					assert.Equal(t, http.StatusCreated, request.StatusCode)
+					assert.Equal(t, "application/json; charset=utf-8", request.ContentType)
+					assert.Equal(t, "/points/1", request.Location)
+					assert.Equal(t, `"1"`, request.ETag)
+					assert.Equal(t, created, string(request.Body))
+					return nil
+				}).Times(1)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:   "Replayed",
+			method: http.MethodPost,
+			key:    "key",
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(claimedBy("", http.StatusCreated)).Times(1)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: created,
+			expectedReplayed:     "true",
+			expectedLocation:     "/points/1",
+		},
+		{
+			name:   "Reused",
+			method: http.MethodPost,
+			key:    "key",
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(claimedBy("other", http.StatusCreated)).Times(1)
+			},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedResponseBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"idempotency key was used for a different request","instance":"/points","code":"idempotency_key_reused","field":"Idempotency-Key"}`,
+		},
+		{
+			name:   "InProgress",
+			method: http.MethodPost,
+			key:    "key",
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(claimedBy("", 0)).Times(1)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"detail":"a request with this idempotency key is in progress","instance":"/points","code":"idempotency_key_in_progress","field":"Idempotency-Key"}`,
+		},
+		{
+			name:   "ServerError",
+			method: http.MethodPost,
+			key:    "key",
+			status: http.StatusInternalServerError,
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				repo.EXPECT().ReleaseIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *models.IdempotentRequest) error {
+					assert.Equal(t, "key", request.Key)
+					return nil
+				}).Times(1)
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:   "ClientClosedRequest",
+			method: http.MethodPost,
+			key:    "key",
+			status: constants.StatusClientClosedRequest,
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				repo.EXPECT().ReleaseIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedStatusCode:   constants.StatusClientClosedRequest,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:    "Canceled",
+			method:  http.MethodPost,
+			key:     "key",
+			status:  http.StatusCreated,
+			cancels: true,
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				repo.EXPECT().ReleaseIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:   "Panic",
+			method: http.MethodPost,
+			key:    "key",
+			panics: true,
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				repo.EXPECT().ReleaseIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCalls:      1,
+		},
+		{
+			name:   "ScopedToActor",
+			method: http.MethodPost,
+			key:    "key",
+			actor:  "alice",
+			status: http.StatusCreated,
+			mocks: func(repo *mock_repository.MockIdempotencyRepository) {
+				repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+					assert.Equal(t, "actor:alice", request.Scope)
+					return nil, nil
+				}).Times(1)
+				repo.EXPECT().SaveIdempotentResponse(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: created,
+			expectedCalls:        1,
+		},
+		{
+			name:                 "KeyTooLong",
+			method:               http.MethodPost,
+			key:                  strings.Repeat("k", 256),
+			mocks:                func(*mock_repository.MockIdempotencyRepository) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid idempotency key","instance":"/points","code":"invalid_idempotency_key","field":"Idempotency-Key"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIdempotencyRepository(ctrl)
+			tt.mocks(repo)
+
+			router := gin.New()
+			router.Use(gin.Recovery())
+			router.Use(IdempotencyMiddleware(repo, time.Hour, "POST /points"))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			calls := 0
+			respond := func(c *gin.Context) {
+				calls++
+				if tt.panics {
+					panic("handler failed")
+				}
+
+				if tt.cancels {
+					cancel()
+				}
+
+				c.Header("Location", "/points/1")
+				c.Header("ETag", `"1"`)
+
+				c.Data(tt.status, "application/json; charset=utf-8", []byte(created))
+			}
+			router.POST("/points", respond)
+			router.PUT("/points", respond)
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, "/points", strings.NewReader(`{"data":{"type":"Point","coordinates":[30,10]}}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.key != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.key)
+			}
+
+			if tt.actor != "" {
+				req = req.WithContext(models.WithActor(req.Context(), tt.actor))
+			}
+
+			req.RemoteAddr = "192.0.2.1:1234"
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+			assert.Equal(t, tt.expectedReplayed, w.Header().Get(IdempotentReplayedHeader))
+			if tt.expectedLocation != "" {
+				assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
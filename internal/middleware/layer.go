@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// Query parameters naming the layers a request is scoped to.
+const (
+	LayerParam        = "layer"
+	PointLayerParam   = "point_layer"
+	ContourLayerParam = "contour_layer"
+)
+
+// LayerMiddleware scopes the queries of a request to the layers named by its
+// query parameters: layer for the points and contours alike, point_layer and
+// contour_layer for either of them, taking precedence. A layer that does not
+// exist fails the request with 404.
+func LayerMiddleware(repo repository.LayerRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var scope models.LayerScope
+		for _, param := range []string{LayerParam, PointLayerParam, ContourLayerParam} {
+			name := c.Query(param)
+			if name == "" {
+				continue
+			}
+
+			layer, err := repo.GetLayerByName(c.Request.Context(), name)
+			if errors.Is(err, constants.ErrLayerNotFound) {
+				abortWithError(c, constants.ErrLayerNotFound.At(param))
+				return
+			}
+
+			if err != nil {
+				logger.Errorf("Failed to get layer %s: %v", name, err)
+				abortWithError(c, constants.ErrInternal)
+				return
+			}
+
+			switch param {
+			case LayerParam:
+				scope.Points, scope.Contours = &layer.ID, &layer.ID
+			case PointLayerParam:
+				scope.Points = &layer.ID
+			case ContourLayerParam:
+				scope.Contours = &layer.ID
+			}
+		}
+
+		if scope != (models.LayerScope{}) {
+			c.Request = c.Request.WithContext(models.WithLayerScope(c.Request.Context(), scope))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+)
+
+func TestLayerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	stores, zones := uint(1), uint(2)
+
+	tests := []struct {
+		name                 string
+		query                string
+		mocks                func(*mock_repository.MockLayerRepository)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedScope        models.LayerScope
+	}{
+		{
+			name:               "NoLayer",
+			mocks:              func(*mock_repository.MockLayerRepository) {},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "Layer",
+			query: "?layer=stores",
+			mocks: func(repo *mock_repository.MockLayerRepository) {
+				repo.EXPECT().GetLayerByName(gomock.Any(), "stores").Return(&models.Layer{ID: stores, Name: "stores"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedScope:      models.LayerScope{Points: &stores, Contours: &stores},
+		},
+		{
+			name:  "CrossLayer",
+			query: "?point_layer=stores&contour_layer=zones",
+			mocks: func(repo *mock_repository.MockLayerRepository) {
+				repo.EXPECT().GetLayerByName(gomock.Any(), "stores").Return(&models.Layer{ID: stores, Name: "stores"}, nil)
+				repo.EXPECT().GetLayerByName(gomock.Any(), "zones").Return(&models.Layer{ID: zones, Name: "zones"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedScope:      models.LayerScope{Points: &stores, Contours: &zones},
+		},
+		{
+			name:  "Override",
+			query: "?layer=stores&contour_layer=zones",
+			mocks: func(repo *mock_repository.MockLayerRepository) {
+				repo.EXPECT().GetLayerByName(gomock.Any(), "stores").Return(&models.Layer{ID: stores, Name: "stores"}, nil)
+				repo.EXPECT().GetLayerByName(gomock.Any(), "zones").Return(&models.Layer{ID: zones, Name: "zones"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedScope:      models.LayerScope{Points: &stores, Contours: &zones},
+		},
+		{
+			name:  "NotFound",
+			query: "?point_layer=shops",
+			mocks: func(repo *mock_repository.MockLayerRepository) {
+				repo.EXPECT().GetLayerByName(gomock.Any(), "shops").Return(nil, constants.ErrLayerNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"layer not found","instance":"/points","code":"layer_not_found","field":"point_layer"}`,
+		},
+		{
+			name:  "Error",
+			query: "?layer=stores",
+			mocks: func(repo *mock_repository.MockLayerRepository) {
+				repo.EXPECT().GetLayerByName(gomock.Any(), "stores").Return(nil, constants.ErrInternal.Wrap(assert.AnError))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","instance":"/points","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockLayerRepository(ctrl)
+			tt.mocks(repo)
+
+			var scope models.LayerScope
+			router := gin.New()
+			router.Use(LayerMiddleware(repo))
+			router.GET("/points", func(c *gin.Context) {
+				scope = models.LayerScopeFrom(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/points"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedScope, scope)
+			if tt.expectedResponseBody != "" {
+				assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware bounds the context of every request by the timeout of its
+// route, keyed by method and route pattern as in "POST /points/voronoi", or
+// by fallback for the other routes. A timeout of zero leaves the request
+// unbounded. Database queries run with the context are cancelled once it
+// expires.
+func TimeoutMiddleware(fallback time.Duration, routes map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = fallback
+		}
+
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
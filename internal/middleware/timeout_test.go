@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(TimeoutMiddleware(time.Minute, map[string]time.Duration{
+		"GET /slow/:id": time.Hour,
+		"GET /export":   0,
+	}))
+
+	deadlines := make(map[string]time.Duration)
+	record := func(c *gin.Context) {
+		deadline, ok := c.Request.Context().Deadline()
+		if ok {
+			deadlines[c.Request.URL.Path] = time.Until(deadline)
+		}
+
+		c.Status(http.StatusOK)
+	}
+	router.GET("/fast", record)
+	router.GET("/slow/:id", record)
+	router.GET("/export", record)
+
+	for _, path := range []string{"/fast", "/slow/1", "/export"} {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.InDelta(t, time.Minute, deadlines["/fast"], float64(time.Second))
+	assert.InDelta(t, time.Hour, deadlines["/slow/1"], float64(time.Second))
+	assert.NotContains(t, deadlines, "/export")
+}
@@ -0,0 +1,57 @@
+package models
+
+import "github.com/malamsyah/geo-service/internal/constants"
+
+// BBox is a lon/lat bounding box in EPSG:4326.
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+func (b BBox) Validate() error {
+	if b.MinLon < -180 || b.MaxLon > 180 || b.MinLat < -90 || b.MaxLat > 90 {
+		return constants.ErrCoordinatesOutOfRange
+	}
+
+	if b.MinLon > b.MaxLon || b.MinLat > b.MaxLat {
+		return constants.ErrInvalidBBox
+	}
+
+	return nil
+}
+
+// Bounds returns the bounding box of a geometry.
+func (g Geometry) Bounds() BBox {
+	var positions [][2]float64
+
+	switch {
+	case g.IsPoint():
+		positions = append(positions, g.PointCoordinates)
+	case g.IsPolygon():
+		for _, ring := range g.PolygonCoordinates {
+			positions = append(positions, ring...)
+		}
+	case g.IsMultiPolygon():
+		for _, polygon := range g.MultiPolygonCoordinates {
+			for _, ring := range polygon {
+				positions = append(positions, ring...)
+			}
+		}
+	}
+
+	if len(positions) == 0 {
+		return BBox{}
+	}
+
+	b := BBox{MinLon: positions[0][0], MinLat: positions[0][1], MaxLon: positions[0][0], MaxLat: positions[0][1]}
+	for _, p := range positions[1:] {
+		b.MinLon = min(b.MinLon, p[0])
+		b.MinLat = min(b.MinLat, p[1])
+		b.MaxLon = max(b.MaxLon, p[0])
+		b.MaxLat = max(b.MaxLat, p[1])
+	}
+
+	return b
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+type EndCap string
+
+const (
+	EndCapRound  EndCap = "round"
+	EndCapFlat   EndCap = "flat"
+	EndCapSquare EndCap = "square"
+
+	// DefaultBufferSegments is the number of segments approximating a quarter
+	// circle, the ST_Buffer default.
+	DefaultBufferSegments = 8
+	// MaxBufferSegments bounds the segments per quarter circle.
+	MaxBufferSegments = 64
+)
+
+// Buffer describes the buffer of a geometry on the spheroid. Distance is in
+// metres, a negative one shrinking polygons. Segments approximate a quarter
+// circle and EndCap shapes the ends of lines.
+type Buffer struct {
+	Distance float64
+	Segments int
+	EndCap   EndCap
+}
+
+func (b Buffer) Validate() error {
+	if b.Distance == 0 || math.IsInf(b.Distance, 0) || math.IsNaN(b.Distance) {
+		return constants.ErrInvalidDistance
+	}
+
+	if b.Segments < 1 || b.Segments > MaxBufferSegments {
+		return constants.ErrInvalidSegments
+	}
+
+	switch b.EndCap {
+	case EndCapRound, EndCapFlat, EndCapSquare:
+		return nil
+	default:
+		return constants.ErrInvalidEndCap
+	}
+}
+
+// Parameters returns the buffer style parameters of ST_Buffer.
+func (b Buffer) Parameters() string {
+	return fmt.Sprintf("quad_segs=%d endcap=%s", b.Segments, b.EndCap)
+}
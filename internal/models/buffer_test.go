@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer_Validate(t *testing.T) {
+	assert.NoError(t, Buffer{Distance: 100, Segments: 8, EndCap: EndCapRound}.Validate())
+	assert.NoError(t, Buffer{Distance: -100, Segments: 1, EndCap: EndCapSquare}.Validate())
+	assert.ErrorIs(t, Buffer{Distance: 0, Segments: 8, EndCap: EndCapRound}.Validate(), constants.ErrInvalidDistance)
+	assert.ErrorIs(t, Buffer{Distance: 100, Segments: 0, EndCap: EndCapRound}.Validate(), constants.ErrInvalidSegments)
+	assert.ErrorIs(t, Buffer{Distance: 100, Segments: 65, EndCap: EndCapRound}.Validate(), constants.ErrInvalidSegments)
+	assert.ErrorIs(t, Buffer{Distance: 100, Segments: 8, EndCap: "butt"}.Validate(), constants.ErrInvalidEndCap)
+}
+
+func TestBuffer_Parameters(t *testing.T) {
+	assert.Equal(t, "quad_segs=16 endcap=flat", Buffer{Distance: 1, Segments: 16, EndCap: EndCapFlat}.Parameters())
+}
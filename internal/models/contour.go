@@ -1,6 +1,37 @@
 package models
 
+import "time"
+
+// Contour is a polygon. ParentID links the cells a contour is tessellated
+// into back to it.
 type Contour struct {
-	ID   uint     `json:"id,omitempty" gorm:"primaryKey"`
-	Data Geometry `json:"data" gorm:"column:data;type:geometry(POLYGON,4326)"`
+	ID         uint       `json:"id,omitempty" gorm:"primaryKey"`
+	ParentID   *uint      `json:"parent_id,omitempty" gorm:"column:parent_id;index"`
+	Data       Geometry   `json:"data" gorm:"column:data;type:geometry(POLYGON,4326)"`
+	Properties Properties `json:"properties,omitempty" gorm:"column:properties;type:jsonb"`
+	// LayerID is the layer the contour belongs to, nil when it belongs to
+	// none.
+	LayerID *uint  `json:"layer_id,omitempty" gorm:"column:layer_id;index"`
+	Layer   *Layer `json:"-" gorm:"constraint:OnDelete:SET NULL"`
+	// Version is bumped on every update. Set on an update, it is the version
+	// the caller expects to replace.
+	Version uint `json:"version,omitempty" gorm:"column:version;not null;default:1"`
+	// UpdatedAt is when the version was made, unknown for contours older
+	// than the contour history.
+	UpdatedAt *time.Time `json:"updated_at,omitempty" gorm:"column:updated_at"`
+	// DeletedAt is when the contour was moved to the trash, nil while it is
+	// live.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"column:deleted_at;index"`
+}
+
+// SimplifiedContour is the simplified variant stored next to a contour,
+// Tolerance being the one it was simplified with, in metres.
+type SimplifiedContour struct {
+	ContourID uint     `json:"contour_id" gorm:"primaryKey;autoIncrement:false"`
+	Tolerance float64  `json:"tolerance" gorm:"column:tolerance"`
+	Data      Geometry `json:"data" gorm:"column:data;type:geometry(POLYGON,4326)"`
+}
+
+func (SimplifiedContour) TableName() string {
+	return "simplified_contours"
 }
@@ -0,0 +1,10 @@
+package models
+
+// Distance locates a point relative to the boundary of a contour. Meters is
+// the geodesic distance to the boundary, negative when the point is inside
+// the contour, and Closest the nearest point of the boundary.
+type Distance struct {
+	Meters  float64
+	Inside  bool
+	Closest Geometry `gorm:"column:closest"`
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/malamsyah/geo-service/internal/constants"
 	"gorm.io/gorm"
@@ -105,26 +104,24 @@ func (g Geometry) Validate() error {
 }
 
 func (g Geometry) validatePoint() error {
-	if g.PointCoordinates[0] < -180 || g.PointCoordinates[0] > 180 || g.PointCoordinates[1] < -90 || g.PointCoordinates[1] > 90 {
-		return constants.ErrCoordinatesOutOfRange
+	if !inRange(g.PointCoordinates) {
+		return constants.ErrCoordinatesOutOfRange.At("coordinates").WithDetails(map[string]any{"position": g.PointCoordinates})
 	}
 
 	return nil
 }
 
+// validatePolygon checks that every ring is closed and within range. Errors
+// point at the offending ring or position.
 func (g Geometry) validatePolygon() error {
-	for _, coords := range g.PolygonCoordinates {
-		if len(coords) < 2 {
-			return constants.ErrInvalidContours
+	for i, coords := range g.PolygonCoordinates {
+		if len(coords) < 2 || coords[0] != coords[len(coords)-1] {
+			return constants.ErrInvalidContours.At(fmt.Sprintf("coordinates[%d]", i))
 		}
 
-		if coords[0] != coords[len(coords)-1] {
-			return constants.ErrInvalidContours
-		}
-
-		for _, coord := range coords {
-			if coord[0] < -180 || coord[0] > 180 || coord[1] < -90 || coord[1] > 90 {
-				return constants.ErrCoordinatesOutOfRange
+		for j, coord := range coords {
+			if !inRange(coord) {
+				return constants.ErrCoordinatesOutOfRange.At(fmt.Sprintf("coordinates[%d][%d]", i, j)).WithDetails(map[string]any{"position": coord})
 			}
 		}
 	}
@@ -132,40 +129,28 @@ func (g Geometry) validatePolygon() error {
 	return nil
 }
 
+func inRange(position [2]float64) bool {
+	return position[0] >= -180 && position[0] <= 180 && position[1] >= -90 && position[1] <= 90
+}
+
 func (g Geometry) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
 	if g.IsPolygon() {
 		return clause.Expr{
 			SQL:  "ST_PolygonFromText(?)",
-			Vars: []interface{}{fmt.Sprintf("POLYGON(%s)", g.polygonCoordinatesToString())},
+			Vars: []interface{}{g.WKT()},
 		}
 	}
 
 	if g.IsPoint() {
 		return clause.Expr{
 			SQL:  "ST_PointFromText(?)",
-			Vars: []interface{}{fmt.Sprintf("POINT(%s %s)", fmt.Sprint(g.PointCoordinates[0]), fmt.Sprint(g.PointCoordinates[1]))},
+			Vars: []interface{}{g.WKT()},
 		}
 	}
 
 	return clause.Expr{}
 }
 
-func (g Geometry) polygonCoordinatesToString() string {
-	coords := make([]string, 0)
-	for _, c := range g.PolygonCoordinates {
-		var points []string
-
-		for _, p := range c {
-			points = append(points, fmt.Sprintf("%s %s", fmt.Sprint(p[0]), fmt.Sprint(p[1])))
-		}
-
-		joined := strings.Join(points, ",")
-		coords = append(coords, fmt.Sprintf("(%s)", joined))
-	}
-
-	return strings.Join(coords, ",")
-}
-
 func (g *Geometry) Scan(src interface{}) error {
 	if src == nil {
 		*g = Geometry{}
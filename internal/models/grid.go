@@ -0,0 +1,194 @@
+package models
+
+import (
+	"math"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+type GridType string
+
+const (
+	GridSquare  GridType = "square"
+	GridHex     GridType = "hex"
+	GridContour GridType = "contour"
+
+	// MaxGridCells bounds the number of cells a grid aggregation may span.
+	MaxGridCells = 100000
+	// MaxMercatorLat is the latitude past which EPSG:3857 is undefined.
+	MaxMercatorLat = 85.0511287798066
+
+	// sqrt(3)/2, the height of a hexagon over its edge length, halved.
+	hexRatio = 0.8660254037844386
+)
+
+// Grid describes how points are aggregated. Square and hexagonal grids are
+// laid out in EPSG:3857 the way ST_SquareGrid and ST_HexagonGrid do, Size
+// being the side of a square or the edge of a hexagon in Web Mercator units,
+// as returned by AtLatitude for a size in metres. A contour grid uses the
+// contours as cells.
+type Grid struct {
+	Type GridType
+	Size float64
+}
+
+// AtLatitude returns the grid whose cells span Size metres on the ground at
+// the latitude lat. Web Mercator stretches distances by 1/cos(lat), so the
+// cells of the grid shrink north of lat and grow south of it, in the northern
+// hemisphere.
+func (g Grid) AtLatitude(lat float64) Grid {
+	lat = math.Max(-MaxMercatorLat, math.Min(lat, MaxMercatorLat))
+	g.Size /= math.Cos(lat * math.Pi / 180)
+
+	return g
+}
+
+// Cell is an aggregation cell, addressed by its column I and row J in a
+// square or hexagonal grid, or by ContourID.
+type Cell struct {
+	I         int
+	J         int
+	ContourID uint
+	Data      Geometry `gorm:"column:data"`
+	Count     int
+}
+
+func (g Grid) Validate() error {
+	switch g.Type {
+	case GridContour:
+		return nil
+	case GridSquare, GridHex:
+		if g.Size <= 0 || math.IsInf(g.Size, 0) || math.IsNaN(g.Size) {
+			return constants.ErrInvalidGridSize
+		}
+
+		return nil
+	default:
+		return constants.ErrInvalidGrid
+	}
+}
+
+// CellCount estimates the number of cells covering the bounding box.
+func (g Grid) CellCount(bbox BBox) float64 {
+	minX, minY := Mercator(bbox.MinLon, bbox.MinLat)
+	maxX, maxY := Mercator(bbox.MaxLon, bbox.MaxLat)
+
+	return g.SpanCellCount(maxX-minX, maxY-minY)
+}
+
+// SpanCellCount estimates the number of cells covering a width by height
+// extent in Web Mercator units.
+func (g Grid) SpanCellCount(width, height float64) float64 {
+	if g.Type == GridHex {
+		return (width/(1.5*g.Size) + 1) * (height/(2*hexRatio*g.Size) + 1)
+	}
+
+	return (width/g.Size + 1) * (height/g.Size + 1)
+}
+
+// CellsCovering returns the column and row of every cell intersecting the
+// extent, given in the coordinates the grid is laid out in.
+func (g Grid) CellsCovering(minX, minY, maxX, maxY float64) [][2]int {
+	var cells [][2]int
+
+	if g.Type != GridHex {
+		for i := int(math.Floor(minX / g.Size)); float64(i)*g.Size < maxX; i++ {
+			for j := int(math.Floor(minY / g.Size)); float64(j)*g.Size < maxY; j++ {
+				cells = append(cells, [2]int{i, j})
+			}
+		}
+
+		return cells
+	}
+
+	h := hexRatio * g.Size
+	for i := int(math.Ceil((minX - g.Size) / (1.5 * g.Size))); 1.5*g.Size*float64(i)-g.Size < maxX; i++ {
+		offset := g.hexOffset(i)
+		for j := int(math.Ceil((minY - offset - h) / (2 * h))); 2*h*float64(j)+offset-h < maxY; j++ {
+			cells = append(cells, [2]int{i, j})
+		}
+	}
+
+	return cells
+}
+
+// CellAt returns the cell holding the EPSG:3857 coordinates. For hexagons it
+// is the one with the nearest centre among the candidate columns.
+func (g Grid) CellAt(x, y float64) (int, int) {
+	if g.Type != GridHex {
+		return int(math.Floor(x / g.Size)), int(math.Floor(y / g.Size))
+	}
+
+	column := int(math.Round(x / (1.5 * g.Size)))
+	bestI, bestJ, best := 0, 0, math.Inf(1)
+
+	for i := column - 1; i <= column+1; i++ {
+		j := int(math.Round((y - g.hexOffset(i)) / (2 * hexRatio * g.Size)))
+		cx, cy := g.hexCenter(i, j)
+
+		if d := (x-cx)*(x-cx) + (y-cy)*(y-cy); d < best {
+			bestI, bestJ, best = i, j, d
+		}
+	}
+
+	return bestI, bestJ
+}
+
+// CellPolygon returns the EPSG:4326 polygon of a cell.
+func (g Grid) CellPolygon(i, j int) Geometry {
+	ring := g.CellRing(i, j)
+	for k, position := range ring {
+		ring[k] = inverseMercator(position[0], position[1])
+	}
+
+	return Geometry{Type: PolygonType, PolygonCoordinates: [][][2]float64{ring}}
+}
+
+// CellRing returns the counterclockwise ring of a cell in the coordinates the
+// grid is laid out in.
+func (g Grid) CellRing(i, j int) [][2]float64 {
+	if g.Type == GridHex {
+		cx, cy := g.hexCenter(i, j)
+		h := hexRatio * g.Size
+
+		return [][2]float64{
+			{cx - g.Size, cy}, {cx - g.Size/2, cy - h}, {cx + g.Size/2, cy - h}, {cx + g.Size, cy},
+			{cx + g.Size/2, cy + h}, {cx - g.Size/2, cy + h}, {cx - g.Size, cy},
+		}
+	}
+
+	minX, minY := float64(i)*g.Size, float64(j)*g.Size
+	maxX, maxY := minX+g.Size, minY+g.Size
+
+	return [][2]float64{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}, {minX, minY}}
+}
+
+func (g Grid) hexCenter(i, j int) (float64, float64) {
+	return 1.5 * g.Size * float64(i), 2*hexRatio*g.Size*float64(j) + g.hexOffset(i)
+}
+
+// hexOffset shifts odd columns up by half a hexagon, as ST_HexagonGrid does.
+func (g Grid) hexOffset(i int) float64 {
+	if i%2 != 0 {
+		return hexRatio * g.Size
+	}
+
+	return 0
+}
+
+// Mercator projects lon/lat to EPSG:3857, clamping the latitude to the range
+// the projection is defined on.
+func Mercator(lon, lat float64) (float64, float64) {
+	lat = math.Max(-MaxMercatorLat, math.Min(lat, MaxMercatorLat))
+	x := lon * webMercatorHalfWorld / 180
+	y := math.Log(math.Tan((90+lat)*math.Pi/360)) * webMercatorHalfWorld / math.Pi
+
+	return x, y
+}
+
+func inverseMercator(x, y float64) [2]float64 {
+	lon := x * 180 / webMercatorHalfWorld
+	lat := math.Atan(math.Exp(y*math.Pi/webMercatorHalfWorld))*360/math.Pi - 90
+
+	return [2]float64{lon, lat}
+}
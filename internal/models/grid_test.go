@@ -0,0 +1,106 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrid_Validate(t *testing.T) {
+	assert.NoError(t, Grid{Type: GridHex, Size: 1000}.Validate())
+	assert.NoError(t, Grid{Type: GridSquare, Size: 1000}.Validate())
+	assert.NoError(t, Grid{Type: GridContour}.Validate())
+	assert.ErrorIs(t, Grid{Type: GridHex}.Validate(), constants.ErrInvalidGridSize)
+	assert.ErrorIs(t, Grid{Type: GridSquare, Size: math.NaN()}.Validate(), constants.ErrInvalidGridSize)
+	assert.ErrorIs(t, Grid{Type: "triangle", Size: 1000}.Validate(), constants.ErrInvalidGrid)
+}
+
+func TestGrid_CellAt(t *testing.T) {
+	hex := Grid{Type: GridHex, Size: 1000}
+
+	// Hexagons are flat topped, odd columns shifted up by half a hexagon.
+	assert.Equal(t, [2]int{0, 0}, cellAt(hex, 0, 0))
+	assert.Equal(t, [2]int{0, 1}, cellAt(hex, 0, 1732))
+	assert.Equal(t, [2]int{1, 0}, cellAt(hex, 1500, 866))
+	assert.Equal(t, [2]int{-1, -1}, cellAt(hex, -1500, -866))
+	assert.Equal(t, [2]int{1, -1}, cellAt(hex, 1500, -900))
+
+	square := Grid{Type: GridSquare, Size: 1000}
+	assert.Equal(t, [2]int{0, 0}, cellAt(square, 0, 999))
+	assert.Equal(t, [2]int{-1, 2}, cellAt(square, -1, 2000))
+}
+
+func TestGrid_CellPolygon(t *testing.T) {
+	for _, grid := range []Grid{{Type: GridHex, Size: 5000}, {Type: GridSquare, Size: 5000}} {
+		lon, lat := 106.8271, -6.1754
+		i, j := grid.CellAt(Mercator(lon, lat))
+		polygon := grid.CellPolygon(i, j)
+
+		assert.NoError(t, polygon.Validate())
+		bounds := polygon.Bounds()
+		assert.True(t, bounds.MinLon <= lon && lon <= bounds.MaxLon, string(grid.Type))
+		assert.True(t, bounds.MinLat <= lat && lat <= bounds.MaxLat, string(grid.Type))
+	}
+
+	ring := Grid{Type: GridHex, Size: 1000}.CellPolygon(0, 0).PolygonCoordinates[0]
+	assert.Len(t, ring, 7)
+	assert.InDelta(t, -1000*180/webMercatorHalfWorld, ring[0][0], 1e-12)
+	assert.InDelta(t, 0, ring[0][1], 1e-12)
+}
+
+func TestGrid_AtLatitude(t *testing.T) {
+	for _, lat := range []float64{0, 60, -75} {
+		grid := Grid{Type: GridSquare, Size: 1000}.AtLatitude(lat)
+		ring := grid.CellPolygon(grid.CellAt(Mercator(10, lat))).PolygonCoordinates[0]
+
+		// Measure the cell in an equal-area projection centred on it.
+		center := EqualArea{Lon: (ring[0][0] + ring[2][0]) / 2, Lat: (ring[0][1] + ring[2][1]) / 2}
+		minX, minY := center.Forward(ring[0][0], ring[0][1])
+		maxX, maxY := center.Forward(ring[2][0], ring[2][1])
+		assert.InDelta(t, 1000, maxX-minX, 10, "%v", lat)
+		assert.InDelta(t, 1000, maxY-minY, 10, "%v", lat)
+	}
+}
+
+func TestGrid_CellCount(t *testing.T) {
+	bbox := BBox{MinLon: 0, MinLat: 0, MaxLon: 1, MaxLat: 1}
+
+	assert.InDelta(t, 12544, Grid{Type: GridSquare, Size: 1000}.CellCount(bbox), 200)
+	assert.Greater(t, Grid{Type: GridHex, Size: 100}.CellCount(bbox), float64(MaxGridCells))
+}
+
+func TestGrid_CellsCovering(t *testing.T) {
+	square := Grid{Type: GridSquare, Size: 1000}
+	assert.Equal(t, [][2]int{{-1, 0}, {-1, 1}, {0, 0}, {0, 1}}, square.CellsCovering(-500, 0, 500, 1500))
+	assert.Equal(t, [][2]int{{0, 0}}, square.CellsCovering(0, 0, 1000, 1000))
+
+	hex := Grid{Type: GridHex, Size: 1000}
+	for _, cell := range hex.CellsCovering(-3000, -3000, 3000, 3000) {
+		ring := hex.CellRing(cell[0], cell[1])
+		assert.True(t, ring[0][0] < 3000 && ring[3][0] > -3000, "%v", cell)
+		assert.True(t, ring[1][1] < 3000 && ring[4][1] > -3000, "%v", cell)
+	}
+
+	// Every point of the extent lies in a covering cell.
+	covering := hex.CellsCovering(-3000, -3000, 3000, 3000)
+	for x := -3000.0; x <= 3000; x += 250 {
+		for y := -3000.0; y <= 3000; y += 250 {
+			assert.Contains(t, covering, cellAt(hex, x, y))
+		}
+	}
+}
+
+func TestGeometry_Bounds(t *testing.T) {
+	polygon := Geometry{Type: PolygonType, PolygonCoordinates: [][][2]float64{{{30, 10}, {40, 40}, {20, 40}, {10, 20}, {30, 10}}}}
+	assert.Equal(t, BBox{MinLon: 10, MinLat: 10, MaxLon: 40, MaxLat: 40}, polygon.Bounds())
+
+	point := Geometry{Type: PointType, PointCoordinates: [2]float64{1, 2}}
+	assert.Equal(t, BBox{MinLon: 1, MinLat: 2, MaxLon: 1, MaxLat: 2}, point.Bounds())
+}
+
+func cellAt(grid Grid, x, y float64) [2]int {
+	i, j := grid.CellAt(x, y)
+	return [2]int{i, j}
+}
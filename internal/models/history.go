@@ -0,0 +1,52 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Operations recorded in the contour history.
+const (
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// AnonymousActor is the actor of changes made by unidentified callers.
+const AnonymousActor = "anonymous"
+
+// ContourHistory is an immutable record of a contour version replaced by an
+// update or removed by a delete, Actor being who did so. The version was
+// current from ValidFrom, unknown for contours older than the history, until
+// ValidTo.
+type ContourHistory struct {
+	ID         uint       `json:"-" gorm:"primaryKey"`
+	ContourID  uint       `json:"contour_id" gorm:"column:contour_id;not null;index"`
+	Version    uint       `json:"version" gorm:"column:version;not null"`
+	Data       Geometry   `json:"data" gorm:"column:data;type:geometry(POLYGON,4326)"`
+	Properties Properties `json:"properties,omitempty" gorm:"column:properties;type:jsonb"`
+	Operation  string     `json:"operation" gorm:"column:operation;type:varchar(16);not null"`
+	Actor      string     `json:"actor" gorm:"column:actor;not null"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty" gorm:"column:valid_from"`
+	ValidTo    time.Time  `json:"valid_to" gorm:"column:valid_to;not null"`
+}
+
+func (ContourHistory) TableName() string {
+	return "contour_history"
+}
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the actor of the changes made with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, AnonymousActor when there is
+// none.
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	return AnonymousActor
+}
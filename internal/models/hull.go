@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+type HullType string
+
+const (
+	HullConvex  HullType = "convex"
+	HullConcave HullType = "concave"
+
+	// DefaultTargetPercent is the concave hull target used when none is given.
+	DefaultTargetPercent = 0.8
+)
+
+// Hull describes the hull of a point set. TargetPercent, in [0, 1], is the
+// ST_ConcaveHull target of a concave hull, 1 giving the convex hull and lower
+// values hugging the points more closely.
+type Hull struct {
+	Type          HullType
+	TargetPercent float64
+}
+
+// HullInput selects the points a hull is computed over: the inline
+// Positions, or the stored points matching IDs, BBox and ContourID, all of
+// them when none is set.
+type HullInput struct {
+	Positions [][2]float64
+	IDs       []uint
+	BBox      *BBox
+	ContourID uint
+}
+
+func (h Hull) Validate() error {
+	switch h.Type {
+	case HullConvex:
+		return nil
+	case HullConcave:
+		if h.TargetPercent < 0 || h.TargetPercent > 1 || math.IsNaN(h.TargetPercent) {
+			return constants.ErrInvalidTargetPercent
+		}
+
+		return nil
+	default:
+		return constants.ErrInvalidHull
+	}
+}
+
+func (in HullInput) Validate() error {
+	if len(in.Positions) > 0 && (len(in.IDs) > 0 || in.BBox != nil || in.ContourID != 0) {
+		return constants.ErrConflictingHullInput
+	}
+
+	if in.BBox != nil && in.ContourID != 0 {
+		return constants.ErrConflictingFilters
+	}
+
+	if in.BBox != nil {
+		if err := in.BBox.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, position := range in.Positions {
+		if !inRange(position) {
+			return constants.ErrCoordinatesOutOfRange.At(fmt.Sprintf("points[%d]", i)).WithDetails(map[string]any{"position": position})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHull_Validate(t *testing.T) {
+	assert.NoError(t, Hull{Type: HullConvex}.Validate())
+	assert.NoError(t, Hull{Type: HullConcave, TargetPercent: 0}.Validate())
+	assert.NoError(t, Hull{Type: HullConcave, TargetPercent: 1}.Validate())
+	assert.ErrorIs(t, Hull{Type: HullConcave, TargetPercent: 1.5}.Validate(), constants.ErrInvalidTargetPercent)
+	assert.ErrorIs(t, Hull{Type: "alpha"}.Validate(), constants.ErrInvalidHull)
+}
+
+func TestHullInput_Validate(t *testing.T) {
+	assert.NoError(t, HullInput{}.Validate())
+	assert.NoError(t, HullInput{IDs: []uint{1, 2}, BBox: &BBox{0, 0, 1, 1}}.Validate())
+	assert.NoError(t, HullInput{Positions: [][2]float64{{0, 0}, {1, 1}}}.Validate())
+	assert.ErrorIs(t, HullInput{Positions: [][2]float64{{0, 0}}, IDs: []uint{1}}.Validate(), constants.ErrConflictingHullInput)
+	assert.ErrorIs(t, HullInput{BBox: &BBox{0, 0, 1, 1}, ContourID: 1}.Validate(), constants.ErrConflictingFilters)
+	assert.ErrorIs(t, HullInput{BBox: &BBox{1, 0, 0, 1}}.Validate(), constants.ErrInvalidBBox)
+	assert.ErrorIs(t, HullInput{Positions: [][2]float64{{200, 0}}}.Validate(), constants.ErrCoordinatesOutOfRange)
+}
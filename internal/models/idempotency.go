@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// IdempotentRequest is a request made with an Idempotency-Key, with the
+// response to replay when it is retried until ExpiresAt. Keys are only unique
+// within the Scope of a caller, so callers picking the same key do not share
+// responses. A zero StatusCode marks a request still in progress, whose claim
+// on the key lapses at ClaimExpiresAt in case it never ends.
+type IdempotentRequest struct {
+	Scope          string    `gorm:"column:scope;primaryKey"`
+	Key            string    `gorm:"column:key;primaryKey"`
+	RequestHash    string    `gorm:"column:request_hash;not null"`
+	StatusCode     int       `gorm:"column:status_code;not null;default:0"`
+	ContentType    string    `gorm:"column:content_type;not null;default:''"`
+	Location       string    `gorm:"column:location;not null;default:''"`
+	ETag           string    `gorm:"column:etag;not null;default:''"`
+	Body           []byte    `gorm:"column:body"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	ClaimExpiresAt time.Time `gorm:"column:claim_expires_at;not null"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;not null;index"`
+}
+
+func (IdempotentRequest) TableName() string {
+	return "idempotency_keys"
+}
+
+// Done tells whether the response of the request was stored.
+func (r IdempotentRequest) Done() bool {
+	return r.StatusCode != 0
+}
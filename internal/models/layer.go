@@ -0,0 +1,63 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+// MaxLayerNameLength is the length limit of layer names.
+const MaxLayerNameLength = 63
+
+// Layer is a named collection of points and contours, such as the delivery
+// zones or the store locations. Points and contours belong to at most one
+// layer.
+type Layer struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"column:name;type:varchar(63);not null;uniqueIndex"`
+	Description string    `json:"description,omitempty" gorm:"column:description"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at;not null"`
+}
+
+// Validate checks the name of the layer, which is used in query parameters:
+// lower case letters, digits, hyphens and underscores, starting with a letter
+// or digit.
+func (l *Layer) Validate() error {
+	if l.Name == "" || len(l.Name) > MaxLayerNameLength || strings.IndexAny(l.Name[:1], "-_") == 0 {
+		return constants.ErrInvalidLayerName.At("name")
+	}
+
+	for _, r := range l.Name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return constants.ErrInvalidLayerName.At("name")
+		}
+	}
+
+	return nil
+}
+
+// LayerScope restricts the points and the contours queries see to a layer
+// each, nil standing for all of them. Scoping them to different layers serves
+// cross-layer queries, such as the store locations within a delivery zone.
+type LayerScope struct {
+	Points   *uint
+	Contours *uint
+}
+
+type layerScopeKey struct{}
+
+// WithLayerScope returns a context whose queries are restricted to the layers
+// of scope.
+func WithLayerScope(ctx context.Context, scope LayerScope) context.Context {
+	return context.WithValue(ctx, layerScopeKey{}, scope)
+}
+
+// LayerScopeFrom returns the layer scope carried by ctx, the empty one when
+// there is none.
+func LayerScopeFrom(ctx context.Context) LayerScope {
+	scope, _ := ctx.Value(layerScopeKey{}).(LayerScope)
+	return scope
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayer_Validate(t *testing.T) {
+	for _, name := range []string{"stores", "flood-zones", "delivery_zones_2024", "7eleven"} {
+		assert.NoError(t, (&Layer{Name: name}).Validate(), name)
+	}
+
+	for _, name := range []string{"", "-stores", "_stores", "Stores", "flood zones", "zones/a", strings.Repeat("a", MaxLayerNameLength+1)} {
+		assert.ErrorIs(t, (&Layer{Name: name}).Validate(), constants.ErrInvalidLayerName, name)
+	}
+}
+
+func TestLayerScope(t *testing.T) {
+	assert.Equal(t, LayerScope{}, LayerScopeFrom(context.Background()))
+
+	points, contours := uint(1), uint(2)
+	scope := LayerScope{Points: &points, Contours: &contours}
+	assert.Equal(t, scope, LayerScopeFrom(WithLayerScope(context.Background(), scope)))
+}
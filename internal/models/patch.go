@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/pkg/jsonpatch"
+)
+
+// Media types of the patches of points and contours.
+const (
+	MediaTypeMergePatch = "application/merge-patch+json"
+	MediaTypeJSONPatch  = "application/json-patch+json"
+)
+
+// Patch is a change to the data and properties of a point or contour, an RFC
+// 7396 merge patch or an RFC 6902 JSON Patch as told by MediaType. It applies
+// to the document {"data": ..., "properties": ...}, as in create requests,
+// properties being an empty object when there are none.
+type Patch struct {
+	MediaType string
+	Body      []byte
+}
+
+type patchDocument struct {
+	Data       Geometry   `json:"data"`
+	Properties Properties `json:"properties"`
+}
+
+// Apply patches data and properties. constants.ErrInvalidPatch is returned
+// for malformed patches and constants.ErrPatchConflict for those that do not
+// apply to the current document. A patched document that no longer decodes
+// returns the decoding error, while the patched geometry is left to be
+// validated by the caller.
+func (p Patch) Apply(data *Geometry, properties *Properties) error {
+	current := patchDocument{Data: *data, Properties: *properties}
+	if current.Properties == nil {
+		current.Properties = Properties{}
+	}
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	switch p.MediaType {
+	case MediaTypeMergePatch:
+		doc, err = jsonpatch.MergePatch(doc, p.Body)
+	case MediaTypeJSONPatch:
+		doc, err = jsonpatch.Apply(doc, p.Body)
+	default:
+		return constants.ErrUnsupportedMediaType
+	}
+
+	switch {
+	case errors.Is(err, jsonpatch.ErrInvalidPatch):
+		return constants.ErrInvalidPatch.Wrap(err)
+	case errors.Is(err, jsonpatch.ErrPathNotFound), errors.Is(err, jsonpatch.ErrTestFailed):
+		return constants.ErrPatchConflict.Wrap(err)
+	case err != nil:
+		return err
+	}
+
+	var patched patchDocument
+	if err := json.Unmarshal(doc, &patched); err != nil {
+		return err
+	}
+
+	if len(patched.Properties) == 0 {
+		patched.Properties = nil
+	}
+
+	*data, *properties = patched.Data, patched.Properties
+	return nil
+}
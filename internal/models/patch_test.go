@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch(t *testing.T) {
+	square := func() Geometry {
+		return Geometry{Type: PolygonType, PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}}
+	}
+
+	data, properties := square(), Properties{"name": "field", "crop": "rice"}
+	patch := Patch{MediaType: MediaTypeMergePatch, Body: []byte(`{"properties":{"crop":null,"owner":"alice"}}`)}
+	assert.NoError(t, patch.Apply(&data, &properties))
+	assert.Equal(t, square(), data)
+	assert.Equal(t, Properties{"name": "field", "owner": "alice"}, properties)
+
+	// Holes are added to polygons by appending rings.
+	data, properties = square(), nil
+	patch = Patch{MediaType: MediaTypeJSONPatch, Body: []byte(`[
+		{"op":"add","path":"/data/coordinates/-","value":[[2,2],[2,4],[4,4],[4,2],[2,2]]},
+		{"op":"add","path":"/properties/name","value":"field"}
+	]`)}
+	assert.NoError(t, patch.Apply(&data, &properties))
+	assert.Len(t, data.PolygonCoordinates, 2)
+	assert.Equal(t, [2]float64{4, 4}, data.PolygonCoordinates[1][2])
+	assert.Equal(t, Properties{"name": "field"}, properties)
+
+	data, properties = square(), Properties{"name": "field"}
+	patch = Patch{MediaType: MediaTypeMergePatch, Body: []byte(`{"properties":null}`)}
+	assert.NoError(t, patch.Apply(&data, &properties))
+	assert.Nil(t, properties)
+
+	patch = Patch{MediaType: MediaTypeJSONPatch, Body: []byte(`{"op":"remove"}`)}
+	assert.ErrorIs(t, patch.Apply(&data, &properties), constants.ErrInvalidPatch)
+
+	patch = Patch{MediaType: MediaTypeJSONPatch, Body: []byte(`[{"op":"test","path":"/data/type","value":"Point"}]`)}
+	assert.ErrorIs(t, patch.Apply(&data, &properties), constants.ErrPatchConflict)
+
+	patch = Patch{MediaType: MediaTypeJSONPatch, Body: []byte(`[{"op":"remove","path":"/properties/name"}]`)}
+	assert.ErrorIs(t, patch.Apply(&data, &properties), constants.ErrPatchConflict)
+
+	patch = Patch{MediaType: "application/json", Body: []byte(`{}`)}
+	assert.ErrorIs(t, patch.Apply(&data, &properties), constants.ErrUnsupportedMediaType)
+
+	patch = Patch{MediaType: MediaTypeMergePatch, Body: []byte(`{"properties":"none"}`)}
+	assert.Error(t, patch.Apply(&data, &properties))
+	assert.Equal(t, square(), data)
+}
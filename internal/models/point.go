@@ -1,6 +1,37 @@
 package models
 
+import (
+	"time"
+
+	"github.com/malamsyah/geo-service/pkg/geohash"
+	"gorm.io/gorm"
+)
+
 type Point struct {
-	ID   uint     `json:"id" gorm:"primaryKey"`
-	Data Geometry `json:"data" gorm:"column:data;type:geometry(POINT,4326)"`
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	Data       Geometry   `json:"data" gorm:"column:data;type:geometry(POINT,4326)"`
+	Properties Properties `json:"properties,omitempty" gorm:"column:properties;type:jsonb"`
+	Geohash    string     `json:"geohash,omitempty" gorm:"column:geohash;type:varchar(12)"`
+	// LayerID is the layer the point belongs to, nil when it belongs to none.
+	LayerID *uint  `json:"layer_id,omitempty" gorm:"column:layer_id;index"`
+	Layer   *Layer `json:"-" gorm:"constraint:OnDelete:SET NULL"`
+	// Version is bumped on every update. Set on an update, it is the version
+	// the caller expects to replace.
+	Version uint `json:"version,omitempty" gorm:"column:version;not null;default:1"`
+	// DeletedAt is when the point was moved to the trash, nil while it is live.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"column:deleted_at;index"`
+}
+
+// BeforeSave keeps the stored geohash in step with the point, at full
+// precision so any shorter prefix can be queried.
+func (p *Point) BeforeSave(_ *gorm.DB) error {
+	p.SetGeohash()
+	return nil
+}
+
+// SetGeohash sets the geohash of the point, for writes bypassing the hooks.
+func (p *Point) SetGeohash() {
+	if p.Data.IsPoint() {
+		p.Geohash = geohash.Encode(p.Data.PointCoordinates[0], p.Data.PointCoordinates[1], geohash.MaxPrecision)
+	}
 }
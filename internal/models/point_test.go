@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoint_BeforeSave(t *testing.T) {
+	point := &Point{Data: Geometry{Type: PointType, PointCoordinates: [2]float64{10.40744, 57.64911}}}
+	assert.NoError(t, point.BeforeSave(nil))
+	assert.Len(t, point.Geohash, 12)
+	assert.Equal(t, "u4pruydqqvj", point.Geohash[:11])
+
+	point.Data.PointCoordinates = [2]float64{106.8271, -6.1754}
+	assert.NoError(t, point.BeforeSave(nil))
+	assert.Equal(t, "qqguygv", point.Geohash[:7])
+}
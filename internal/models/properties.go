@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+// Properties holds the free form attributes of a geometry, stored as jsonb.
+type Properties map[string]any
+
+func (p Properties) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (p *Properties) Scan(src interface{}) error {
+	if src == nil {
+		*p = nil
+		return nil
+	}
+
+	var data []byte
+
+	switch src := src.(type) {
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return constants.ErrUnsupportedScan
+	}
+
+	return json.Unmarshal(data, p)
+}
@@ -0,0 +1,31 @@
+package models
+
+import "github.com/malamsyah/geo-service/pkg/simplify"
+
+// Simplify returns the polygon simplified to the tolerance in metres,
+// preserving its topology. Polygons whose simplified form would not pass
+// Validate are kept as they are, as are other geometries.
+func (g Geometry) Simplify(tolerance float64) Geometry {
+	switch {
+	case g.IsPolygon():
+		g.PolygonCoordinates = simplifyPolygon(g.PolygonCoordinates, tolerance)
+	case g.IsMultiPolygon():
+		polygons := make([][][][2]float64, len(g.MultiPolygonCoordinates))
+		for i, polygon := range g.MultiPolygonCoordinates {
+			polygons[i] = simplifyPolygon(polygon, tolerance)
+		}
+
+		g.MultiPolygonCoordinates = polygons
+	}
+
+	return g
+}
+
+func simplifyPolygon(rings [][][2]float64, tolerance float64) [][][2]float64 {
+	simplified := simplify.Polygon(rings, tolerance)
+	if (Geometry{Type: PolygonType, PolygonCoordinates: simplified}).Validate() != nil {
+		return rings
+	}
+
+	return simplified
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeometry_Simplify(t *testing.T) {
+	ring := make([][2]float64, 0)
+	for i := 0; i < 360; i++ {
+		angle := float64(i) * math.Pi / 180
+		ring = append(ring, [2]float64{106.8 + 0.1*math.Cos(angle), -6.2 + 0.1*math.Sin(angle)})
+	}
+
+	ring = append(ring, ring[0])
+	polygon := Geometry{Type: PolygonType, PolygonCoordinates: [][][2]float64{ring}}
+
+	simplified := polygon.Simplify(100)
+	assert.NoError(t, simplified.Validate())
+	assert.Less(t, len(simplified.PolygonCoordinates[0]), len(ring))
+	assert.Len(t, polygon.PolygonCoordinates[0], 361)
+
+	multi := Geometry{Type: MultiPolygon, MultiPolygonCoordinates: [][][][2]float64{{ring}}}
+	assert.Equal(t, simplified.PolygonCoordinates, multi.Simplify(100).MultiPolygonCoordinates[0])
+
+	unclosed := Geometry{Type: PolygonType, PolygonCoordinates: [][][2]float64{ring[:360]}}
+	assert.Equal(t, unclosed, unclosed.Simplify(100))
+
+	point := Geometry{Type: PointType, PointCoordinates: [2]float64{1, 2}}
+	assert.Equal(t, point, point.Simplify(100))
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+// authalicRadius is the radius of the sphere with the area of the WGS84
+// ellipsoid, in metres.
+const authalicRadius = 6371007.181
+
+// Tessellation splits a contour into square or hexagonal cells of equal area.
+// The cells are laid out the way ST_SquareGrid and ST_HexagonGrid do in an
+// EqualArea projection centred on the contour, Size being the side of a
+// square or the edge of a hexagon in metres.
+type Tessellation struct {
+	Type   GridType
+	Size   float64
+	Engine Engine
+}
+
+// TessellationCell is the cell in column I and row J of a tessellation,
+// clipped to the contour. ContourIDs are the contours it is stored as, one
+// per polygon, if any.
+type TessellationCell struct {
+	I          int      `json:"i"`
+	J          int      `json:"j"`
+	ContourIDs []uint   `json:"contour_ids,omitempty" gorm:"-"`
+	Data       Geometry `json:"data" gorm:"column:data"`
+}
+
+func (t Tessellation) Validate() error {
+	switch t.Engine {
+	case EnginePostGIS, EngineGo:
+	default:
+		return constants.ErrInvalidEngine
+	}
+
+	if t.Type != GridSquare && t.Type != GridHex {
+		return constants.ErrInvalidGrid
+	}
+
+	return t.Grid().Validate()
+}
+
+// Grid returns the grid the cells are laid out on.
+func (t Tessellation) Grid() Grid {
+	return Grid{Type: t.Type, Size: t.Size}
+}
+
+// EqualArea is the spherical Lambert azimuthal equal-area projection centred
+// on Lon, Lat, in metres. Areas are true everywhere and shapes are kept close
+// to the centre.
+type EqualArea struct {
+	Lon float64
+	Lat float64
+}
+
+// Forward projects lon/lat to the plane.
+func (p EqualArea) Forward(lon, lat float64) (float64, float64) {
+	phi, phi0 := lat*math.Pi/180, p.Lat*math.Pi/180
+	dLambda := (lon - p.Lon) * math.Pi / 180
+
+	k := math.Sqrt(2 / (1 + math.Sin(phi0)*math.Sin(phi) + math.Cos(phi0)*math.Cos(phi)*math.Cos(dLambda)))
+	x := authalicRadius * k * math.Cos(phi) * math.Sin(dLambda)
+	y := authalicRadius * k * (math.Cos(phi0)*math.Sin(phi) - math.Sin(phi0)*math.Cos(phi)*math.Cos(dLambda))
+
+	return x, y
+}
+
+// Inverse returns the lon/lat of a point of the plane.
+func (p EqualArea) Inverse(x, y float64) [2]float64 {
+	rho := math.Hypot(x, y)
+	if rho == 0 {
+		return [2]float64{p.Lon, p.Lat}
+	}
+
+	phi0 := p.Lat * math.Pi / 180
+	c := 2 * math.Asin(math.Min(rho/(2*authalicRadius), 1))
+
+	lat := math.Asin(math.Cos(c)*math.Sin(phi0) + y*math.Sin(c)*math.Cos(phi0)/rho)
+	dLambda := math.Atan2(x*math.Sin(c), rho*math.Cos(phi0)*math.Cos(c)-y*math.Sin(phi0)*math.Sin(c))
+
+	return [2]float64{p.Lon + dLambda*180/math.Pi, lat * 180 / math.Pi}
+}
+
+// PROJ returns the PROJ definition of the projection, for ST_Transform.
+func (p EqualArea) PROJ() string {
+	return fmt.Sprintf("+proj=laea +lat_0=%s +lon_0=%s +x_0=0 +y_0=0 +R=%s +units=m +no_defs",
+		strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Lon, 'f', -1, 64), strconv.FormatFloat(authalicRadius, 'f', -1, 64))
+}
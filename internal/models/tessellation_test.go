@@ -0,0 +1,48 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTessellation_Validate(t *testing.T) {
+	assert.NoError(t, Tessellation{Type: GridSquare, Size: 500, Engine: EnginePostGIS}.Validate())
+	assert.NoError(t, Tessellation{Type: GridHex, Size: 500, Engine: EngineGo}.Validate())
+	assert.ErrorIs(t, Tessellation{Type: GridContour, Size: 500, Engine: EngineGo}.Validate(), constants.ErrInvalidGrid)
+	assert.ErrorIs(t, Tessellation{Type: GridHex, Size: -1, Engine: EngineGo}.Validate(), constants.ErrInvalidGridSize)
+	assert.ErrorIs(t, Tessellation{Type: GridHex, Size: 500, Engine: "geos"}.Validate(), constants.ErrInvalidEngine)
+}
+
+func TestEqualArea(t *testing.T) {
+	p := EqualArea{Lon: 10, Lat: 60}
+
+	x, y := p.Forward(10, 60)
+	assert.InDelta(t, 0, x, 1e-9)
+	assert.InDelta(t, 0, y, 1e-9)
+
+	for _, position := range [][2]float64{{10.5, 60.2}, {9, 59}, {-20, 45}} {
+		x, y := p.Forward(position[0], position[1])
+		back := p.Inverse(x, y)
+		assert.InDelta(t, position[0], back[0], 1e-9)
+		assert.InDelta(t, position[1], back[1], 1e-9)
+	}
+
+	// One degree of latitude measures about 111 km along the central meridian.
+	_, y0 := p.Forward(10, 60)
+	_, y1 := p.Forward(10, 61)
+	assert.InDelta(t, 111195, y1-y0, 100)
+
+	// A cell of one square kilometre covers the same area on the sphere,
+	// 1 - sin(lat) measuring the cap above a parallel.
+	south, north := p.Inverse(0, -500), p.Inverse(0, 500)
+	west, east := p.Inverse(-500, 0), p.Inverse(500, 0)
+	capArea := func(lat float64) float64 { return 1 - math.Sin(lat*math.Pi/180) }
+	band := 2 * math.Pi * authalicRadius * authalicRadius * (capArea(south[1]) - capArea(north[1]))
+	assert.InDelta(t, 1e6, band*(east[0]-west[0])/360, 1e3)
+
+	assert.Equal(t, "+proj=laea +lat_0=0.00001 +lon_0=-5 +x_0=0 +y_0=0 +R=6371007.181 +units=m +no_defs", EqualArea{Lon: -5, Lat: 1e-5}.PROJ())
+	assert.Equal(t, "+proj=laea +lat_0=60 +lon_0=10 +x_0=0 +y_0=0 +R=6371007.181 +units=m +no_defs", p.PROJ())
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"math"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+const (
+	// MaxTileZoom is the deepest zoom level tiles are served for.
+	MaxTileZoom = 24
+	// TileExtent is the size of a tile in its own integer coordinates.
+	TileExtent = 4096
+	// TileBuffer is the margin, in tile coordinates, kept around a tile so
+	// geometries crossing its edges render without seams.
+	TileBuffer = 64
+
+	webMercatorHalfWorld = 20037508.342789244
+)
+
+// Tile addresses a Web Mercator (EPSG:3857) tile in the XYZ scheme.
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+func (t Tile) Validate() error {
+	if t.Z < 0 || t.Z > MaxTileZoom {
+		return constants.ErrInvalidTile
+	}
+
+	size := 1 << t.Z
+	if t.X < 0 || t.X >= size || t.Y < 0 || t.Y >= size {
+		return constants.ErrInvalidTile
+	}
+
+	return nil
+}
+
+// Resolution is the size in EPSG:3857 metres of one tile coordinate unit,
+// which is also the tolerance geometries can be simplified to without any
+// visible change.
+func (t Tile) Resolution() float64 {
+	return 2 * webMercatorHalfWorld / math.Exp2(float64(t.Z)) / TileExtent
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTile(t *testing.T) {
+	assert.NoError(t, Tile{Z: 0, X: 0, Y: 0}.Validate())
+	assert.NoError(t, Tile{Z: 3, X: 7, Y: 7}.Validate())
+	assert.ErrorIs(t, Tile{Z: 3, X: 8, Y: 0}.Validate(), constants.ErrInvalidTile)
+	assert.ErrorIs(t, Tile{Z: -1}.Validate(), constants.ErrInvalidTile)
+	assert.ErrorIs(t, Tile{Z: MaxTileZoom + 1}.Validate(), constants.ErrInvalidTile)
+
+	// A zoom 0 tile spans the whole Web Mercator world.
+	assert.InDelta(t, 2*webMercatorHalfWorld/TileExtent, Tile{}.Resolution(), 1e-9)
+	assert.InDelta(t, Tile{}.Resolution()/1024, Tile{Z: 10}.Resolution(), 1e-9)
+}
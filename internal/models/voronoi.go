@@ -0,0 +1,49 @@
+package models
+
+import "github.com/malamsyah/geo-service/internal/constants"
+
+type Engine string
+
+const (
+	// EnginePostGIS computes geometries on the database, falling back to Go
+	// when it lacks the functions needed.
+	EnginePostGIS Engine = "postgis"
+	// EngineGo computes geometries in memory.
+	EngineGo Engine = "go"
+)
+
+// VoronoiInput selects the seed points of a Voronoi diagram or Delaunay
+// triangulation: the points of IDs, or else those within the contour.
+// Voronoi cells are clipped to the contour.
+type VoronoiInput struct {
+	ContourID uint
+	IDs       []uint
+	Engine    Engine
+}
+
+// VoronoiCell is the Voronoi cell of the point PointID. ContourIDs are the
+// contours it is stored as, one per polygon, if any.
+type VoronoiCell struct {
+	PointID    uint     `json:"point_id"`
+	ContourIDs []uint   `json:"contour_ids,omitempty" gorm:"-"`
+	Data       Geometry `json:"data" gorm:"column:data"`
+}
+
+// Validate checks the input, the contour being required when clip is set.
+func (in VoronoiInput) Validate(clip bool) error {
+	switch in.Engine {
+	case EnginePostGIS, EngineGo:
+	default:
+		return constants.ErrInvalidEngine
+	}
+
+	if clip && in.ContourID == 0 {
+		return constants.ErrMissingContour
+	}
+
+	if in.ContourID == 0 && len(in.IDs) == 0 {
+		return constants.ErrMissingSeeds
+	}
+
+	return nil
+}
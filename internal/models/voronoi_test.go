@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoronoiInput_Validate(t *testing.T) {
+	assert.NoError(t, VoronoiInput{ContourID: 1, Engine: EnginePostGIS}.Validate(true))
+	assert.NoError(t, VoronoiInput{ContourID: 1, IDs: []uint{1, 2}, Engine: EngineGo}.Validate(true))
+	assert.NoError(t, VoronoiInput{IDs: []uint{1, 2}, Engine: EngineGo}.Validate(false))
+	assert.ErrorIs(t, VoronoiInput{IDs: []uint{1, 2}, Engine: EngineGo}.Validate(true), constants.ErrMissingContour)
+	assert.ErrorIs(t, VoronoiInput{Engine: EngineGo}.Validate(false), constants.ErrMissingSeeds)
+	assert.ErrorIs(t, VoronoiInput{ContourID: 1, Engine: "geos"}.Validate(true), constants.ErrInvalidEngine)
+}
@@ -0,0 +1,229 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+)
+
+// WKT returns the Well-Known Text representation of the geometry, or an empty
+// string for unsupported types.
+func (g Geometry) WKT() string {
+	switch g.Type {
+	case PointType:
+		return fmt.Sprintf("POINT(%s)", positionToString(g.PointCoordinates))
+	case PolygonType:
+		return fmt.Sprintf("POLYGON(%s)", ringsToString(g.PolygonCoordinates))
+	case MultiPolygon:
+		polygons := make([]string, 0, len(g.MultiPolygonCoordinates))
+		for _, p := range g.MultiPolygonCoordinates {
+			polygons = append(polygons, fmt.Sprintf("(%s)", ringsToString(p)))
+		}
+
+		return fmt.Sprintf("MULTIPOLYGON(%s)", strings.Join(polygons, ","))
+	default:
+		return ""
+	}
+}
+
+// MultiPointWKT returns the Well-Known Text MULTIPOINT of the positions.
+func MultiPointWKT(positions [][2]float64) string {
+	points := make([]string, 0, len(positions))
+	for _, p := range positions {
+		points = append(points, fmt.Sprintf("(%s)", positionToString(p)))
+	}
+
+	return fmt.Sprintf("MULTIPOINT(%s)", strings.Join(points, ","))
+}
+
+func positionToString(p [2]float64) string {
+	return fmt.Sprintf("%s %s", fmt.Sprint(p[0]), fmt.Sprint(p[1]))
+}
+
+func ringsToString(rings [][][2]float64) string {
+	coords := make([]string, 0)
+	for _, c := range rings {
+		var points []string
+
+		for _, p := range c {
+			points = append(points, positionToString(p))
+		}
+
+		joined := strings.Join(points, ",")
+		coords = append(coords, fmt.Sprintf("(%s)", joined))
+	}
+
+	return strings.Join(coords, ",")
+}
+
+// ParseWKT reads a POINT, POLYGON or MULTIPOLYGON in Well-Known Text. An EWKT
+// SRID prefix is accepted and ignored, coordinates are assumed to be EPSG:4326.
+func ParseWKT(s string) (Geometry, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToUpper(s), "SRID=") {
+		idx := strings.Index(s, ";")
+		if idx < 0 {
+			return Geometry{}, constants.ErrInvalidWKT
+		}
+
+		s = s[idx+1:]
+	}
+
+	open := strings.Index(s, "(")
+	if open < 0 {
+		return Geometry{}, constants.ErrInvalidWKT
+	}
+
+	p := &wktParser{s: s[open:]}
+
+	var g Geometry
+	var err error
+
+	switch strings.ToUpper(strings.TrimSpace(s[:open])) {
+	case "POINT":
+		g.Type = PointType
+		g.PointCoordinates, err = p.point()
+	case "POLYGON":
+		g.Type = PolygonType
+		g.PolygonCoordinates, err = p.polygon()
+	case "MULTIPOLYGON":
+		g.Type = MultiPolygon
+		g.MultiPolygonCoordinates, err = p.multiPolygon()
+	default:
+		return Geometry{}, constants.ErrInvalidGeometryType
+	}
+
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	if p.skipSpaces(); p.pos != len(p.s) {
+		return Geometry{}, constants.ErrInvalidWKT
+	}
+
+	return g, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		return 0, constants.ErrInvalidWKT
+	}
+
+	return v, nil
+}
+
+func (p *wktParser) position() ([2]float64, error) {
+	x, err := p.number()
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	y, err := p.number()
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	return [2]float64{x, y}, nil
+}
+
+// list parses a parenthesised, comma separated list calling item for each
+// element.
+func (p *wktParser) list(item func() error) error {
+	if !p.consume('(') {
+		return constants.ErrInvalidWKT
+	}
+
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+
+		if p.consume(')') {
+			return nil
+		}
+
+		if !p.consume(',') {
+			return constants.ErrInvalidWKT
+		}
+	}
+}
+
+func (p *wktParser) point() ([2]float64, error) {
+	var pos [2]float64
+	count := 0
+	err := p.list(func() error {
+		var err error
+		pos, err = p.position()
+		count++
+		return err
+	})
+
+	if err == nil && count != 1 {
+		err = constants.ErrInvalidWKT
+	}
+
+	return pos, err
+}
+
+func (p *wktParser) ring() ([][2]float64, error) {
+	ring := make([][2]float64, 0)
+	err := p.list(func() error {
+		pos, err := p.position()
+		ring = append(ring, pos)
+		return err
+	})
+
+	return ring, err
+}
+
+func (p *wktParser) polygon() ([][][2]float64, error) {
+	rings := make([][][2]float64, 0)
+	err := p.list(func() error {
+		ring, err := p.ring()
+		rings = append(rings, ring)
+		return err
+	})
+
+	return rings, err
+}
+
+func (p *wktParser) multiPolygon() ([][][][2]float64, error) {
+	polygons := make([][][][2]float64, 0)
+	err := p.list(func() error {
+		polygon, err := p.polygon()
+		polygons = append(polygons, polygon)
+		return err
+	})
+
+	return polygons, err
+}
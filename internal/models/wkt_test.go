@@ -0,0 +1,91 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		name          string
+		wkt           string
+		expected      Geometry
+		expectedError error
+	}{
+		{
+			name:     "Point",
+			wkt:      "POINT(1.5 -2)",
+			expected: Geometry{Type: PointType, PointCoordinates: [2]float64{1.5, -2}},
+		},
+		{
+			name:     "PointWithSRIDAndSpaces",
+			wkt:      " SRID=4326;point ( 1e1  2 ) ",
+			expected: Geometry{Type: PointType, PointCoordinates: [2]float64{10, 2}},
+		},
+		{
+			name: "PolygonWithHole",
+			wkt:  "POLYGON((0 0, 10 0, 10 10, 0 0), (1 1, 2 1, 2 2, 1 1))",
+			expected: Geometry{Type: PolygonType, PolygonCoordinates: [][][2]float64{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 0}},
+				{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+			}},
+		},
+		{
+			name: "MultiPolygon",
+			wkt:  "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))",
+			expected: Geometry{Type: MultiPolygon, MultiPolygonCoordinates: [][][][2]float64{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{5, 5}, {6, 5}, {6, 6}, {5, 5}}},
+			}},
+		},
+		{
+			name:          "UnsupportedType",
+			wkt:           "LINESTRING(0 0,1 1)",
+			expectedError: constants.ErrInvalidGeometryType,
+		},
+		{
+			name:          "PointWithTwoPositions",
+			wkt:           "POINT(0 0,1 1)",
+			expectedError: constants.ErrInvalidWKT,
+		},
+		{
+			name:          "Unbalanced",
+			wkt:           "POLYGON((0 0,1 0,1 1,0 0)",
+			expectedError: constants.ErrInvalidWKT,
+		},
+		{
+			name:          "TrailingGarbage",
+			wkt:           "POINT(0 0) x",
+			expectedError: constants.ErrInvalidWKT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ParseWKT(tt.wkt)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, g)
+			assert.Equal(t, tt.expected, mustParseWKT(t, g.WKT()))
+		})
+	}
+}
+
+func mustParseWKT(t *testing.T, wkt string) Geometry {
+	g, err := ParseWKT(wkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestMultiPointWKT(t *testing.T) {
+	assert.Equal(t, "MULTIPOINT((106.8 -6.2),(1 2))", MultiPointWKT([][2]float64{{106.8, -6.2}, {1, 2}}))
+}
@@ -1,22 +1,43 @@
 package repository
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/models"
 	"gorm.io/gorm"
 )
 
 type ContourRepository interface {
-	CreateContour(Contour *models.Contour) error
-	GetContourByID(id uint) (*models.Contour, error)
-	GetContours(offset, limit int) ([]models.Contour, error)
-	UpdateContour(contour *models.Contour) error
-	DeleteContour(id uint) error
-	GetContoursIntersectArea(idA, idB uint) ([]models.Contour, error)
+	CreateContour(ctx context.Context, Contour *models.Contour) error
+	CreateContours(ctx context.Context, contours []models.Contour, batchSize int) error
+	GetContourByID(ctx context.Context, id uint) (*models.Contour, error)
+	GetContours(ctx context.Context, offset, limit int) ([]models.Contour, error)
+	GetContoursByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Contour, error)
+	StreamContours(ctx context.Context, bbox *models.BBox, fn func(*models.Contour) error) error
+	GetContoursTile(ctx context.Context, tile models.Tile) ([]byte, error)
+	UpdateContour(ctx context.Context, contour *models.Contour) error
+	DeleteContour(ctx context.Context, id, version uint) error
+	GetContoursIntersectArea(ctx context.Context, idA, idB uint) ([]models.Contour, error)
+	GetContoursPointCount(ctx context.Context, bbox *models.BBox) ([]models.Cell, error)
+	GetContourBuffer(ctx context.Context, id uint, buffer models.Buffer) (*models.Contour, error)
+	GetContourTessellation(ctx context.Context, id uint, tessellation models.Tessellation, projection models.EqualArea) ([]models.TessellationCell, error)
+	SaveSimplifiedContour(ctx context.Context, variant *models.SimplifiedContour) error
+	GetSimplifiedContour(ctx context.Context, contourID uint) (*models.SimplifiedContour, error)
+	GetContourHistory(ctx context.Context, id uint) ([]models.ContourHistory, error)
+	GetContourAsOf(ctx context.Context, id uint, at time.Time) (*models.Contour, error)
+	UndeleteContour(ctx context.Context, id uint) (*models.Contour, error)
+	GetDeletedContours(ctx context.Context, offset, limit int) ([]models.Contour, error)
+	PurgeContours(ctx context.Context, before time.Time) (int64, error)
 }
 
+// ContoursLayer is the name of the vector tile layer holding contours.
+const ContoursLayer = "contours"
+
 type ContourRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -25,17 +46,23 @@ func NewContourRepository(db *gorm.DB) ContourRepository {
 	return &ContourRepositoryImpl{db}
 }
 
-func (r *ContourRepositoryImpl) CreateContour(contour *models.Contour) error {
-	return r.db.Create(contour).Error
+func (r *ContourRepositoryImpl) CreateContour(ctx context.Context, contour *models.Contour) error {
+	return dbError(r.db.WithContext(ctx).Create(contour).Error)
+}
+
+func (r *ContourRepositoryImpl) CreateContours(ctx context.Context, contours []models.Contour, batchSize int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return dbError(tx.CreateInBatches(contours, batchSize).Error)
+	})
 }
 
-func (r *ContourRepositoryImpl) GetContourByID(id uint) (*models.Contour, error) {
+func (r *ContourRepositoryImpl) GetContourByID(ctx context.Context, id uint) (*models.Contour, error) {
 	contour := new(models.Contour)
-	query, params := r.getContourQuery(filter{ID: id})
+	query, params := r.getContourQuery(contourFilter(ctx, filter{ID: id}))
 
-	err := r.db.Raw(query, params...).Scan(&contour).Error
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&contour).Error
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 
 	if contour.ID == uint(0) {
@@ -45,48 +72,322 @@ func (r *ContourRepositoryImpl) GetContourByID(id uint) (*models.Contour, error)
 	return contour, nil
 }
 
-func (r *ContourRepositoryImpl) GetContours(offset, limit int) ([]models.Contour, error) {
+func (r *ContourRepositoryImpl) GetContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
 	var contours []models.Contour
-	query, params := r.getContourQuery(filter{Offset: offset, Limit: limit})
+	query, params := r.getContourQuery(contourFilter(ctx, filter{Offset: offset, Limit: limit}))
 
-	err := r.db.Raw(query, params...).Scan(&contours).Error
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&contours).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	return contours, nil
+}
+
+// UpdateContour replaces the polygon and properties of a contour and bumps
+// its version, recording the replaced one in the contour history. The contour
+// stays in its layer, which contour is set to. A contour carrying a version
+// is only replaced while it is current, constants.ErrVersionMismatch being
+// returned otherwise. Contours outside the layer scope of ctx are not found.
+func (r *ContourRepositoryImpl) UpdateContour(ctx context.Context, contour *models.Contour) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := tx.NowFunc()
+		if err := recordContourHistory(tx, contour.ID, contour.Version, models.OperationUpdate, models.ActorFrom(ctx), now); err != nil {
+			return err
+		}
+
+		params := []any{contour.Data, contour.Properties, now}
+		updated, err := updateVersioned(tx, "contours", "data = ?, properties = ?, updated_at = ?", params,
+			contour.ID, contour.Version, models.LayerScopeFrom(ctx).Contours, constants.ErrContourNotFound)
+		if err != nil {
+			return err
+		}
+
+		contour.Version, contour.LayerID = updated.Version, updated.LayerID
+		contour.UpdatedAt = &now
+		return nil
+	})
+}
+
+// DeleteContour moves a contour to the trash, only while it is at version
+// when that is set, recording it in the contour history.
+// constants.ErrContourNotFound is returned when it does not exist or is
+// outside the layer scope of ctx. Its simplified variant and the cells it was tessellated into are left alone
+// until it is purged.
+func (r *ContourRepositoryImpl) DeleteContour(ctx context.Context, id, version uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := tx.NowFunc()
+		if err := recordContourHistory(tx, id, version, models.OperationDelete, models.ActorFrom(ctx), now); err != nil {
+			return err
+		}
+
+		return deleteVersioned(tx, "contours", id, version, models.LayerScopeFrom(ctx).Contours, now, constants.ErrContourNotFound)
+	})
+}
+
+// UndeleteContour takes a contour out of the trash as a new version.
+// constants.ErrContourNotFound is returned when it does not exist and
+// constants.ErrContourNotDeleted when it is not in the trash.
+func (r *ContourRepositoryImpl) UndeleteContour(ctx context.Context, id uint) (*models.Contour, error) {
+	query := "UPDATE contours SET deleted_at = NULL, version = version + 1, updated_at = ? WHERE id = ? AND deleted_at IS NOT NULL"
+
+	result := r.db.WithContext(ctx).Exec(query, r.db.NowFunc(), id)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	contour, err := r.GetContourByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrContourNotDeleted
+	}
+
+	return contour, nil
+}
+
+// GetDeletedContours lists the contours in the trash.
+func (r *ContourRepositoryImpl) GetDeletedContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
+	contours := make([]models.Contour, 0)
+	query, params := r.getContourQuery(contourFilter(ctx, filter{Trashed: true, Offset: offset, Limit: limit}))
+
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(&contours).Error; err != nil {
+		return nil, dbError(err)
+	}
+
 	return contours, nil
 }
 
-func (r *ContourRepositoryImpl) UpdateContour(contour *models.Contour) error {
-	return r.db.Save(contour).Error
+// PurgeContours deletes for good the contours moved to the trash before the
+// given time, with their simplified variants, and returns how many there
+// were. The cells they were tessellated into are kept, unlinked from them.
+// Their history is kept as well.
+func (r *ContourRepositoryImpl) PurgeContours(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trashed := tx.Model(&models.Contour{}).Select("id").Where("deleted_at < ?", before)
+
+		if err := tx.Where("contour_id IN (?)", trashed).Delete(&models.SimplifiedContour{}).Error; err != nil {
+			return dbError(err)
+		}
+
+		if err := tx.Model(&models.Contour{}).Where("parent_id IN (?)", trashed).Update("parent_id", nil).Error; err != nil {
+			return dbError(err)
+		}
+
+		result := tx.Exec("DELETE FROM contours WHERE deleted_at < ?", before)
+		purged = result.RowsAffected
+		return dbError(result.Error)
+	})
+
+	return purged, err
+}
+
+func (r *ContourRepositoryImpl) GetContoursByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Contour, error) {
+	var contours []models.Contour
+	query, params := r.getContourQuery(contourFilter(ctx, filter{BBox: &bbox, Offset: offset, Limit: limit}))
+
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&contours).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	return contours, nil
+}
+
+func (r *ContourRepositoryImpl) StreamContours(ctx context.Context, bbox *models.BBox, fn func(*models.Contour) error) error {
+	query, params := r.getContourQuery(contourFilter(ctx, filter{BBox: bbox, Unbounded: true}))
+
+	rows, err := r.db.WithContext(ctx).Raw(query, params...).Rows()
+	if err != nil {
+		return dbError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contour models.Contour
+		if err := r.db.ScanRows(rows, &contour); err != nil {
+			return dbError(err)
+		}
+
+		if err := fn(&contour); err != nil {
+			return dbError(err)
+		}
+	}
+
+	return dbError(rows.Err())
 }
 
-func (r *ContourRepositoryImpl) DeleteContour(id uint) error {
-	return r.db.Delete(&models.Contour{}, id).Error
+// contourFilter returns f scoped to the layers of ctx, for contour queries.
+func contourFilter(ctx context.Context, f filter) filter {
+	f.Layer = models.LayerScopeFrom(ctx).Contours
+	return f
 }
 
 func (r *ContourRepositoryImpl) getContourQuery(f filter) (string, []any) {
 	params := make([]any, 0)
-	query := "SELECT id, ST_AsGeoJSON(data) AS data FROM contours"
-	keyword := "WHERE"
+	query := "SELECT c.id, c.parent_id, ST_AsGeoJSON(c.data) AS data, c.properties, c.layer_id, c.version, c.updated_at, c.deleted_at FROM contours c"
+
+	conditions, conditionParams := f.conditions("c")
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	params = append(params, conditionParams...)
+
 	if f.ID != 0 {
-		query += fmt.Sprintf(" %s id = ?", keyword)
-		params = append(params, f.ID)
-	} else {
-		query += " ORDER BY id DESC OFFSET ? LIMIT ?"
+		return query, params
+	}
+
+	query += " ORDER BY c.id DESC"
+	if !f.Unbounded {
+		query += " OFFSET ? LIMIT ?"
 		params = append(params, f.Offset, f.Limit)
 	}
 
 	return query, params
 }
 
-func (r *ContourRepositoryImpl) GetContoursIntersectArea(idA, idB uint) ([]models.Contour, error) {
+func (r *ContourRepositoryImpl) GetContoursIntersectArea(ctx context.Context, idA, idB uint) ([]models.Contour, error) {
 	contours := make([]models.Contour, 0)
-	query := "SELECT ST_AsGeoJSON(ST_Intersection(ca.data, cb.data)) AS data FROM contours ca, contours cb WHERE ca.id = ? AND cb.id = ?"
-	err := r.db.Raw(query, idA, idB).Scan(&contours).Error
+	query := "SELECT ST_AsGeoJSON(ST_Intersection(ca.data, cb.data)) AS data FROM contours ca, contours cb " +
+		"WHERE ca.id = ? AND cb.id = ? AND ca.deleted_at IS NULL AND cb.deleted_at IS NULL"
+	layer := models.LayerScopeFrom(ctx).Contours
+	query, params := inLayer(query, []any{idA, idB}, "ca", layer)
+	query, params = inLayer(query, params, "cb", layer)
+
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&contours).Error
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 
 	return contours, nil
 }
+
+// GetContoursTile encodes the contours of a tile as the contours layer of a
+// Mapbox Vector Tile. Polygons are simplified to the tile resolution first,
+// so low zooms stay small, and those collapsing below it are dropped. They
+// are clipped to the latitudes Web Mercator covers before being projected,
+// as those reaching the poles cannot be.
+func (r *ContourRepositoryImpl) GetContoursTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	query := "SELECT ST_AsMVT(t.*, ?, ?, 'geom', 'id') FROM (" +
+		"SELECT c.id, c.properties, ST_AsMVTGeom(ST_SimplifyPreserveTopology(" +
+		"ST_Transform(ST_Intersection(c.data, ST_MakeEnvelope(-180, ?, 180, ?, 4326)), 3857), ?), ST_TileEnvelope(?, ?, ?), ?, ?, true) AS geom " +
+		"FROM contours c WHERE c.deleted_at IS NULL AND ST_Intersects(c.data, ST_Transform(ST_TileEnvelope(?, ?, ?), 4326))"
+	params := []any{
+		ContoursLayer, models.TileExtent,
+		-models.MaxMercatorLat, models.MaxMercatorLat,
+		tile.Resolution(), tile.Z, tile.X, tile.Y, models.TileExtent, models.TileBuffer,
+		tile.Z, tile.X, tile.Y,
+	}
+	query, params = inLayer(query, params, "c", models.LayerScopeFrom(ctx).Contours)
+	query += ") AS t WHERE t.geom IS NOT NULL"
+
+	var data []byte
+	if err := r.db.WithContext(ctx).Raw(query, params...).Row().Scan(&data); err != nil {
+		return nil, dbError(err)
+	}
+
+	return data, nil
+}
+
+// GetContoursPointCount counts the points within every contour intersecting
+// the bounding box, all contours when it is nil, empty contours included.
+// The contours and the points counted may be scoped to different layers.
+func (r *ContourRepositoryImpl) GetContoursPointCount(ctx context.Context, bbox *models.BBox) ([]models.Cell, error) {
+	query := "SELECT c.id AS contour_id, ST_AsGeoJSON(c.data) AS data, COUNT(p.id) AS count " +
+		"FROM contours c LEFT JOIN points p ON p.deleted_at IS NULL AND ST_Within(p.data, c.data)"
+	query, params := inLayer(query, make([]any, 0), "p", models.LayerScopeFrom(ctx).Points)
+
+	conditions, conditionParams := contourFilter(ctx, filter{BBox: bbox}).conditions("c")
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	params = append(params, conditionParams...)
+
+	query += " GROUP BY c.id ORDER BY c.id"
+
+	cells := make([]models.Cell, 0)
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(&cells).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	return cells, nil
+}
+
+// SaveSimplifiedContour stores the simplified variant of a contour, replacing
+// the previous one.
+func (r *ContourRepositoryImpl) SaveSimplifiedContour(ctx context.Context, variant *models.SimplifiedContour) error {
+	return dbError(r.db.WithContext(ctx).Save(variant).Error)
+}
+
+func (r *ContourRepositoryImpl) GetSimplifiedContour(ctx context.Context, contourID uint) (*models.SimplifiedContour, error) {
+	variant := new(models.SimplifiedContour)
+	query := "SELECT s.contour_id, s.tolerance, ST_AsGeoJSON(s.data) AS data FROM simplified_contours s " +
+		"JOIN contours c ON c.id = s.contour_id AND c.deleted_at IS NULL WHERE s.contour_id = ?"
+	query, params := inLayer(query, []any{contourID}, "c", models.LayerScopeFrom(ctx).Contours)
+
+	result := r.db.WithContext(ctx).Raw(query, params...).Scan(variant)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrContourNotFound
+	}
+
+	return variant, nil
+}
+
+// GetContourBuffer returns the buffer of a contour computed on the spheroid
+// as a new, unsaved contour, a negative distance shrinking it. The result may
+// be empty or a MultiPolygon when shrinking. constants.ErrContourNotFound is
+// returned when the contour does not exist.
+func (r *ContourRepositoryImpl) GetContourBuffer(ctx context.Context, id uint, buffer models.Buffer) (*models.Contour, error) {
+	query := "SELECT ST_AsGeoJSON(ST_Buffer(c.data::geography, ?, ?)::geometry) AS data FROM contours c WHERE c.id = ? AND c.deleted_at IS NULL"
+	query, params := inLayer(query, []any{buffer.Distance, buffer.Parameters(), id}, "c", models.LayerScopeFrom(ctx).Contours)
+
+	contour := new(models.Contour)
+	result := r.db.WithContext(ctx).Raw(query, params...).Scan(contour)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrContourNotFound
+	}
+
+	return contour, nil
+}
+
+// GetContourTessellation returns the square or hexagonal cells covering a
+// contour, clipped to it and ordered by column and row. Cells are generated
+// with ST_SquareGrid or ST_HexagonGrid in the equal-area projection, which
+// need PostGIS 3.1; constants.ErrGridUnsupported is returned when they are
+// unavailable. Cells merely touching the contour come back empty.
+func (r *ContourRepositoryImpl) GetContourTessellation(ctx context.Context, id uint, tessellation models.Tessellation, projection models.EqualArea) ([]models.TessellationCell, error) {
+	if r.db.Dialector.Name() != "postgres" {
+		return nil, constants.ErrGridUnsupported
+	}
+
+	gridFunc := "ST_SquareGrid"
+	if tessellation.Type == models.GridHex {
+		gridFunc = "ST_HexagonGrid"
+	}
+
+	clip, params := inLayer("SELECT ST_Transform(src.data, ?::text) AS geom FROM contours src WHERE src.id = ? AND src.deleted_at IS NULL",
+		[]any{projection.PROJ(), id}, "src", models.LayerScopeFrom(ctx).Contours)
+	query := "WITH c AS (" + clip + ") " +
+		"SELECT g.i, g.j, ST_AsGeoJSON(ST_Transform(ST_CollectionExtract(ST_Intersection(g.geom, c.geom), 3), ?::text, 4326)) AS data " +
+		"FROM c, " + gridFunc + "(?, c.geom) AS g WHERE ST_Intersects(g.geom, c.geom) ORDER BY g.i, g.j"
+	params = append(params, projection.PROJ(), tessellation.Size)
+
+	cells := make([]models.TessellationCell, 0)
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(&cells).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == undefinedFunction {
+			return nil, constants.ErrGridUnsupported
+		}
+
+		return nil, dbError(err)
+	}
+
+	return cells, nil
+}
@@ -1,12 +1,15 @@
 package repository
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
+	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/models"
 )
 
@@ -55,7 +58,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_CreateContour() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				err := repo.CreateContour(tt.Contour)
+				err := repo.CreateContour(context.Background(), tt.Contour)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -69,6 +72,58 @@ func (p *ContourRepoTestSuite) TestContourRepository_CreateContour() {
 	tx.Rollback()
 }
 
+func (p *ContourRepoTestSuite) TestContourRepository_CreateContours() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+	p.Suite.T().Run("CreateContours", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			contours []models.Contour
+			wantErr  bool
+		}{
+			{
+				name: "ValidContours",
+				contours: []models.Contour{
+					{Data: models.Geometry{Type: "Polygon", PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}}}},
+					{
+						Data:       models.Geometry{Type: "Polygon", PolygonCoordinates: [][][2]float64{{{20, 20}, {30, 20}, {30, 30}, {20, 20}}}},
+						Properties: models.Properties{"NAME": "North parcel"},
+					},
+				},
+				wantErr: false,
+			},
+			{
+				name: "RollsBackOnInvalidContour",
+				contours: []models.Contour{
+					{Data: models.Geometry{Type: "Polygon", PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}}}},
+					{Data: models.Geometry{Type: "Polygon"}},
+				},
+				wantErr: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := repo.CreateContours(context.Background(), tt.contours, 1)
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+					for _, contour := range tt.contours {
+						assert.NotZero(t, contour.ID)
+
+						stored, err := repo.GetContourByID(context.Background(), contour.ID)
+						assert.NoError(t, err)
+						assert.Equal(t, contour.Properties, stored.Properties)
+					}
+				}
+			})
+		}
+	})
+
+	tx.Rollback()
+}
+
 func (p *ContourRepoTestSuite) TestContourRepository_GetContourByID() {
 	tx := p.db.Begin()
 	repo := NewContourRepository(tx)
@@ -79,7 +134,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContourByID() {
 			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
 		},
 	}
-	err := repo.CreateContour(exampleContour)
+	err := repo.CreateContour(context.Background(), exampleContour)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -104,7 +159,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContourByID() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				actualContour, err := repo.GetContourByID(tt.ID)
+				actualContour, err := repo.GetContourByID(context.Background(), tt.ID)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -128,7 +183,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContours() {
 			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
 		},
 	}
-	err := repo.CreateContour(exampleContour)
+	err := repo.CreateContour(context.Background(), exampleContour)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -159,7 +214,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContours() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				Contours, err := repo.GetContours(tt.offset, tt.limit)
+				Contours, err := repo.GetContours(context.Background(), tt.offset, tt.limit)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -183,7 +238,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_UpdateContour() {
 			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
 		},
 	}
-	err := repo.CreateContour(exampleContour)
+	err := repo.CreateContour(context.Background(), exampleContour)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -219,7 +274,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_UpdateContour() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				err := repo.UpdateContour(tt.Contour)
+				err := repo.UpdateContour(context.Background(), tt.Contour)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -227,7 +282,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_UpdateContour() {
 					assert.NotZero(t, tt.Contour.ID)
 					assert.Equal(t, exampleContour.ID, tt.Contour.ID)
 
-					actualContour, err := repo.GetContourByID(tt.Contour.ID)
+					actualContour, err := repo.GetContourByID(context.Background(), tt.Contour.ID)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -241,6 +296,173 @@ func (p *ContourRepoTestSuite) TestContourRepository_UpdateContour() {
 	tx.Rollback()
 }
 
+func (p *ContourRepoTestSuite) TestContourRepository_Version() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+		},
+	}
+	err := repo.CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+	p.Suite.T().Run("Version", func(t *testing.T) {
+		assert.Equal(t, uint(1), exampleContour.Version)
+
+		update := &models.Contour{ID: exampleContour.ID, Data: exampleContour.Data, Version: 1}
+		assert.NoError(t, repo.UpdateContour(context.Background(), update))
+		assert.Equal(t, uint(2), update.Version)
+
+		stale := &models.Contour{ID: exampleContour.ID, Data: exampleContour.Data, Version: 1}
+		assert.ErrorIs(t, repo.UpdateContour(context.Background(), stale), constants.ErrVersionMismatch)
+		assert.ErrorIs(t, repo.DeleteContour(context.Background(), exampleContour.ID, 1), constants.ErrVersionMismatch)
+
+		missing := &models.Contour{ID: 999999, Data: exampleContour.Data, Version: 1}
+		assert.ErrorIs(t, repo.UpdateContour(context.Background(), missing), constants.ErrContourNotFound)
+
+		actualContour, err := repo.GetContourByID(context.Background(), exampleContour.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), actualContour.Version)
+
+		assert.NoError(t, repo.DeleteContour(context.Background(), exampleContour.ID, 2))
+		assert.ErrorIs(t, repo.DeleteContour(context.Background(), exampleContour.ID, 2), constants.ErrContourNotFound)
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_History() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+	ctx := models.WithActor(context.Background(), "alice")
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+		},
+	}
+	err := repo.CreateContour(ctx, exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+	p.Suite.T().Run("History", func(t *testing.T) {
+		created := time.Now()
+
+		update := &models.Contour{
+			ID: exampleContour.ID,
+			Data: models.Geometry{
+				Type:               "Polygon",
+				PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.9, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+			},
+			Version: 1,
+		}
+		assert.NoError(t, repo.UpdateContour(ctx, update))
+		updated := time.Now()
+
+		history, err := repo.GetContourHistory(ctx, exampleContour.ID)
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+		assert.Equal(t, uint(1), history[0].Version)
+		assert.Equal(t, exampleContour.Data, history[0].Data)
+		assert.Equal(t, models.OperationUpdate, history[0].Operation)
+		assert.Equal(t, "alice", history[0].Actor)
+
+		asOf, err := repo.GetContourAsOf(ctx, exampleContour.ID, created)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), asOf.Version)
+		assert.Equal(t, exampleContour.Data, asOf.Data)
+
+		asOf, err = repo.GetContourAsOf(ctx, exampleContour.ID, updated)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), asOf.Version)
+
+		_, err = repo.GetContourAsOf(ctx, exampleContour.ID, created.Add(-time.Hour))
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+
+		assert.NoError(t, repo.DeleteContour(ctx, exampleContour.ID, 2))
+
+		history, err = repo.GetContourHistory(ctx, exampleContour.ID)
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		assert.Equal(t, models.OperationDelete, history[1].Operation)
+
+		_, err = repo.GetContourAsOf(ctx, exampleContour.ID, time.Now())
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+
+		asOf, err = repo.GetContourAsOf(ctx, exampleContour.ID, updated)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), asOf.Version)
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_Trash() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+		},
+	}
+	err := repo.CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+	p.Suite.T().Run("Trash", func(t *testing.T) {
+		_, err := repo.UndeleteContour(context.Background(), exampleContour.ID)
+		assert.ErrorIs(t, err, constants.ErrContourNotDeleted)
+
+		assert.NoError(t, repo.DeleteContour(context.Background(), exampleContour.ID, 0))
+
+		contours, err := repo.GetContours(context.Background(), 0, 1000)
+		assert.NoError(t, err)
+		for _, contour := range contours {
+			assert.NotEqual(t, exampleContour.ID, contour.ID)
+		}
+
+		deleted, err := repo.GetDeletedContours(context.Background(), 0, 1000)
+		assert.NoError(t, err)
+		assert.Contains(t, ids(deleted), exampleContour.ID)
+
+		restored, err := repo.UndeleteContour(context.Background(), exampleContour.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, uint(2), restored.Version)
+
+		assert.NoError(t, repo.DeleteContour(context.Background(), exampleContour.ID, 2))
+
+		purged, err := repo.PurgeContours(context.Background(), time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Zero(t, purged)
+
+		purged, err = repo.PurgeContours(context.Background(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Positive(t, purged)
+
+		_, err = repo.UndeleteContour(context.Background(), exampleContour.ID)
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+	})
+
+	tx.Rollback()
+}
+
+func ids(contours []models.Contour) []uint {
+	ids := make([]uint, 0, len(contours))
+	for _, contour := range contours {
+		ids = append(ids, contour.ID)
+	}
+
+	return ids
+}
+
 func (p *ContourRepoTestSuite) TestContourRepository_DeleteContour() {
 	tx := p.db.Begin()
 	repo := NewContourRepository(tx)
@@ -251,7 +473,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_DeleteContour() {
 			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
 		},
 	}
-	err := repo.CreateContour(exampleContour)
+	err := repo.CreateContour(context.Background(), exampleContour)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -269,19 +491,19 @@ func (p *ContourRepoTestSuite) TestContourRepository_DeleteContour() {
 			{
 				name:    "ContourNotExists",
 				ID:      999999,
-				wantErr: false,
+				wantErr: true,
 			},
 		}
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				err := repo.DeleteContour(tt.ID)
+				err := repo.DeleteContour(context.Background(), tt.ID, 0)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
 					assert.NoError(t, err)
 
-					actualContour, err := repo.GetContourByID(tt.ID)
+					actualContour, err := repo.GetContourByID(context.Background(), tt.ID)
 					assert.Error(t, err)
 					assert.Nil(t, actualContour)
 				}
@@ -302,7 +524,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContoursIntersectArea()
 			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
 		},
 	}
-	err := repo.CreateContour(exampleContourA)
+	err := repo.CreateContour(context.Background(), exampleContourA)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -313,7 +535,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContoursIntersectArea()
 			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
 		},
 	}
-	err = repo.CreateContour(exampleContourB)
+	err = repo.CreateContour(context.Background(), exampleContourB)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -341,7 +563,7 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContoursIntersectArea()
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				Contours, err := repo.GetContoursIntersectArea(tt.IDA, tt.IDB)
+				Contours, err := repo.GetContoursIntersectArea(context.Background(), tt.IDA, tt.IDB)
 				if !tt.found {
 					assert.NoError(t, err)
 					assert.Zero(t, len(Contours))
@@ -355,3 +577,261 @@ func (p *ContourRepoTestSuite) TestContourRepository_GetContoursIntersectArea()
 
 	tx.Rollback()
 }
+
+func (p *ContourRepoTestSuite) TestContourRepository_StreamContours() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+		},
+	}
+	err := repo.CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("StreamContours", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			bbox  *models.BBox
+			found bool
+		}{
+			{
+				name:  "IntersectsBBox",
+				bbox:  &models.BBox{MinLon: 5, MinLat: 5, MaxLon: 20, MaxLat: 20},
+				found: true,
+			},
+			{
+				name:  "OutsideBBox",
+				bbox:  &models.BBox{MinLon: 40, MinLat: 40, MaxLon: 60, MaxLat: 60},
+				found: false,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ids := make([]uint, 0)
+				err := repo.StreamContours(context.Background(), tt.bbox, func(contour *models.Contour) error {
+					ids = append(ids, contour.ID)
+					return nil
+				})
+				assert.NoError(t, err)
+				if tt.found {
+					assert.Contains(t, ids, exampleContour.ID)
+				} else {
+					assert.NotContains(t, ids, exampleContour.ID)
+				}
+			})
+		}
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_GetContoursTile() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+		},
+		Properties: models.Properties{"name": "Field"},
+	}
+	err := repo.CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetContoursTile", func(t *testing.T) {
+		data, err := repo.GetContoursTile(context.Background(), models.Tile{Z: 1, X: 1, Y: 0})
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), ContoursLayer)
+
+		_, err = repo.GetContoursTile(context.Background(), models.Tile{Z: 1, X: 0, Y: 1})
+		assert.NoError(t, err)
+	})
+
+	p.Suite.T().Run("GetContoursTile reaching the pole", func(t *testing.T) {
+		polar := &models.Contour{
+			Data: models.Geometry{
+				Type:               "Polygon",
+				PolygonCoordinates: [][][2]float64{{{20, 80}, {30, 80}, {30, 90}, {20, 90}, {20, 80}}},
+			},
+		}
+		assert.NoError(t, repo.CreateContour(context.Background(), polar))
+
+		data, err := repo.GetContoursTile(context.Background(), models.Tile{Z: 1, X: 1, Y: 0})
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), ContoursLayer)
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_GetContoursPointCount() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+		},
+	}
+	err := repo.CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	for _, coordinates := range [][2]float64{{5, 5}, {6, 6}, {50, 50}} {
+		point := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: coordinates}}
+		if err := NewPointRepository(tx).CreatePoint(context.Background(), point); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	p.Suite.T().Run("GetContoursPointCount", func(t *testing.T) {
+		cells, err := repo.GetContoursPointCount(context.Background(), &models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6})
+		assert.NoError(t, err)
+		assert.Len(t, cells, 1)
+		assert.Equal(t, exampleContour.ID, cells[0].ContourID)
+		assert.Equal(t, 2, cells[0].Count)
+
+		cells, err = repo.GetContoursPointCount(context.Background(), &models.BBox{MinLon: 40, MinLat: 40, MaxLon: 60, MaxLat: 60})
+		assert.NoError(t, err)
+		assert.Empty(t, cells)
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_SimplifiedContour() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	contour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.1}, {125.75, 10.15}, {125.7, 10.2}, {125.6, 10.2}, {125.6, 10.1}}},
+		},
+	}
+	if err := repo.CreateContour(context.Background(), contour); err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("SimplifiedContour", func(t *testing.T) {
+		_, err := repo.GetSimplifiedContour(context.Background(), contour.ID)
+		assert.ErrorIs(t, err, constants.ErrNotFound)
+
+		variant := &models.SimplifiedContour{
+			ContourID: contour.ID,
+			Tolerance: 10000,
+			Data: models.Geometry{
+				Type:               "Polygon",
+				PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.1}, {125.7, 10.2}, {125.6, 10.2}, {125.6, 10.1}}},
+			},
+		}
+		assert.NoError(t, repo.SaveSimplifiedContour(context.Background(), variant))
+
+		variant.Tolerance = 20000
+		assert.NoError(t, repo.SaveSimplifiedContour(context.Background(), variant))
+
+		actual, err := repo.GetSimplifiedContour(context.Background(), contour.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, variant, actual)
+
+		assert.NoError(t, repo.DeleteContour(context.Background(), contour.ID, 0))
+		_, err = repo.GetSimplifiedContour(context.Background(), contour.ID)
+		assert.ErrorIs(t, err, constants.ErrNotFound)
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_GetContourBuffer() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	contour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {0.1, 0}, {0.1, 0.1}, {0, 0.1}, {0, 0}}},
+		},
+	}
+	if err := repo.CreateContour(context.Background(), contour); err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetContourBuffer", func(t *testing.T) {
+		grown, err := repo.GetContourBuffer(context.Background(), contour.ID, models.Buffer{Distance: 1000, Segments: 8, EndCap: models.EndCapRound})
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.109, grown.Data.Bounds().MaxLat, 1e-3)
+
+		shrunk, err := repo.GetContourBuffer(context.Background(), contour.ID, models.Buffer{Distance: -1000, Segments: 8, EndCap: models.EndCapRound})
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.091, shrunk.Data.Bounds().MaxLat, 1e-3)
+
+		empty, err := repo.GetContourBuffer(context.Background(), contour.ID, models.Buffer{Distance: -10000, Segments: 8, EndCap: models.EndCapRound})
+		assert.NoError(t, err)
+		assert.Empty(t, empty.Data.PolygonCoordinates)
+
+		_, err = repo.GetContourBuffer(context.Background(), contour.ID+1000, models.Buffer{Distance: 1000, Segments: 8, EndCap: models.EndCapRound})
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+	})
+
+	tx.Rollback()
+}
+
+func (p *ContourRepoTestSuite) TestContourRepository_GetContourTessellation() {
+	tx := p.db.Begin()
+	repo := NewContourRepository(tx)
+
+	contour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {0.02, 0}, {0.02, 0.02}, {0, 0.02}, {0, 0}}},
+		},
+	}
+	if err := repo.CreateContour(context.Background(), contour); err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetContourTessellation", func(t *testing.T) {
+		projection := models.EqualArea{Lon: 0.01, Lat: 0.01}
+
+		cells, err := repo.GetContourTessellation(context.Background(), contour.ID, models.Tessellation{Type: models.GridSquare, Size: 1000}, projection)
+		assert.NoError(t, err)
+		assert.Len(t, cells, 16)
+		assert.Equal(t, -2, cells[0].I)
+		assert.Equal(t, -2, cells[0].J)
+
+		cells, err = repo.GetContourTessellation(context.Background(), contour.ID, models.Tessellation{Type: models.GridHex, Size: 500}, projection)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, cells)
+
+		cells, err = repo.GetContourTessellation(context.Background(), contour.ID+1000, models.Tessellation{Type: models.GridSquare, Size: 1000}, projection)
+		assert.NoError(t, err)
+		assert.Empty(t, cells)
+	})
+
+	p.Suite.T().Run("PurgeParent", func(t *testing.T) {
+		cell := &models.Contour{ParentID: &contour.ID, Data: contour.Data}
+		assert.NoError(t, repo.CreateContour(context.Background(), cell))
+		assert.NoError(t, repo.DeleteContour(context.Background(), contour.ID, 0))
+
+		purged, err := repo.PurgeContours(context.Background(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Positive(t, purged)
+
+		actual, err := repo.GetContourByID(context.Background(), cell.ID)
+		assert.NoError(t, err)
+		assert.Nil(t, actual.ParentID)
+	})
+
+	tx.Rollback()
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// duplicatePointsLock is the advisory lock serializing the duplicate checks
+// of point creations and the merges of duplicates.
+const duplicatePointsLock = 7245113
+
+// ErrNotInTransaction is returned by the queries whose locks only last for a
+// transaction when they are run outside of one.
+var ErrNotInTransaction = errors.New("not in a transaction")
+
+// GetDuplicatePoint returns the oldest point of the layer of point within
+// tolerance metres of it, identical to it when tolerance is zero, and
+// constants.ErrPointNotFound when there is none. It must run in the
+// transaction creating point, as opened by TxManager, and fails with
+// ErrNotInTransaction otherwise: concurrent checks wait for that transaction
+// to end, so none of them misses a point created after another check.
+func (r *PointRepositoryImpl) GetDuplicatePoint(ctx context.Context, point *models.Point, tolerance float64) (*models.Point, error) {
+	db := r.db.WithContext(ctx)
+	if err := lockDuplicatePoints(db); err != nil {
+		return nil, err
+	}
+
+	query := "SELECT p.id, ST_AsGeoJSON(p.data) AS data, p.properties, p.geohash, p.layer_id, p.version FROM points p " +
+		"WHERE p.deleted_at IS NULL AND p.layer_id IS NOT DISTINCT FROM ? " +
+		"AND ST_DWithin(p.data::geography, ST_GeomFromText(?, 4326)::geography, ?) ORDER BY p.id LIMIT 1"
+
+	existing := new(models.Point)
+	if err := db.Raw(query, point.LayerID, point.Data.WKT(), tolerance).Scan(existing).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	if existing.ID == 0 {
+		return nil, constants.ErrPointNotFound
+	}
+
+	return existing, nil
+}
+
+// GetDuplicatePointPairs returns the pairs of points of the same layer within
+// tolerance metres of one another, identical when tolerance is zero, the
+// lower id first. Like GetDuplicatePoint, it must run in a transaction, the
+// one merging the pairs: duplicate checks wait for it to end, and so do
+// writes to points, for no point to be created or changed while it is merged.
+func (r *PointRepositoryImpl) GetDuplicatePointPairs(ctx context.Context, tolerance float64) ([][2]uint, error) {
+	db := r.db.WithContext(ctx)
+	if err := lockDuplicatePoints(db); err != nil {
+		return nil, err
+	}
+
+	if err := db.Exec("LOCK TABLE points IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	query := "SELECT a.id AS a, b.id AS b FROM points a JOIN points b ON a.id < b.id AND a.layer_id IS NOT DISTINCT FROM b.layer_id " +
+		"AND ST_DWithin(a.data::geography, b.data::geography, ?) AND b.deleted_at IS NULL " +
+		"WHERE a.deleted_at IS NULL"
+	query, params := inLayer(query, []any{tolerance}, "a", models.LayerScopeFrom(ctx).Points)
+
+	var rows []struct {
+		A uint
+		B uint
+	}
+	if err := db.Raw(query+" ORDER BY a.id, b.id", params...).Scan(&rows).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	pairs := make([][2]uint, len(rows))
+	for i, row := range rows {
+		pairs[i] = [2]uint{row.A, row.B}
+	}
+
+	return pairs, nil
+}
+
+// lockDuplicatePoints takes duplicatePointsLock until the transaction of db
+// ends, failing with ErrNotInTransaction outside of one.
+func lockDuplicatePoints(db *gorm.DB) error {
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); !ok {
+		return dbError(ErrNotInTransaction)
+	}
+
+	return dbError(db.Exec("SELECT pg_advisory_xact_lock(?)", duplicatePointsLock).Error)
+}
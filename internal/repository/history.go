@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// recordContourHistory records the version of a contour that operation is
+// about to replace or remove at the given time, on behalf of actor. The row is
+// locked for the rest of the transaction, so the write sees the version that
+// was recorded. Nothing is recorded when the contour does not exist or is not
+// at version, the write then failing the same way.
+func recordContourHistory(tx *gorm.DB, id, version uint, operation, actor string, at time.Time) error {
+	query := "INSERT INTO contour_history (contour_id, version, data, properties, operation, actor, valid_from, valid_to) " +
+		"SELECT c.id, c.version, c.data, c.properties, ?, ?, c.updated_at, ? FROM contours c WHERE c.id = ? AND c.deleted_at IS NULL"
+	params := []any{operation, actor, at, id}
+	if version != 0 {
+		query += " AND c.version = ?"
+		params = append(params, version)
+	}
+
+	return dbError(tx.Exec(query+" FOR UPDATE", params...).Error)
+}
+
+// GetContourHistory returns the recorded versions of a contour, oldest first.
+// They outlive the contour itself, though not in a layer scope, which only
+// sees the history of the contours in the layer.
+func (r *ContourRepositoryImpl) GetContourHistory(ctx context.Context, id uint) ([]models.ContourHistory, error) {
+	history := make([]models.ContourHistory, 0)
+	query, params := historyInLayer(ctx, "SELECT h.id, h.contour_id, h.version, ST_AsGeoJSON(h.data) AS data, h.properties, h.operation, h.actor, h.valid_from, h.valid_to "+
+		"FROM contour_history h WHERE h.contour_id = ?", []any{id})
+
+	if err := r.db.WithContext(ctx).Raw(query+" ORDER BY h.valid_to, h.id", params...).Scan(&history).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	return history, nil
+}
+
+// GetContourAsOf returns the version of a contour that was current at the
+// given time. constants.ErrContourNotFound is returned when it did not exist
+// then, as far as its history tells, or is outside the layer scope of ctx.
+func (r *ContourRepositoryImpl) GetContourAsOf(ctx context.Context, id uint, at time.Time) (*models.Contour, error) {
+	query, params := historyInLayer(ctx, "SELECT h.contour_id AS id, ST_AsGeoJSON(h.data) AS data, h.properties, h.version, h.valid_from AS updated_at "+
+		"FROM contour_history h WHERE h.contour_id = ? AND (h.valid_from IS NULL OR h.valid_from <= ?) AND h.valid_to > ?", []any{id, at, at})
+
+	contour := new(models.Contour)
+	result := r.db.WithContext(ctx).Raw(query+" ORDER BY h.valid_to LIMIT 1", params...).Scan(contour)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		return contour, nil
+	}
+
+	contour, err := r.GetContourByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if contour.UpdatedAt != nil && contour.UpdatedAt.After(at) {
+		return nil, constants.ErrContourNotFound
+	}
+
+	return contour, nil
+}
+
+// historyInLayer restricts a query of the contour history h to the contours
+// in the layer the contours of ctx are scoped to, if any. The history does
+// not record layers, so the current layer of the contour is the one checked.
+func historyInLayer(ctx context.Context, query string, params []any) (string, []any) {
+	layer := models.LayerScopeFrom(ctx).Contours
+	if layer == nil {
+		return query, params
+	}
+
+	query, params = inLayer(query+" AND EXISTS (SELECT 1 FROM contours c WHERE c.id = h.contour_id", params, "c", layer)
+	return query + ")", params
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepository interface {
+	ClaimIdempotencyKey(ctx context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error)
+	SaveIdempotentResponse(ctx context.Context, request *models.IdempotentRequest) error
+	ReleaseIdempotencyKey(ctx context.Context, request *models.IdempotentRequest) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type IdempotencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{db}
+}
+
+// ClaimIdempotencyKey records a request as in progress under its scope and
+// key, taking over a key that has expired or whose request is still in
+// progress past its claim, as when its process died. The request holding the
+// key is returned when it is taken already, nil when the claim succeeded. The
+// claim is a single statement, so only one of concurrent requests with the
+// same key wins it.
+func (r *IdempotencyRepositoryImpl) ClaimIdempotencyKey(ctx context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+	query := "INSERT INTO idempotency_keys (scope, key, request_hash, status_code, content_type, location, etag, created_at, claim_expires_at, expires_at) " +
+		"VALUES (?, ?, ?, 0, '', '', '', ?, ?, ?) " +
+		"ON CONFLICT (scope, key) DO UPDATE SET request_hash = EXCLUDED.request_hash, status_code = 0, content_type = '', location = '', etag = '', body = NULL, " +
+		"created_at = EXCLUDED.created_at, claim_expires_at = EXCLUDED.claim_expires_at, expires_at = EXCLUDED.expires_at " +
+		"WHERE idempotency_keys.expires_at <= EXCLUDED.created_at " +
+		"OR (idempotency_keys.status_code = 0 AND idempotency_keys.claim_expires_at <= EXCLUDED.created_at)"
+
+	result := r.db.WithContext(ctx).Exec(query, request.Scope, request.Key, request.RequestHash, request.CreatedAt, request.ClaimExpiresAt, request.ExpiresAt)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	existing := new(models.IdempotentRequest)
+	if err := r.db.WithContext(ctx).Raw("SELECT * FROM idempotency_keys WHERE scope = ? AND key = ?", request.Scope, request.Key).Scan(existing).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	return existing, nil
+}
+
+// SaveIdempotentResponse stores the response of a claimed request.
+func (r *IdempotencyRepositoryImpl) SaveIdempotentResponse(ctx context.Context, request *models.IdempotentRequest) error {
+	query := "UPDATE idempotency_keys SET status_code = ?, content_type = ?, location = ?, etag = ?, body = ? WHERE scope = ? AND key = ? AND request_hash = ?"
+	return dbError(r.db.WithContext(ctx).Exec(query, request.StatusCode, request.ContentType, request.Location, request.ETag, request.Body,
+		request.Scope, request.Key, request.RequestHash).Error)
+}
+
+// ReleaseIdempotencyKey gives up the claim of a request still in progress, so
+// it can be retried.
+func (r *IdempotencyRepositoryImpl) ReleaseIdempotencyKey(ctx context.Context, request *models.IdempotentRequest) error {
+	query := "DELETE FROM idempotency_keys WHERE scope = ? AND key = ? AND request_hash = ? AND status_code = 0"
+	return dbError(r.db.WithContext(ctx).Exec(query, request.Scope, request.Key, request.RequestHash).Error)
+}
+
+// PurgeIdempotencyKeys deletes the keys that expired before the given time and
+// returns how many there were.
+func (r *IdempotencyRepositoryImpl) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec("DELETE FROM idempotency_keys WHERE expires_at < ?", before)
+	return result.RowsAffected, dbError(result.Error)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+type IdempotencyRepoTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestIdempotencyRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyRepoTestSuite))
+}
+
+func (p *IdempotencyRepoTestSuite) SetupSuite() {
+	p.db = setupTestDB(p.Suite.T())
+}
+
+func (p *IdempotencyRepoTestSuite) TestIdempotencyRepository_Claim() {
+	tx := p.db.Begin()
+	repo := NewIdempotencyRepository(tx)
+	now := time.Now()
+
+	p.Suite.T().Run("Claim", func(t *testing.T) {
+		request := &models.IdempotentRequest{Scope: "actor:alice", Key: "claim", RequestHash: "hash", CreatedAt: now, ClaimExpiresAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+
+		existing, err := repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.False(t, existing.Done())
+
+		request.StatusCode, request.ContentType, request.Body = 201, "application/json", []byte(`{"id":1}`)
+		request.Location, request.ETag = "/points/1", `"1"`
+		assert.NoError(t, repo.SaveIdempotentResponse(context.Background(), request))
+
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, existing.StatusCode)
+		assert.Equal(t, "hash", existing.RequestHash)
+		assert.Equal(t, "/points/1", existing.Location)
+		assert.Equal(t, `"1"`, existing.ETag)
+		assert.Equal(t, `{"id":1}`, string(existing.Body))
+
+		// A done request keeps its key.
+		assert.NoError(t, repo.ReleaseIdempotencyKey(context.Background(), request))
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.NotNil(t, existing)
+
+		later := &models.IdempotentRequest{Scope: "actor:alice", Key: "claim", RequestHash: "other", CreatedAt: now.Add(2 * time.Hour), ClaimExpiresAt: now.Add(2*time.Hour + time.Minute), ExpiresAt: now.Add(3 * time.Hour)}
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), later)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+	})
+
+	p.Suite.T().Run("Release", func(t *testing.T) {
+		request := &models.IdempotentRequest{Scope: "actor:alice", Key: "release", RequestHash: "hash", CreatedAt: now, ClaimExpiresAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+
+		existing, err := repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+
+		assert.NoError(t, repo.ReleaseIdempotencyKey(context.Background(), request))
+
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+	})
+
+	p.Suite.T().Run("ClaimLapsed", func(t *testing.T) {
+		request := &models.IdempotentRequest{Scope: "actor:alice", Key: "lapsed", RequestHash: "hash", CreatedAt: now, ClaimExpiresAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+		existing, err := repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+
+		retry := *request
+		retry.CreatedAt, retry.ClaimExpiresAt = now.Add(30*time.Second), now.Add(90*time.Second)
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), &retry)
+		assert.NoError(t, err)
+		assert.False(t, existing.Done())
+
+		retry.CreatedAt, retry.ClaimExpiresAt = now.Add(2*time.Minute), now.Add(3*time.Minute)
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), &retry)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+	})
+
+	p.Suite.T().Run("Scoped", func(t *testing.T) {
+		request := &models.IdempotentRequest{Scope: "actor:alice", Key: "scoped", RequestHash: "hash", CreatedAt: now, ClaimExpiresAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+		existing, err := repo.ClaimIdempotencyKey(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+
+		other := &models.IdempotentRequest{Scope: "actor:bob", Key: "scoped", RequestHash: "other", CreatedAt: now, ClaimExpiresAt: now.Add(time.Minute), ExpiresAt: now.Add(time.Hour)}
+		existing, err = repo.ClaimIdempotencyKey(context.Background(), other)
+		assert.NoError(t, err)
+		assert.Nil(t, existing)
+	})
+
+	p.Suite.T().Run("Purge", func(t *testing.T) {
+		purged, err := repo.PurgeIdempotencyKeys(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Zero(t, purged)
+
+		purged, err = repo.PurgeIdempotencyKeys(context.Background(), now.Add(4*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), purged)
+	})
+
+	tx.Rollback()
+}
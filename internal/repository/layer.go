@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"gorm.io/gorm"
+)
+
+type LayerRepository interface {
+	CreateLayer(ctx context.Context, layer *models.Layer) error
+	GetLayers(ctx context.Context, offset, limit int) ([]models.Layer, error)
+	GetLayerByID(ctx context.Context, id uint) (*models.Layer, error)
+	GetLayerByName(ctx context.Context, name string) (*models.Layer, error)
+	UpdateLayer(ctx context.Context, layer *models.Layer) error
+	DeleteLayer(ctx context.Context, id uint) error
+}
+
+const (
+	// uniqueViolation is the PostgreSQL error code of a write breaking a
+	// unique constraint.
+	uniqueViolation = "23505"
+
+	// foreignKeyViolation is the PostgreSQL error code of a write referencing
+	// a row that does not exist, a layer being the only such reference.
+	foreignKeyViolation = "23503"
+)
+
+type LayerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLayerRepository(db *gorm.DB) LayerRepository {
+	return &LayerRepositoryImpl{db}
+}
+
+// CreateLayer creates a layer. constants.ErrLayerExists is returned when its
+// name is taken.
+func (r *LayerRepositoryImpl) CreateLayer(ctx context.Context, layer *models.Layer) error {
+	return layerError(r.db.WithContext(ctx).Create(layer).Error)
+}
+
+func (r *LayerRepositoryImpl) GetLayers(ctx context.Context, offset, limit int) ([]models.Layer, error) {
+	layers := make([]models.Layer, 0)
+	err := r.db.WithContext(ctx).Order("name").Offset(offset).Limit(limit).Find(&layers).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	return layers, nil
+}
+
+func (r *LayerRepositoryImpl) GetLayerByID(ctx context.Context, id uint) (*models.Layer, error) {
+	return r.getLayer(ctx, "id = ?", id)
+}
+
+func (r *LayerRepositoryImpl) GetLayerByName(ctx context.Context, name string) (*models.Layer, error) {
+	return r.getLayer(ctx, "name = ?", name)
+}
+
+func (r *LayerRepositoryImpl) getLayer(ctx context.Context, condition string, param any) (*models.Layer, error) {
+	layer := new(models.Layer)
+	result := r.db.WithContext(ctx).Where(condition, param).Limit(1).Find(layer)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrLayerNotFound
+	}
+
+	return layer, nil
+}
+
+// UpdateLayer renames a layer and replaces its description.
+// constants.ErrLayerNotFound is returned when it does not exist and
+// constants.ErrLayerExists when the new name is taken.
+func (r *LayerRepositoryImpl) UpdateLayer(ctx context.Context, layer *models.Layer) error {
+	result := r.db.WithContext(ctx).Model(layer).Select("name", "description", "updated_at").Updates(layer)
+	if result.Error != nil {
+		return layerError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return constants.ErrLayerNotFound
+	}
+
+	updated, err := r.GetLayerByID(ctx, layer.ID)
+	if err != nil {
+		return err
+	}
+
+	*layer = *updated
+	return nil
+}
+
+// DeleteLayer deletes a layer, which must not have any live point or contour
+// left, constants.ErrLayerNotEmpty being returned otherwise. Those in the
+// trash no longer belong to any layer. constants.ErrLayerNotFound is returned
+// when it does not exist. The layer is locked first, so no point or contour
+// can be written into it meanwhile.
+func (r *LayerRepositoryImpl) DeleteLayer(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var layer models.Layer
+		result := tx.Raw("SELECT id FROM layers WHERE id = ? FOR UPDATE", id).Scan(&layer)
+		if result.Error != nil {
+			return dbError(result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return constants.ErrLayerNotFound
+		}
+
+		var members int64
+		query := "SELECT (SELECT COUNT(*) FROM points WHERE layer_id = ? AND deleted_at IS NULL) + " +
+			"(SELECT COUNT(*) FROM contours WHERE layer_id = ? AND deleted_at IS NULL)"
+		if err := tx.Raw(query, id, id).Scan(&members).Error; err != nil {
+			return dbError(err)
+		}
+
+		if members > 0 {
+			return constants.ErrLayerNotEmpty.WithDetails(map[string]any{"count": members})
+		}
+
+		return dbError(tx.Exec("DELETE FROM layers WHERE id = ?", id).Error)
+	})
+}
+
+// layerError reports a write of a layer taking the name of another one as
+// constants.ErrLayerExists.
+func layerError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return constants.ErrLayerExists.At("name")
+	}
+
+	return dbError(err)
+}
+
+// inLayer appends to query the condition keeping the rows of the table
+// aliased as alias in layer, nothing when it is nil.
+func inLayer(query string, params []any, alias string, layer *uint) (string, []any) {
+	if layer == nil {
+		return query, params
+	}
+
+	return query + " AND " + alias + ".layer_id = ?", append(params, *layer)
+}
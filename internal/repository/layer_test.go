@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+type LayerRepoTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestLayerRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(LayerRepoTestSuite))
+}
+
+func (p *LayerRepoTestSuite) SetupSuite() {
+	p.db = setupTestDB(p.Suite.T())
+}
+
+func (p *LayerRepoTestSuite) TestLayerRepository_CRUD() {
+	tx := p.db.Begin()
+	repo := NewLayerRepository(tx)
+
+	layer := &models.Layer{Name: "crud-stores", Description: "Store locations"}
+	p.Suite.T().Run("CreateLayer", func(t *testing.T) {
+		assert.NoError(t, repo.CreateLayer(context.Background(), layer))
+		assert.NotZero(t, layer.ID)
+
+		// Failed writes run in savepoints, not to abort the transaction.
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			return NewLayerRepository(tx).CreateLayer(context.Background(), &models.Layer{Name: "crud-stores"})
+		})
+		assert.ErrorIs(t, err, constants.ErrLayerExists)
+	})
+
+	p.Suite.T().Run("GetLayer", func(t *testing.T) {
+		found, err := repo.GetLayerByName(context.Background(), "crud-stores")
+		assert.NoError(t, err)
+		assert.Equal(t, layer.ID, found.ID)
+
+		_, err = repo.GetLayerByID(context.Background(), layer.ID+1000)
+		assert.ErrorIs(t, err, constants.ErrLayerNotFound)
+	})
+
+	p.Suite.T().Run("UpdateLayer", func(t *testing.T) {
+		updated := &models.Layer{ID: layer.ID, Name: "crud-shops"}
+		assert.NoError(t, repo.UpdateLayer(context.Background(), updated))
+		assert.Equal(t, "crud-shops", updated.Name)
+		assert.Empty(t, updated.Description)
+		assert.Equal(t, layer.CreatedAt.Unix(), updated.CreatedAt.Unix())
+
+		err := repo.UpdateLayer(context.Background(), &models.Layer{ID: layer.ID + 1000, Name: "crud-missing"})
+		assert.ErrorIs(t, err, constants.ErrLayerNotFound)
+	})
+
+	p.Suite.T().Run("DeleteLayer", func(t *testing.T) {
+		points := NewPointRepository(tx)
+		point := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{30, 10}}, LayerID: &layer.ID}
+		assert.NoError(t, points.CreatePoint(context.Background(), point))
+
+		assert.ErrorIs(t, repo.DeleteLayer(context.Background(), layer.ID), constants.ErrLayerNotEmpty)
+
+		assert.NoError(t, points.DeletePoint(context.Background(), point.ID, 0))
+		assert.NoError(t, repo.DeleteLayer(context.Background(), layer.ID))
+		assert.ErrorIs(t, repo.DeleteLayer(context.Background(), layer.ID), constants.ErrLayerNotFound)
+
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			return NewPointRepository(tx).CreatePoint(context.Background(), &models.Point{Data: point.Data, LayerID: &layer.ID})
+		})
+		assert.ErrorIs(t, err, constants.ErrUnknownLayer)
+	})
+
+	tx.Rollback()
+}
+
+func (p *LayerRepoTestSuite) TestLayerRepository_Scope() {
+	tx := p.db.Begin()
+	layers := NewLayerRepository(tx)
+	points := NewPointRepository(tx)
+	contours := NewContourRepository(tx)
+
+	stores, zones := &models.Layer{Name: "scope-stores"}, &models.Layer{Name: "scope-zones"}
+	for _, layer := range []*models.Layer{stores, zones} {
+		if err := layers.CreateLayer(context.Background(), layer); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	zone := &models.Contour{
+		Data:    models.Geometry{Type: "Polygon", PolygonCoordinates: [][][2]float64{{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}}},
+		LayerID: &zones.ID,
+	}
+	if err := contours.CreateContour(context.Background(), zone); err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	store := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{1, 1}}, LayerID: &stores.ID}
+	other := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{2, 2}}}
+	for _, point := range []*models.Point{store, other} {
+		if err := points.CreatePoint(context.Background(), point); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	inStores := models.WithLayerScope(context.Background(), models.LayerScope{Points: &stores.ID, Contours: &stores.ID})
+	crossLayer := models.WithLayerScope(context.Background(), models.LayerScope{Points: &stores.ID, Contours: &zones.ID})
+
+	p.Suite.T().Run("GetPoints", func(t *testing.T) {
+		result, err := points.GetPoints(inStores, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{store.ID}, pointIDs(result))
+		assert.Equal(t, &stores.ID, result[0].LayerID)
+
+		_, err = points.GetPointByID(inStores, other.ID)
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+	})
+
+	p.Suite.T().Run("GetContours", func(t *testing.T) {
+		result, err := contours.GetContours(inStores, 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+
+		_, err = contours.GetContourByID(inStores, zone.ID)
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+	})
+
+	p.Suite.T().Run("GetPointsByContourID", func(t *testing.T) {
+		result, err := points.GetPointsByContourID(context.Background(), zone.ID)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uint{store.ID, other.ID}, pointIDs(result))
+
+		result, err = points.GetPointsByContourID(crossLayer, zone.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{store.ID}, pointIDs(result))
+
+		result, err = points.GetPointsByContourID(inStores, zone.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	p.Suite.T().Run("GetContoursPointCount", func(t *testing.T) {
+		cells, err := contours.GetContoursPointCount(crossLayer, nil)
+		assert.NoError(t, err)
+		assert.Len(t, cells, 1)
+		assert.Equal(t, zone.ID, cells[0].ContourID)
+		assert.Equal(t, 1, cells[0].Count)
+	})
+
+	p.Suite.T().Run("History", func(t *testing.T) {
+		update := &models.Contour{ID: zone.ID, Data: zone.Data, LayerID: &zones.ID}
+		assert.NoError(t, contours.UpdateContour(context.Background(), update))
+
+		history, err := contours.GetContourHistory(crossLayer, zone.ID)
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+
+		history, err = contours.GetContourHistory(inStores, zone.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, history)
+
+		_, err = contours.GetContourAsOf(inStores, zone.ID, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+	})
+
+	p.Suite.T().Run("CrossLayerWrites", func(t *testing.T) {
+		assert.ErrorIs(t, points.UpdatePoint(inStores, &models.Point{ID: other.ID, Data: other.Data}), constants.ErrPointNotFound)
+		assert.ErrorIs(t, points.DeletePoint(inStores, other.ID, 0), constants.ErrPointNotFound)
+		assert.ErrorIs(t, contours.UpdateContour(inStores, &models.Contour{ID: zone.ID, Data: zone.Data, LayerID: &stores.ID}), constants.ErrContourNotFound)
+		assert.ErrorIs(t, contours.DeleteContour(inStores, zone.ID, 0), constants.ErrContourNotFound)
+
+		_, err := points.GetPointByID(context.Background(), other.ID)
+		assert.NoError(t, err)
+		current, err := contours.GetContourByID(context.Background(), zone.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, &zones.ID, current.LayerID)
+
+		// Updates leave the point in its layer.
+		update := &models.Point{ID: store.ID, Data: store.Data}
+		assert.NoError(t, points.UpdatePoint(inStores, update))
+		assert.Equal(t, &stores.ID, update.LayerID)
+		assert.NoError(t, contours.DeleteContour(crossLayer, zone.ID, 0))
+	})
+
+	tx.Rollback()
+}
+
+func pointIDs(points []models.Point) []uint {
+	ids := make([]uint, len(points))
+	for i, point := range points {
+		ids[i] = point.ID
+	}
+
+	return ids
+}
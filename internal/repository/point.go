@@ -1,19 +1,65 @@
 package repository
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/models"
 	"gorm.io/gorm"
 )
 
 type PointRepository interface {
-	CreatePoint(point *models.Point) error
-	GetPointByID(id uint) (*models.Point, error)
-	GetPointsByContourID(contourID uint) ([]models.Point, error)
-	GetPoints(offset, limit int) ([]models.Point, error)
-	UpdatePoint(point *models.Point) error
-	DeletePoint(id uint) error
+	CreatePoint(ctx context.Context, point *models.Point) error
+	CreatePoints(ctx context.Context, points []models.Point, batchSize int) error
+	GetPointByID(ctx context.Context, id uint) (*models.Point, error)
+	GetPointsByContourID(ctx context.Context, contourID uint) ([]models.Point, error)
+	GetPoints(ctx context.Context, offset, limit int) ([]models.Point, error)
+	GetPointsByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Point, error)
+	GetPointsByGeohash(ctx context.Context, prefix string, offset, limit int) ([]models.Point, error)
+	StreamPoints(ctx context.Context, bbox *models.BBox, contourID uint, fn func(*models.Point) error) error
+	GetPointsTile(ctx context.Context, tile models.Tile) ([]byte, error)
+	GetPointsGrid(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error)
+	GetPointContourDistance(ctx context.Context, pointID, contourID uint) (*models.Distance, error)
+	GetPointBuffer(ctx context.Context, id uint, buffer models.Buffer) (*models.Contour, error)
+	GetPointsHull(ctx context.Context, hull models.Hull, input models.HullInput) (*models.Contour, error)
+	GetPointsByIDs(ctx context.Context, ids []uint) ([]models.Point, error)
+	GetVoronoiCells(ctx context.Context, input models.VoronoiInput) ([]models.VoronoiCell, error)
+	GetDelaunayTriangles(ctx context.Context, input models.VoronoiInput) ([]models.Geometry, error)
+	UpdatePoint(ctx context.Context, point *models.Point) error
+	DeletePoint(ctx context.Context, id, version uint) error
+	PurgePoints(ctx context.Context, before time.Time) (int64, error)
+	GetDuplicatePoint(ctx context.Context, point *models.Point, tolerance float64) (*models.Point, error)
+	GetDuplicatePointPairs(ctx context.Context, tolerance float64) ([][2]uint, error)
+}
+
+const (
+	// PointsLayer is the name of the vector tile layer holding points.
+	PointsLayer = "points"
+
+	// undefinedFunction is the PostgreSQL error code of a call to a function
+	// that does not exist.
+	undefinedFunction = "42883"
+)
+
+// dbError reports a failed query as constants.ErrInternal, keeping the cause
+// reachable for context errors. Domain errors, such as those returned by the
+// callbacks of streams, are passed through. Writes into a layer that does not
+// exist are reported as constants.ErrUnknownLayer.
+func dbError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return constants.ErrUnknownLayer.At("layer_id")
+	}
+
+	return constants.Wrap(err, constants.ErrInternal)
 }
 
 type PointRepositoryImpl struct {
@@ -21,77 +67,469 @@ type PointRepositoryImpl struct {
 }
 
 type filter struct {
-	Offset int
-	Limit  int
-	ID     uint
+	Offset    int
+	Limit     int
+	ID        uint
+	IDs       []uint
+	ContourID uint
+	BBox      *models.BBox
+	// Geohash is a geohash prefix, it only applies to points.
+	Geohash string
+	// Unbounded drops OFFSET and LIMIT, it is used by the streaming exports.
+	Unbounded bool
+	// Trashed selects the soft deleted rows instead of the live ones.
+	Trashed bool
+	// Layer keeps the rows of a layer, and ContourLayer the contour of
+	// ContourID when it is in a layer.
+	Layer        *uint
+	ContourLayer *uint
+}
+
+// pointFilter returns f scoped to the layers of ctx, for point queries.
+func pointFilter(ctx context.Context, f filter) filter {
+	scope := models.LayerScopeFrom(ctx)
+	f.Layer, f.ContourLayer = scope.Points, scope.Contours
+	return f
 }
 
 func NewPointRepository(db *gorm.DB) PointRepository {
 	return &PointRepositoryImpl{db}
 }
 
-func (r *PointRepositoryImpl) CreatePoint(point *models.Point) error {
-	return r.db.Create(point).Error
+func (r *PointRepositoryImpl) CreatePoint(ctx context.Context, point *models.Point) error {
+	return dbError(r.db.WithContext(ctx).Create(point).Error)
+}
+
+func (r *PointRepositoryImpl) CreatePoints(ctx context.Context, points []models.Point, batchSize int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return dbError(tx.CreateInBatches(points, batchSize).Error)
+	})
 }
 
-func (r *PointRepositoryImpl) GetPointByID(id uint) (*models.Point, error) {
+func (r *PointRepositoryImpl) GetPointByID(ctx context.Context, id uint) (*models.Point, error) {
 	point := new(models.Point)
-	query, params := r.getPointQuery(filter{ID: id})
+	query, params := r.getPointQuery(pointFilter(ctx, filter{ID: id}))
 
-	err := r.db.Raw(query, params...).Scan(&point).Error
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&point).Error
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 
 	if point.ID == uint(0) {
-		return nil, gorm.ErrRecordNotFound
+		return nil, constants.ErrPointNotFound
 	}
 
 	return point, nil
 }
 
-func (r *PointRepositoryImpl) GetPoints(offset, limit int) ([]models.Point, error) {
+func (r *PointRepositoryImpl) GetPoints(ctx context.Context, offset, limit int) ([]models.Point, error) {
 	var points []models.Point
-	query, params := r.getPointQuery(filter{Offset: offset, Limit: limit})
+	query, params := r.getPointQuery(pointFilter(ctx, filter{Offset: offset, Limit: limit}))
 
-	err := r.db.Raw(query, params...).Scan(&points).Error
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&points).Error
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 
 	return points, nil
 }
 
-func (r *PointRepositoryImpl) UpdatePoint(point *models.Point) error {
-	return r.db.Save(point).Error
+// UpdatePoint replaces a point and bumps its version. The point stays in its
+// layer, which point is set to. A point carrying a version is only replaced
+// while it is current, constants.ErrVersionMismatch being returned otherwise.
+// Points outside the layer scope of ctx are not found.
+func (r *PointRepositoryImpl) UpdatePoint(ctx context.Context, point *models.Point) error {
+	point.SetGeohash()
+	params := []any{point.Data, point.Properties, point.Geohash}
+
+	updated, err := updateVersioned(r.db.WithContext(ctx), "points", "data = ?, properties = ?, geohash = ?", params,
+		point.ID, point.Version, models.LayerScopeFrom(ctx).Points, constants.ErrPointNotFound)
+	if err != nil {
+		return err
+	}
+
+	point.Version, point.LayerID = updated.Version, updated.LayerID
+	return nil
+}
+
+// DeletePoint moves a point to the trash, only while it is at version when
+// that is set. constants.ErrPointNotFound is returned when it does not exist
+// or is outside the layer scope of ctx.
+func (r *PointRepositoryImpl) DeletePoint(ctx context.Context, id, version uint) error {
+	return deleteVersioned(r.db.WithContext(ctx), "points", id, version, models.LayerScopeFrom(ctx).Points, r.db.NowFunc(), constants.ErrPointNotFound)
+}
+
+// PurgePoints deletes for good the points moved to the trash before the
+// given time and returns how many there were.
+func (r *PointRepositoryImpl) PurgePoints(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec("DELETE FROM points WHERE deleted_at < ?", before)
+	return result.RowsAffected, dbError(result.Error)
+}
+
+func (r *PointRepositoryImpl) GetPointsByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Point, error) {
+	var points []models.Point
+	query, params := r.getPointQuery(pointFilter(ctx, filter{BBox: &bbox, Offset: offset, Limit: limit}))
+
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&points).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	return points, nil
+}
+
+func (r *PointRepositoryImpl) GetPointsByGeohash(ctx context.Context, prefix string, offset, limit int) ([]models.Point, error) {
+	var points []models.Point
+	query, params := r.getPointQuery(pointFilter(ctx, filter{Geohash: prefix, Offset: offset, Limit: limit}))
+
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&points).Error
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	return points, nil
 }
 
-func (r *PointRepositoryImpl) DeletePoint(id uint) error {
-	return r.db.Delete(&models.Point{}, id).Error
+func (r *PointRepositoryImpl) StreamPoints(ctx context.Context, bbox *models.BBox, contourID uint, fn func(*models.Point) error) error {
+	query, params := r.getPointQuery(pointFilter(ctx, filter{BBox: bbox, ContourID: contourID, Unbounded: true}))
+
+	rows, err := r.db.WithContext(ctx).Raw(query, params...).Rows()
+	if err != nil {
+		return dbError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var point models.Point
+		if err := r.db.ScanRows(rows, &point); err != nil {
+			return dbError(err)
+		}
+
+		if err := fn(&point); err != nil {
+			return dbError(err)
+		}
+	}
+
+	return dbError(rows.Err())
 }
 
 func (r *PointRepositoryImpl) getPointQuery(f filter) (string, []any) {
 	params := make([]any, 0)
-	query := "SELECT id, ST_AsGeoJSON(data) AS data FROM points"
-	keyword := "WHERE"
+	query := "SELECT p.id, ST_AsGeoJSON(p.data) AS data, p.properties, p.geohash, p.layer_id, p.version, p.deleted_at FROM points p"
+	if f.ContourID != 0 {
+		query += " JOIN contours c ON ST_Within(p.data, c.data) AND c.id = ? AND c.deleted_at IS NULL"
+		query, params = inLayer(query, append(params, f.ContourID), "c", f.ContourLayer)
+	}
+
+	conditions, conditionParams := f.conditions("p")
+	query += " WHERE " + strings.Join(conditions, " AND ")
+	params = append(params, conditionParams...)
+
 	if f.ID != 0 {
-		query += fmt.Sprintf(" %s id = ?", keyword)
-		params = append(params, f.ID)
-	} else {
-		query += " ORDER BY id DESC OFFSET ? LIMIT ?"
+		return query, params
+	}
+
+	query += " ORDER BY p.id DESC"
+	if !f.Unbounded {
+		query += " OFFSET ? LIMIT ?"
 		params = append(params, f.Offset, f.Limit)
 	}
 
 	return query, params
 }
 
-func (r *PointRepositoryImpl) GetPointsByContourID(contourID uint) ([]models.Point, error) {
+// conditions returns the WHERE conditions shared by the point and contour
+// queries for the table aliased as alias. Soft deleted rows are left out,
+// unless the trash is being listed.
+func (f filter) conditions(alias string) ([]string, []any) {
+	conditions := []string{alias + ".deleted_at IS NULL"}
+	if f.Trashed {
+		conditions[0] = alias + ".deleted_at IS NOT NULL"
+	}
+
+	params := make([]any, 0)
+
+	if f.ID != 0 {
+		conditions = append(conditions, alias+".id = ?")
+		params = append(params, f.ID)
+	}
+
+	if len(f.IDs) > 0 {
+		conditions = append(conditions, alias+".id IN ?")
+		params = append(params, f.IDs)
+	}
+
+	if f.BBox != nil {
+		conditions = append(conditions, "ST_Intersects("+alias+".data, ST_MakeEnvelope(?, ?, ?, ?, 4326))")
+		params = append(params, f.BBox.MinLon, f.BBox.MinLat, f.BBox.MaxLon, f.BBox.MaxLat)
+	}
+
+	if f.Geohash != "" {
+		conditions = append(conditions, alias+".geohash LIKE ?")
+		params = append(params, f.Geohash+"%")
+	}
+
+	if f.Layer != nil {
+		conditions = append(conditions, alias+".layer_id = ?")
+		params = append(params, *f.Layer)
+	}
+
+	return conditions, params
+}
+
+func (r *PointRepositoryImpl) GetPointsByContourID(ctx context.Context, contourID uint) ([]models.Point, error) {
 	points := make([]models.Point, 0)
-	query := "SELECT p.id, ST_AsGeoJSON(p.data) AS data FROM points p JOIN contours c ON ST_Within(p.data, c.data) WHERE c.id = ?"
-	err := r.db.Raw(query, contourID).Scan(&points).Error
+	query := "SELECT p.id, ST_AsGeoJSON(p.data) AS data, p.properties, p.geohash, p.layer_id, p.version FROM points p JOIN contours c ON ST_Within(p.data, c.data) " +
+		"WHERE c.id = ? AND c.deleted_at IS NULL AND p.deleted_at IS NULL"
+	scope := models.LayerScopeFrom(ctx)
+	query, params := inLayer(query, []any{contourID}, "c", scope.Contours)
+	query, params = inLayer(query, params, "p", scope.Points)
+
+	err := r.db.WithContext(ctx).Raw(query, params...).Scan(&points).Error
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 
 	return points, nil
 }
+
+// GetPointsTile encodes the points of a tile as the points layer of a Mapbox
+// Vector Tile, their properties becoming feature attributes.
+func (r *PointRepositoryImpl) GetPointsTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	query := "SELECT ST_AsMVT(t.*, ?, ?, 'geom', 'id') FROM (" +
+		"SELECT p.id, p.properties, ST_AsMVTGeom(ST_Transform(p.data, 3857), ST_TileEnvelope(?, ?, ?), ?, ?, true) AS geom " +
+		"FROM points p WHERE p.deleted_at IS NULL AND ST_Intersects(p.data, ST_Transform(ST_TileEnvelope(?, ?, ?), 4326))"
+	params := []any{
+		PointsLayer, models.TileExtent,
+		tile.Z, tile.X, tile.Y, models.TileExtent, models.TileBuffer,
+		tile.Z, tile.X, tile.Y,
+	}
+	query, params = inLayer(query, params, "p", models.LayerScopeFrom(ctx).Points)
+	query += ") AS t WHERE t.geom IS NOT NULL"
+
+	var data []byte
+	if err := r.db.WithContext(ctx).Raw(query, params...).Row().Scan(&data); err != nil {
+		return nil, dbError(err)
+	}
+
+	return data, nil
+}
+
+// GetPointsGrid counts the points of every square or hexagonal cell covering
+// the bounding box, or the contour when contourID is set. Cells are generated
+// in EPSG:3857 with ST_SquareGrid or ST_HexagonGrid, which need PostGIS 3.1;
+// constants.ErrGridUnsupported is returned when they are unavailable.
+func (r *PointRepositoryImpl) GetPointsGrid(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error) {
+	if r.db.Dialector.Name() != "postgres" {
+		return nil, constants.ErrGridUnsupported
+	}
+
+	gridFunc := "ST_SquareGrid"
+	if grid.Type == models.GridHex {
+		gridFunc = "ST_HexagonGrid"
+	}
+
+	scope := models.LayerScopeFrom(ctx)
+	bounds := "ST_Transform(ST_MakeEnvelope(?, ?, ?, ?, 4326), 3857)"
+	params := []any{grid.Size}
+	if contourID != 0 {
+		bounds, params = inLayer("(SELECT ST_Transform(c.data, 3857) FROM contours c WHERE c.id = ? AND c.deleted_at IS NULL", append(params, contourID), "c", scope.Contours)
+		bounds += ")"
+	} else {
+		params = append(params, bbox.MinLon, max(bbox.MinLat, -models.MaxMercatorLat), bbox.MaxLon, min(bbox.MaxLat, models.MaxMercatorLat))
+	}
+
+	query := "SELECT g.i, g.j, ST_AsGeoJSON(ST_Transform(g.geom, 4326)) AS data, COUNT(*) AS count " +
+		"FROM " + gridFunc + "(?, " + bounds + ") AS g JOIN points p ON p.deleted_at IS NULL AND ST_Intersects(ST_Transform(p.data, 3857), g.geom)"
+	query, params = inLayer(query, params, "p", scope.Points)
+	if contourID != 0 {
+		query += " JOIN contours c ON ST_Within(p.data, c.data) AND c.id = ? AND c.deleted_at IS NULL"
+		query, params = inLayer(query, append(params, contourID), "c", scope.Contours)
+	}
+
+	query += " GROUP BY g.i, g.j, g.geom ORDER BY g.i, g.j"
+
+	cells := make([]models.Cell, 0)
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(&cells).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == undefinedFunction {
+			return nil, constants.ErrGridUnsupported
+		}
+
+		return nil, dbError(err)
+	}
+
+	return cells, nil
+}
+
+// GetPointContourDistance measures the distance on the spheroid from a point
+// to the boundary of a contour, holes included. constants.ErrPointNotFound or
+// constants.ErrContourNotFound is returned when either of them does not
+// exist.
+func (r *PointRepositoryImpl) GetPointContourDistance(ctx context.Context, pointID, contourID uint) (*models.Distance, error) {
+	query := "SELECT ST_Covers(c.data, p.data) AS inside, " +
+		"CASE WHEN ST_Covers(c.data, p.data) THEN -1 ELSE 1 END * ST_Distance(p.data::geography, ST_Boundary(c.data)::geography) AS meters, " +
+		"ST_AsGeoJSON(ST_ClosestPoint(ST_Boundary(c.data)::geography, p.data::geography)::geometry) AS closest " +
+		"FROM points p, contours c WHERE p.id = ? AND c.id = ? AND p.deleted_at IS NULL AND c.deleted_at IS NULL"
+	scope := models.LayerScopeFrom(ctx)
+	query, params := inLayer(query, []any{pointID, contourID}, "p", scope.Points)
+	query, params = inLayer(query, params, "c", scope.Contours)
+
+	distance := new(models.Distance)
+	result := r.db.WithContext(ctx).Raw(query, params...).Scan(distance)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		// Tell which of them is missing.
+		if _, err := r.GetPointByID(ctx, pointID); err != nil {
+			return nil, err
+		}
+
+		return nil, constants.ErrContourNotFound
+	}
+
+	return distance, nil
+}
+
+// GetPointBuffer returns the buffer of a point computed on the spheroid as a
+// new, unsaved contour. constants.ErrPointNotFound is returned when the point
+// does not exist.
+func (r *PointRepositoryImpl) GetPointBuffer(ctx context.Context, id uint, buffer models.Buffer) (*models.Contour, error) {
+	query := "SELECT ST_AsGeoJSON(ST_Buffer(p.data::geography, ?, ?)::geometry) AS data FROM points p WHERE p.id = ? AND p.deleted_at IS NULL"
+	query, params := inLayer(query, []any{buffer.Distance, buffer.Parameters(), id}, "p", models.LayerScopeFrom(ctx).Points)
+
+	contour := new(models.Contour)
+	result := r.db.WithContext(ctx).Raw(query, params...).Scan(contour)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, constants.ErrPointNotFound
+	}
+
+	return contour, nil
+}
+
+// GetPointsHull computes the hull of the inline positions of the input, or of
+// the stored points it selects, as a new, unsaved contour. The hull of fewer
+// than three points not on a line is not a polygon, nor is the empty one.
+func (r *PointRepositoryImpl) GetPointsHull(ctx context.Context, hull models.Hull, input models.HullInput) (*models.Contour, error) {
+	source, params := "ST_Collect(p.data)", make([]any, 0)
+	if len(input.Positions) > 0 {
+		source, params = "ST_GeomFromText(?, 4326)", append(params, models.MultiPointWKT(input.Positions))
+	}
+
+	expr := "ST_ConvexHull(" + source + ")"
+	if hull.Type == models.HullConcave {
+		expr = "ST_ConcaveHull(" + source + ", ?)"
+		params = append(params, hull.TargetPercent)
+	}
+
+	query := "SELECT ST_AsGeoJSON(" + expr + ") AS data"
+	if len(input.Positions) == 0 {
+		f := pointFilter(ctx, filter{IDs: input.IDs, BBox: input.BBox})
+		query += " FROM points p"
+		if input.ContourID != 0 {
+			query += " JOIN contours c ON ST_Within(p.data, c.data) AND c.id = ? AND c.deleted_at IS NULL"
+			query, params = inLayer(query, append(params, input.ContourID), "c", f.ContourLayer)
+		}
+
+		conditions, conditionParams := f.conditions("p")
+		query += " WHERE " + strings.Join(conditions, " AND ")
+		params = append(params, conditionParams...)
+	}
+
+	contour := new(models.Contour)
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(contour).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	return contour, nil
+}
+
+func (r *PointRepositoryImpl) GetPointsByIDs(ctx context.Context, ids []uint) ([]models.Point, error) {
+	points := make([]models.Point, 0)
+	query, params := r.getPointQuery(pointFilter(ctx, filter{IDs: ids, Unbounded: true}))
+
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(&points).Error; err != nil {
+		return nil, dbError(err)
+	}
+
+	return points, nil
+}
+
+// GetVoronoiCells computes the Voronoi cells of the seed points clipped to
+// the contour, ordered by point. A cell of a concave contour may be a
+// MultiPolygon, or empty when its seed lies outside the contour.
+// constants.ErrVoronoiUnsupported is returned when the database lacks
+// ST_VoronoiPolygons.
+func (r *PointRepositoryImpl) GetVoronoiCells(ctx context.Context, input models.VoronoiInput) ([]models.VoronoiCell, error) {
+	scope := models.LayerScopeFrom(ctx)
+	seeds, params := voronoiSeeds(input, scope)
+	clip, params := inLayer("SELECT c.data FROM contours c WHERE c.id = ? AND c.deleted_at IS NULL", append(params, input.ContourID), "c", scope.Contours)
+	query := "WITH seeds AS (" + seeds + "), clip AS (" + clip + "), " +
+		"cells AS (SELECT d.path[1] AS n, d.geom FROM ST_Dump((SELECT ST_VoronoiPolygons(ST_Collect(s.data), 0, (SELECT data FROM clip)) FROM seeds s)) AS d) " +
+		"SELECT v.point_id, v.data FROM (" +
+		"SELECT DISTINCT ON (cells.n) s.id AS point_id, ST_AsGeoJSON(ST_CollectionExtract(ST_Intersection(cells.geom, clip.data), 3)) AS data " +
+		"FROM cells CROSS JOIN clip JOIN seeds s ON ST_Intersects(cells.geom, s.data) ORDER BY cells.n, s.id" +
+		") AS v ORDER BY v.point_id"
+
+	cells := make([]models.VoronoiCell, 0)
+	if err := r.voronoiScan(ctx, query, params, &cells); err != nil {
+		return nil, dbError(err)
+	}
+
+	return cells, nil
+}
+
+// GetDelaunayTriangles computes the Delaunay triangulation of the seed
+// points. constants.ErrVoronoiUnsupported is returned when the database
+// lacks ST_DelaunayTriangles.
+func (r *PointRepositoryImpl) GetDelaunayTriangles(ctx context.Context, input models.VoronoiInput) ([]models.Geometry, error) {
+	seeds, params := voronoiSeeds(input, models.LayerScopeFrom(ctx))
+	query := "WITH seeds AS (" + seeds + ") " +
+		"SELECT ST_AsGeoJSON(d.geom) AS data FROM ST_Dump((SELECT ST_DelaunayTriangles(ST_Collect(s.data)) FROM seeds s)) AS d ORDER BY d.path[1]"
+
+	rows := make([]models.Contour, 0)
+	if err := r.voronoiScan(ctx, query, params, &rows); err != nil {
+		return nil, dbError(err)
+	}
+
+	triangles := make([]models.Geometry, len(rows))
+	for i, row := range rows {
+		triangles[i] = row.Data
+	}
+
+	return triangles, nil
+}
+
+// voronoiSeeds returns the query of the seed points of a Voronoi diagram or
+// Delaunay triangulation, within the layers of scope.
+func voronoiSeeds(input models.VoronoiInput, scope models.LayerScope) (string, []any) {
+	if len(input.IDs) > 0 {
+		return inLayer("SELECT p.id, p.data FROM points p WHERE p.id IN ? AND p.deleted_at IS NULL", []any{input.IDs}, "p", scope.Points)
+	}
+
+	query, params := inLayer("SELECT p.id, p.data FROM points p JOIN contours c ON ST_Within(p.data, c.data) AND c.id = ? AND c.deleted_at IS NULL", []any{input.ContourID}, "c", scope.Contours)
+	return inLayer(query+" WHERE p.deleted_at IS NULL", params, "p", scope.Points)
+}
+
+func (r *PointRepositoryImpl) voronoiScan(ctx context.Context, query string, params []any, dest any) error {
+	if r.db.Dialector.Name() != "postgres" {
+		return constants.ErrVoronoiUnsupported
+	}
+
+	if err := r.db.WithContext(ctx).Raw(query, params...).Scan(dest).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == undefinedFunction {
+			return constants.ErrVoronoiUnsupported
+		}
+
+		return dbError(err)
+	}
+
+	return nil
+}
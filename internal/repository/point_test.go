@@ -1,13 +1,16 @@
 package repository
 
 import (
+	"context"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
+	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/db"
 	"github.com/malamsyah/geo-service/internal/models"
 	"github.com/malamsyah/geo-service/pkg/config"
@@ -74,7 +77,7 @@ func (p *PointRepoTestSuite) TestPointRepository_CreatePoint() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				err := repo.CreatePoint(tt.point)
+				err := repo.CreatePoint(context.Background(), tt.point)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -88,6 +91,46 @@ func (p *PointRepoTestSuite) TestPointRepository_CreatePoint() {
 	tx.Rollback()
 }
 
+func (p *PointRepoTestSuite) TestPointRepository_CreatePoints() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+	p.Suite.T().Run("CreatePoints", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			points    []models.Point
+			batchSize int
+			wantErr   bool
+		}{
+			{
+				name: "SpansSeveralBatches",
+				points: []models.Point{
+					{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{125.6, 10.1}}},
+					{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{125.7, 10.2}}},
+					{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{125.8, 10.3}}},
+				},
+				batchSize: 2,
+				wantErr:   false,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := repo.CreatePoints(context.Background(), tt.points, tt.batchSize)
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+					for _, point := range tt.points {
+						assert.NotZero(t, point.ID)
+					}
+				}
+			})
+		}
+	})
+
+	tx.Rollback()
+}
+
 func (p *PointRepoTestSuite) TestPointRepository_GetPointByID() {
 	tx := p.db.Begin()
 	repo := NewPointRepository(tx)
@@ -98,7 +141,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointByID() {
 			PointCoordinates: [2]float64{125.6, 10.1},
 		},
 	}
-	err := repo.CreatePoint(examplePoint)
+	err := repo.CreatePoint(context.Background(), examplePoint)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -123,7 +166,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointByID() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				actualPoint, err := repo.GetPointByID(tt.ID)
+				actualPoint, err := repo.GetPointByID(context.Background(), tt.ID)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -147,7 +190,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPoints() {
 			PointCoordinates: [2]float64{125.6, 10.1},
 		},
 	}
-	err := repo.CreatePoint(examplePoint)
+	err := repo.CreatePoint(context.Background(), examplePoint)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -178,7 +221,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPoints() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				points, err := repo.GetPoints(tt.offset, tt.limit)
+				points, err := repo.GetPoints(context.Background(), tt.offset, tt.limit)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -202,7 +245,7 @@ func (p *PointRepoTestSuite) TestPointRepository_UpdatePoint() {
 			PointCoordinates: [2]float64{125.6, 10.1},
 		},
 	}
-	err := repo.CreatePoint(examplePoint)
+	err := repo.CreatePoint(context.Background(), examplePoint)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -237,7 +280,7 @@ func (p *PointRepoTestSuite) TestPointRepository_UpdatePoint() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				err := repo.UpdatePoint(tt.point)
+				err := repo.UpdatePoint(context.Background(), tt.point)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
@@ -245,7 +288,7 @@ func (p *PointRepoTestSuite) TestPointRepository_UpdatePoint() {
 					assert.NotZero(t, tt.point.ID)
 					assert.Equal(t, examplePoint.ID, tt.point.ID)
 
-					actualPoint, err := repo.GetPointByID(tt.point.ID)
+					actualPoint, err := repo.GetPointByID(context.Background(), tt.point.ID)
 					if err != nil {
 						t.Fatal(err)
 					}
@@ -259,6 +302,45 @@ func (p *PointRepoTestSuite) TestPointRepository_UpdatePoint() {
 	tx.Rollback()
 }
 
+func (p *PointRepoTestSuite) TestPointRepository_Version() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	examplePoint := &models.Point{
+		Data: models.Geometry{
+			Type:             "Point",
+			PointCoordinates: [2]float64{125.6, 10.1},
+		},
+	}
+	err := repo.CreatePoint(context.Background(), examplePoint)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+	p.Suite.T().Run("Version", func(t *testing.T) {
+		assert.Equal(t, uint(1), examplePoint.Version)
+
+		update := &models.Point{ID: examplePoint.ID, Data: examplePoint.Data, Version: 1}
+		assert.NoError(t, repo.UpdatePoint(context.Background(), update))
+		assert.Equal(t, uint(2), update.Version)
+
+		stale := &models.Point{ID: examplePoint.ID, Data: examplePoint.Data, Version: 1}
+		assert.ErrorIs(t, repo.UpdatePoint(context.Background(), stale), constants.ErrVersionMismatch)
+		assert.ErrorIs(t, repo.DeletePoint(context.Background(), examplePoint.ID, 1), constants.ErrVersionMismatch)
+
+		missing := &models.Point{ID: 999999, Data: examplePoint.Data, Version: 1}
+		assert.ErrorIs(t, repo.UpdatePoint(context.Background(), missing), constants.ErrPointNotFound)
+
+		actualPoint, err := repo.GetPointByID(context.Background(), examplePoint.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), actualPoint.Version)
+
+		assert.NoError(t, repo.DeletePoint(context.Background(), examplePoint.ID, 2))
+		assert.ErrorIs(t, repo.DeletePoint(context.Background(), examplePoint.ID, 2), constants.ErrPointNotFound)
+	})
+
+	tx.Rollback()
+}
+
 func (p *PointRepoTestSuite) TestPointRepository_DeletePoint() {
 	tx := p.db.Begin()
 	repo := NewPointRepository(tx)
@@ -269,7 +351,7 @@ func (p *PointRepoTestSuite) TestPointRepository_DeletePoint() {
 			PointCoordinates: [2]float64{125.6, 10.1},
 		},
 	}
-	err := repo.CreatePoint(examplePoint)
+	err := repo.CreatePoint(context.Background(), examplePoint)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -287,19 +369,19 @@ func (p *PointRepoTestSuite) TestPointRepository_DeletePoint() {
 			{
 				name:    "PointNotExists",
 				ID:      999999,
-				wantErr: false,
+				wantErr: true,
 			},
 		}
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				err := repo.DeletePoint(tt.ID)
+				err := repo.DeletePoint(context.Background(), tt.ID, 0)
 				if tt.wantErr {
 					assert.Error(t, err)
 				} else {
 					assert.NoError(t, err)
 
-					actualPoint, err := repo.GetPointByID(tt.ID)
+					actualPoint, err := repo.GetPointByID(context.Background(), tt.ID)
 					assert.Error(t, err)
 					assert.Nil(t, actualPoint)
 				}
@@ -307,6 +389,16 @@ func (p *PointRepoTestSuite) TestPointRepository_DeletePoint() {
 		}
 	})
 
+	p.Suite.T().Run("PurgePoints", func(t *testing.T) {
+		purged, err := repo.PurgePoints(context.Background(), time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Zero(t, purged)
+
+		purged, err = repo.PurgePoints(context.Background(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Positive(t, purged)
+	})
+
 	tx.Rollback()
 }
 
@@ -320,7 +412,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointsByContourID() {
 			PointCoordinates: [2]float64{5.0, 5.0},
 		},
 	}
-	err := repo.CreatePoint(examplePoint)
+	err := repo.CreatePoint(context.Background(), examplePoint)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -333,7 +425,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointsByContourID() {
 		},
 	}
 
-	err = contourRepo.CreateContour(exampleContourA)
+	err = contourRepo.CreateContour(context.Background(), exampleContourA)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -344,7 +436,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointsByContourID() {
 			PolygonCoordinates: [][][2]float64{{{20, 20}, {30, 20}, {30, 30}, {20, 30}, {20, 20}}},
 		},
 	}
-	err = contourRepo.CreateContour(exampleContourB)
+	err = contourRepo.CreateContour(context.Background(), exampleContourB)
 	if err != nil {
 		p.Suite.T().Fatal(err)
 	}
@@ -371,7 +463,7 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointsByContourID() {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				points, err := repo.GetPointsByContourID(tt.countourID)
+				points, err := repo.GetPointsByContourID(context.Background(), tt.countourID)
 				if tt.found {
 					assert.NoError(t, err)
 					assert.Equal(t, tt.expectedResult, points)
@@ -385,3 +477,425 @@ func (p *PointRepoTestSuite) TestPointRepository_GetPointsByContourID() {
 
 	tx.Rollback()
 }
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointsByBBox() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	examplePoint := &models.Point{
+		Data: models.Geometry{
+			Type:             "Point",
+			PointCoordinates: [2]float64{5.0, 5.0},
+		},
+	}
+	err := repo.CreatePoint(context.Background(), examplePoint)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetPointsByBBox", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			bbox           models.BBox
+			expectedResult []models.Point
+		}{
+			{
+				name:           "PointInside",
+				bbox:           models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6},
+				expectedResult: []models.Point{*examplePoint},
+			},
+			{
+				name: "PointOutside",
+				bbox: models.BBox{MinLon: 40, MinLat: 40, MaxLon: 60, MaxLat: 60},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				points, err := repo.GetPointsByBBox(context.Background(), tt.bbox, 0, 10)
+				assert.NoError(t, err)
+				assert.Equal(t, len(tt.expectedResult), len(points))
+				if len(tt.expectedResult) > 0 {
+					assert.Equal(t, tt.expectedResult, points)
+				}
+			})
+		}
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_StreamPoints() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	examplePoint := &models.Point{
+		Data: models.Geometry{
+			Type:             "Point",
+			PointCoordinates: [2]float64{5.0, 5.0},
+		},
+	}
+	err := repo.CreatePoint(context.Background(), examplePoint)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+		},
+	}
+	err = NewContourRepository(tx).CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("StreamPoints", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			bbox      *models.BBox
+			contourID uint
+			found     bool
+		}{
+			{
+				name:      "MatchesContourAndBBox",
+				bbox:      &models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6},
+				contourID: exampleContour.ID,
+				found:     true,
+			},
+			{
+				name:  "OutsideBBox",
+				bbox:  &models.BBox{MinLon: 40, MinLat: 40, MaxLon: 60, MaxLat: 60},
+				found: false,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				points := make([]models.Point, 0)
+				err := repo.StreamPoints(context.Background(), tt.bbox, tt.contourID, func(point *models.Point) error {
+					points = append(points, *point)
+					return nil
+				})
+				assert.NoError(t, err)
+				if tt.found {
+					assert.Contains(t, points, *examplePoint)
+				} else {
+					assert.NotContains(t, points, *examplePoint)
+				}
+			})
+		}
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointsTile() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	examplePoint := &models.Point{
+		Data: models.Geometry{
+			Type:             "Point",
+			PointCoordinates: [2]float64{5.0, 5.0},
+		},
+	}
+	err := repo.CreatePoint(context.Background(), examplePoint)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetPointsTile", func(t *testing.T) {
+		data, err := repo.GetPointsTile(context.Background(), models.Tile{Z: 1, X: 1, Y: 0})
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), PointsLayer)
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointsGrid() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	examplePoint := &models.Point{
+		Data: models.Geometry{
+			Type:             "Point",
+			PointCoordinates: [2]float64{5.0, 5.0},
+		},
+	}
+	err := repo.CreatePoint(context.Background(), examplePoint)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetPointsGrid", func(t *testing.T) {
+		bbox := &models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6}
+
+		for _, grid := range []models.Grid{{Type: models.GridHex, Size: 1000}, {Type: models.GridSquare, Size: 1000}} {
+			t.Run(string(grid.Type), func(t *testing.T) {
+				cells, err := repo.GetPointsGrid(context.Background(), grid, bbox, 0)
+				assert.NoError(t, err)
+				assert.Len(t, cells, 1)
+
+				// The Go fallback must lay cells out as PostGIS does.
+				i, j := grid.CellAt(models.Mercator(5, 5))
+				assert.Equal(t, i, cells[0].I)
+				assert.Equal(t, j, cells[0].J)
+				assert.Equal(t, 1, cells[0].Count)
+			})
+		}
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointsByGeohash() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	examplePoint := &models.Point{
+		Data: models.Geometry{
+			Type:             "Point",
+			PointCoordinates: [2]float64{106.8271, -6.1754},
+		},
+	}
+	err := repo.CreatePoint(context.Background(), examplePoint)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetPointsByGeohash", func(t *testing.T) {
+		assert.Equal(t, "qqguygv", examplePoint.Geohash[:7])
+
+		points, err := repo.GetPointsByGeohash(context.Background(), "qqguy", 0, 10)
+		assert.NoError(t, err)
+		assert.Contains(t, points, *examplePoint)
+
+		points, err = repo.GetPointsByGeohash(context.Background(), "u4pru", 0, 10)
+		assert.NoError(t, err)
+		assert.NotContains(t, points, *examplePoint)
+
+		// The geohash follows the point when it moves.
+		examplePoint.Data.PointCoordinates = [2]float64{10.40744, 57.64911}
+		assert.NoError(t, repo.UpdatePoint(context.Background(), examplePoint))
+
+		points, err = repo.GetPointsByGeohash(context.Background(), "u4pru", 0, 10)
+		assert.NoError(t, err)
+		assert.Contains(t, points, *examplePoint)
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointContourDistance() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	exampleContour := &models.Contour{
+		Data: models.Geometry{
+			Type:               "Polygon",
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+		},
+	}
+	err := NewContourRepository(tx).CreateContour(context.Background(), exampleContour)
+	if err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	inside := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{0.5, 0.999}}}
+	outside := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{1.001, 0.5}}}
+	for _, point := range []*models.Point{inside, outside} {
+		if err := repo.CreatePoint(context.Background(), point); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	p.Suite.T().Run("GetPointContourDistance", func(t *testing.T) {
+		distance, err := repo.GetPointContourDistance(context.Background(), inside.ID, exampleContour.ID)
+		assert.NoError(t, err)
+		assert.True(t, distance.Inside)
+		assert.InDelta(t, -110.6, distance.Meters, 1)
+
+		distance, err = repo.GetPointContourDistance(context.Background(), outside.ID, exampleContour.ID)
+		assert.NoError(t, err)
+		assert.False(t, distance.Inside)
+		assert.InDelta(t, 111.3, distance.Meters, 1)
+		assert.InDelta(t, 1, distance.Closest.PointCoordinates[0], 1e-6)
+		assert.InDelta(t, 0.5, distance.Closest.PointCoordinates[1], 1e-3)
+
+		_, err = repo.GetPointContourDistance(context.Background(), outside.ID, exampleContour.ID+1000)
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+
+		_, err = repo.GetPointContourDistance(context.Background(), outside.ID+1000, exampleContour.ID)
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointBuffer() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	point := &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{0, 0}}}
+	if err := repo.CreatePoint(context.Background(), point); err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	p.Suite.T().Run("GetPointBuffer", func(t *testing.T) {
+		contour, err := repo.GetPointBuffer(context.Background(), point.ID, models.Buffer{Distance: 1000, Segments: 4, EndCap: models.EndCapRound})
+		assert.NoError(t, err)
+		assert.True(t, contour.Data.IsPolygon())
+		assert.Len(t, contour.Data.PolygonCoordinates[0], 17)
+
+		bounds := contour.Data.Bounds()
+		assert.InDelta(t, 0.009, bounds.MaxLat, 1e-3)
+		assert.InDelta(t, -0.009, bounds.MinLon, 1e-3)
+
+		_, err = repo.GetPointBuffer(context.Background(), point.ID+1000, models.Buffer{Distance: 1000, Segments: 4, EndCap: models.EndCapRound})
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+		assert.ErrorIs(t, err, constants.ErrNotFound)
+
+		_, err = repo.GetPointByID(context.Background(), point.ID+1000)
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_GetPointsHull() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	positions := [][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.5}, {5, 5}}
+	points := make([]*models.Point, len(positions))
+	for i, position := range positions {
+		points[i] = &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: position}}
+		if err := repo.CreatePoint(context.Background(), points[i]); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	p.Suite.T().Run("GetPointsHull", func(t *testing.T) {
+		contour, err := repo.GetPointsHull(context.Background(), models.Hull{Type: models.HullConvex}, models.HullInput{BBox: &models.BBox{MinLon: -1, MinLat: -1, MaxLon: 2, MaxLat: 2}})
+		assert.NoError(t, err)
+		assert.True(t, contour.Data.IsPolygon())
+		assert.Equal(t, models.BBox{MinLon: 0, MinLat: 0, MaxLon: 1, MaxLat: 1}, contour.Data.Bounds())
+
+		contour, err = repo.GetPointsHull(context.Background(), models.Hull{Type: models.HullConvex}, models.HullInput{IDs: []uint{points[0].ID, points[2].ID, points[5].ID}})
+		assert.NoError(t, err)
+		assert.Equal(t, models.BBox{MinLon: 0, MinLat: 0, MaxLon: 5, MaxLat: 5}, contour.Data.Bounds())
+
+		contour, err = repo.GetPointsHull(context.Background(), models.Hull{Type: models.HullConcave, TargetPercent: 0.5}, models.HullInput{Positions: positions[:5]})
+		assert.NoError(t, err)
+		assert.True(t, contour.Data.IsPolygon())
+
+		contour, err = repo.GetPointsHull(context.Background(), models.Hull{Type: models.HullConvex}, models.HullInput{IDs: []uint{points[0].ID}})
+		assert.NoError(t, err)
+		assert.False(t, contour.Data.IsPolygon())
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_Voronoi() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	contour := &models.Contour{Data: models.Geometry{
+		Type:               "Polygon",
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {4, 0}, {4, 2}, {0, 2}, {0, 0}}},
+	}}
+	if err := NewContourRepository(tx).CreateContour(context.Background(), contour); err != nil {
+		p.Suite.T().Fatal(err)
+	}
+
+	positions := [][2]float64{{1, 1}, {3, 1}, {2, 0.2}}
+	points := make([]*models.Point, len(positions))
+	for i, position := range positions {
+		points[i] = &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: position}}
+		if err := repo.CreatePoint(context.Background(), points[i]); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	p.Suite.T().Run("GetPointsByIDs", func(t *testing.T) {
+		result, err := repo.GetPointsByIDs(context.Background(), []uint{points[0].ID, points[1].ID})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+
+	p.Suite.T().Run("GetVoronoiCells", func(t *testing.T) {
+		cells, err := repo.GetVoronoiCells(context.Background(), models.VoronoiInput{ContourID: contour.ID, Engine: models.EnginePostGIS})
+		assert.NoError(t, err)
+		assert.Len(t, cells, 3)
+		assert.Equal(t, points[0].ID, cells[0].PointID)
+		assert.Equal(t, 0.0, cells[0].Data.Bounds().MinLon)
+		assert.Equal(t, 4.0, cells[1].Data.Bounds().MaxLon)
+		assert.Equal(t, 0.0, cells[2].Data.Bounds().MinLat)
+
+		cells, err = repo.GetVoronoiCells(context.Background(), models.VoronoiInput{ContourID: contour.ID, IDs: []uint{points[0].ID, points[1].ID}, Engine: models.EnginePostGIS})
+		assert.NoError(t, err)
+		assert.Len(t, cells, 2)
+		assert.InDelta(t, 2, cells[0].Data.Bounds().MaxLon, 1e-9)
+	})
+
+	p.Suite.T().Run("GetDelaunayTriangles", func(t *testing.T) {
+		triangles, err := repo.GetDelaunayTriangles(context.Background(), models.VoronoiInput{ContourID: contour.ID, Engine: models.EnginePostGIS})
+		assert.NoError(t, err)
+		assert.Len(t, triangles, 1)
+		assert.True(t, triangles[0].IsPolygon())
+	})
+
+	tx.Rollback()
+}
+
+func (p *PointRepoTestSuite) TestPointRepository_Duplicates() {
+	tx := p.db.Begin()
+	repo := NewPointRepository(tx)
+
+	positions := [][2]float64{{170, -80}, {170, -80}, {170.00001, -80}, {171, -80}}
+	points := make([]*models.Point, len(positions))
+	for i, position := range positions {
+		points[i] = &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: position}}
+		if err := repo.CreatePoint(context.Background(), points[i]); err != nil {
+			p.Suite.T().Fatal(err)
+		}
+	}
+
+	p.Suite.T().Run("GetDuplicatePoint", func(t *testing.T) {
+		point, err := repo.GetDuplicatePoint(context.Background(), points[2], 0)
+		assert.NoError(t, err)
+		assert.Equal(t, points[2].ID, point.ID)
+
+		point, err = repo.GetDuplicatePoint(context.Background(), points[2], 5)
+		assert.NoError(t, err)
+		assert.Equal(t, points[0].ID, point.ID)
+
+		_, err = repo.GetDuplicatePoint(context.Background(), &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{172, -80}}}, 5)
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+
+		_, err = NewPointRepository(p.db).GetDuplicatePoint(context.Background(), points[2], 0)
+		assert.ErrorIs(t, err, ErrNotInTransaction)
+	})
+
+	p.Suite.T().Run("GetDuplicatePointPairs", func(t *testing.T) {
+		pairs, err := repo.GetDuplicatePointPairs(context.Background(), 0)
+		assert.NoError(t, err)
+		assert.Contains(t, pairs, [2]uint{points[0].ID, points[1].ID})
+		assert.NotContains(t, pairs, [2]uint{points[0].ID, points[2].ID})
+
+		pairs, err = repo.GetDuplicatePointPairs(context.Background(), 5)
+		assert.NoError(t, err)
+		assert.Contains(t, pairs, [2]uint{points[0].ID, points[2].ID})
+		assert.Contains(t, pairs, [2]uint{points[1].ID, points[2].ID})
+		assert.NotContains(t, pairs, [2]uint{points[0].ID, points[3].ID})
+
+		_, err = NewPointRepository(p.db).GetDuplicatePointPairs(context.Background(), 0)
+		assert.ErrorIs(t, err, ErrNotInTransaction)
+	})
+
+	tx.Rollback()
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories are the repositories of a unit of work, sharing its transaction.
+type Repositories struct {
+	Points   PointRepository
+	Contours ContourRepository
+}
+
+// TxManager runs units of work spanning several repositories atomically.
+type TxManager interface {
+	// WithinTx runs fn in a transaction, committed when fn returns nil and
+	// rolled back otherwise. fn gets repositories bound to the transaction and
+	// a context carrying it: WithinTx called again with that context runs in a
+	// savepoint of the outer transaction, rolled back on its own.
+	WithinTx(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
+
+type txKey struct{}
+
+type TxManagerImpl struct {
+	db *gorm.DB
+}
+
+// NewTxManager returns a TxManager running transactions on db. When db is a
+// transaction already, as opened by db.Begin in tests, units of work run in
+// savepoints of it and the caller keeps control of the commit.
+func NewTxManager(db *gorm.DB) TxManager {
+	return &TxManagerImpl{db}
+}
+
+func (m *TxManagerImpl) WithinTx(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error {
+	db := m.db
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		db = tx
+	}
+
+	// gorm opens a savepoint instead of a transaction on a db that is in one.
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, txKey{}, tx)
+		return fn(ctx, Repositories{Points: NewPointRepository(tx), Contours: NewContourRepository(tx)})
+	})
+
+	return dbError(err)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+type TxManagerTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func TestTxManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(TxManagerTestSuite))
+}
+
+func (p *TxManagerTestSuite) SetupSuite() {
+	p.db = setupTestDB(p.Suite.T())
+}
+
+func newTxPoint() *models.Point {
+	return &models.Point{Data: models.Geometry{Type: "Point", PointCoordinates: [2]float64{0.5, 0.5}}}
+}
+
+func newTxContour() *models.Contour {
+	return &models.Contour{Data: models.Geometry{
+		Type:               "Polygon",
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+	}}
+}
+
+func (p *TxManagerTestSuite) TestTxManager_WithinTx() {
+	tx := p.db.Begin()
+	manager := NewTxManager(tx)
+	points := NewPointRepository(tx)
+	contours := NewContourRepository(tx)
+	errAbort := errors.New("abort")
+
+	p.Suite.T().Run("Commit", func(t *testing.T) {
+		point, contour := newTxPoint(), newTxContour()
+		err := manager.WithinTx(context.Background(), func(ctx context.Context, repos Repositories) error {
+			if err := repos.Contours.CreateContour(ctx, contour); err != nil {
+				return err
+			}
+
+			return repos.Points.CreatePoint(ctx, point)
+		})
+		assert.NoError(t, err)
+
+		_, err = points.GetPointByID(context.Background(), point.ID)
+		assert.NoError(t, err)
+		_, err = contours.GetContourByID(context.Background(), contour.ID)
+		assert.NoError(t, err)
+	})
+
+	p.Suite.T().Run("Rollback", func(t *testing.T) {
+		point, contour := newTxPoint(), newTxContour()
+		err := manager.WithinTx(context.Background(), func(ctx context.Context, repos Repositories) error {
+			if err := repos.Contours.CreateContour(ctx, contour); err != nil {
+				return err
+			}
+
+			if err := repos.Points.CreatePoint(ctx, point); err != nil {
+				return err
+			}
+
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		_, err = points.GetPointByID(context.Background(), point.ID)
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+		_, err = contours.GetContourByID(context.Background(), contour.ID)
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+	})
+
+	p.Suite.T().Run("Savepoint", func(t *testing.T) {
+		point, contour := newTxPoint(), newTxContour()
+		err := manager.WithinTx(context.Background(), func(ctx context.Context, repos Repositories) error {
+			if err := repos.Contours.CreateContour(ctx, contour); err != nil {
+				return err
+			}
+
+			nestedErr := manager.WithinTx(ctx, func(ctx context.Context, repos Repositories) error {
+				if err := repos.Points.CreatePoint(ctx, point); err != nil {
+					return err
+				}
+
+				return errAbort
+			})
+			assert.ErrorIs(t, nestedErr, errAbort)
+
+			return nil
+		})
+		assert.NoError(t, err)
+
+		_, err = points.GetPointByID(context.Background(), point.ID)
+		assert.ErrorIs(t, err, constants.ErrPointNotFound)
+		_, err = contours.GetContourByID(context.Background(), contour.ID)
+		assert.NoError(t, err)
+	})
+
+	tx.Rollback()
+}
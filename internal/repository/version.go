@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"gorm.io/gorm"
+)
+
+// versioned is the state of a row after a versioned update: its new version
+// and the layer it is in, which updates leave alone.
+type versioned struct {
+	Version uint
+	LayerID *uint
+}
+
+// updateVersioned sets the columns of a row of table, given as "column = ?"
+// assignments, bumps its version and returns the new one along with its
+// layer. A non zero version makes the update conditional on it being current,
+// checked in the same statement so concurrent writers cannot both win.
+// notFound is returned when the row does not exist, is soft deleted or is not
+// in layer, when set.
+func updateVersioned(db *gorm.DB, table, set string, params []any, id, version uint, layer *uint, notFound error) (*versioned, error) {
+	query, params := inLayer("UPDATE "+table+" SET "+set+", version = version + 1 WHERE id = ? AND deleted_at IS NULL", append(params, id), table, layer)
+	if version != 0 {
+		query += " AND version = ?"
+		params = append(params, version)
+	}
+
+	updated := new(versioned)
+	result := db.Raw(query+" RETURNING version, layer_id", params...).Scan(updated)
+	if result.Error != nil {
+		return nil, dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, versionConflict(db, table, id, layer, notFound)
+	}
+
+	return updated, nil
+}
+
+// deleteVersioned soft deletes a row of table at the given time, moving it
+// to the trash. A non zero version makes the delete conditional on it being
+// current. notFound is returned when the row does not exist, is in the trash
+// already or is not in layer, when set.
+func deleteVersioned(db *gorm.DB, table string, id, version uint, layer *uint, at time.Time, notFound error) error {
+	query, params := inLayer("UPDATE "+table+" SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", []any{at, id}, table, layer)
+	if version != 0 {
+		query += " AND version = ?"
+		params = append(params, version)
+	}
+
+	result := db.Exec(query, params...)
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return versionConflict(db, table, id, layer, notFound)
+	}
+
+	return nil
+}
+
+// versionConflict tells why a write of a row of table matched nothing:
+// notFound when the row does not exist, is soft deleted or is not in layer,
+// constants.ErrVersionMismatch carrying the current version otherwise.
+func versionConflict(db *gorm.DB, table string, id uint, layer *uint, notFound error) error {
+	query, params := inLayer("SELECT version FROM "+table+" WHERE id = ? AND deleted_at IS NULL", []any{id}, table, layer)
+
+	var current uint
+	result := db.Raw(query, params...).Scan(&current)
+	if result.Error != nil {
+		return dbError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return notFound
+	}
+
+	return constants.ErrVersionMismatch.WithDetails(map[string]any{"version": current})
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// AggregatePoints counts points per cell of the grid. Square and hexagonal
+// grids cover the bounding box, or the contour when contourID is set, their
+// cells spanning the size of the grid in metres at its centre, and only cells
+// holding points are returned. A contour grid counts the points of every
+// contour intersecting the bounding box.
+func (s *GeometryServiceImpl) AggregatePoints(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error) {
+	if err := grid.Validate(); err != nil {
+		return nil, err
+	}
+
+	if grid.Type == models.GridContour {
+		return s.contourRepo.GetContoursPointCount(ctx, bbox)
+	}
+
+	var bounds models.BBox
+	switch {
+	case contourID != 0:
+		contour, err := s.contourRepo.GetContourByID(ctx, contourID)
+		if err != nil {
+			return nil, err
+		}
+
+		bounds = contour.Data.Bounds()
+	case bbox != nil:
+		bounds = *bbox
+	default:
+		return nil, constants.ErrInvalidBBox
+	}
+
+	grid = grid.AtLatitude((bounds.MinLat + bounds.MaxLat) / 2)
+	if grid.CellCount(bounds) > models.MaxGridCells {
+		return nil, constants.ErrTooManyCells
+	}
+
+	cells, err := s.pointRepo.GetPointsGrid(ctx, grid, bbox, contourID)
+	if errors.Is(err, constants.ErrGridUnsupported) {
+		return s.aggregatePoints(ctx, grid, bbox, contourID)
+	}
+
+	return cells, err
+}
+
+// aggregatePoints bins the points in Go, for PostGIS versions before 3.1 that
+// lack the grid functions. The points are still read from PostGIS, only the
+// binning is done here. It yields the same cells as the database would.
+func (s *GeometryServiceImpl) aggregatePoints(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error) {
+	counts := make(map[[2]int]int)
+
+	err := s.pointRepo.StreamPoints(ctx, bbox, contourID, func(point *models.Point) error {
+		i, j := grid.CellAt(models.Mercator(point.Data.PointCoordinates[0], point.Data.PointCoordinates[1]))
+		counts[[2]int{i, j}]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([]models.Cell, 0, len(counts))
+	for key, count := range counts {
+		cells = append(cells, models.Cell{I: key[0], J: key[1], Data: grid.CellPolygon(key[0], key[1]), Count: count})
+	}
+
+	sort.Slice(cells, func(a, b int) bool {
+		if cells[a].I != cells[b].I {
+			return cells[a].I < cells[b].I
+		}
+
+		return cells[a].J < cells[b].J
+	})
+
+	return cells, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_AggregatePoints(t *testing.T) {
+	hex := models.Grid{Type: models.GridHex, Size: 1000}
+	bbox := &models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6}
+	contour := &models.Contour{ID: 1, Data: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}}},
+	}}
+	cells := []models.Cell{{I: 371, J: 321, Count: 2}}
+
+	tests := []struct {
+		name          string
+		grid          models.Grid
+		bbox          *models.BBox
+		contourID     uint
+		mocks         func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository)
+		expected      []models.Cell
+		expectedError error
+	}{
+		{
+			name: "Grid",
+			grid: hex,
+			bbox: bbox,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointsGrid(gomock.Any(), hex.AtLatitude(5), bbox, uint(0)).Return(cells, nil).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+			expected: cells,
+		},
+		{
+			name:      "GridInsideContour",
+			grid:      hex,
+			contourID: 1,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(contour, nil).Times(1)
+				mockPointRepo.EXPECT().GetPointsGrid(gomock.Any(), hex.AtLatitude(5), nil, uint(1)).Return(cells, nil).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+			expected: cells,
+		},
+		{
+			name:      "ContourNotFound",
+			grid:      hex,
+			contourID: 2,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(2)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mock_repository.NewMockPointRepository(ctrl), mockContourRepo
+			},
+			expectedError: constants.ErrContourNotFound,
+		},
+		{
+			name: "Contours",
+			grid: models.Grid{Type: models.GridContour},
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContoursPointCount(gomock.Any(), nil).Return([]models.Cell{{ContourID: 1, Count: 3}}, nil).Times(1)
+				return mock_repository.NewMockPointRepository(ctrl), mockContourRepo
+			},
+			expected: []models.Cell{{ContourID: 1, Count: 3}},
+		},
+		{
+			name: "MissingBBox",
+			grid: hex,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidBBox,
+		},
+		{
+			name: "TooManyCells",
+			grid: models.Grid{Type: models.GridSquare, Size: 10},
+			bbox: bbox,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrTooManyCells,
+		},
+		{
+			name: "InvalidGrid",
+			grid: models.Grid{Type: "triangle", Size: 10},
+			bbox: bbox,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidGrid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPointRepo, mockContourRepo := tt.mocks()
+			svc := NewGeometryService(mockPointRepo, mockContourRepo)
+
+			cells, err := svc.AggregatePoints(context.Background(), tt.grid, tt.bbox, tt.contourID)
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, cells)
+		})
+	}
+}
+
+func TestGeometryService_AggregatePointsFallback(t *testing.T) {
+	grid := models.Grid{Type: models.GridHex, Size: 1000}
+	bbox := &models.BBox{MinLon: 4, MinLat: 4, MaxLon: 6, MaxLat: 6}
+
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().GetPointsGrid(gomock.Any(), grid.AtLatitude(5), bbox, uint(0)).Return(nil, constants.ErrGridUnsupported).Times(1)
+	mockPointRepo.EXPECT().StreamPoints(gomock.Any(), bbox, uint(0), gomock.Any()).
+		DoAndReturn(streamPoints(newPoint(1, 5.5, 5.5), newPoint(2, 5.5001, 5.5001), newPoint(3, 4.5, 4.5))).
+		Times(1)
+
+	svc := NewGeometryService(mockPointRepo, nil)
+
+	cells, err := svc.AggregatePoints(context.Background(), grid, bbox, 0)
+	assert.NoError(t, err)
+	assert.Len(t, cells, 2)
+
+	// Cells are ordered by column, the south western one comes first.
+	assert.Equal(t, 1, cells[0].Count)
+	assert.Equal(t, 2, cells[1].Count)
+	i, j := grid.AtLatitude(5).CellAt(models.Mercator(5.5, 5.5))
+	assert.Equal(t, i, cells[1].I)
+	assert.Equal(t, j, cells[1].J)
+	assert.Equal(t, grid.AtLatitude(5).CellPolygon(i, j), cells[1].Data)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// BufferPoint returns the buffer of a point as a new contour, stored when
+// persist is set. Points cannot be shrunk.
+func (s *GeometryServiceImpl) BufferPoint(ctx context.Context, id uint, buffer models.Buffer, persist bool) (*models.Contour, error) {
+	if err := buffer.Validate(); err != nil {
+		return nil, err
+	}
+
+	if buffer.Distance < 0 {
+		return nil, constants.ErrInvalidDistance
+	}
+
+	contour, err := s.pointRepo.GetPointBuffer(ctx, id, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveBuffer(ctx, contour, persist)
+}
+
+// BufferContour returns the buffer of a contour as a new contour, stored when
+// persist is set. A negative distance shrinks the contour.
+func (s *GeometryServiceImpl) BufferContour(ctx context.Context, id uint, buffer models.Buffer, persist bool) (*models.Contour, error) {
+	if err := buffer.Validate(); err != nil {
+		return nil, err
+	}
+
+	contour, err := s.contourRepo.GetContourBuffer(ctx, id, buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveBuffer(ctx, contour, persist)
+}
+
+// saveBuffer rejects buffers shrunk to nothing and stores the others when
+// persist is set, in the layer the contours of ctx are scoped to, if any.
+// Shrinking may split a contour, such buffers are returned but cannot be
+// stored as a contour.
+func (s *GeometryServiceImpl) saveBuffer(ctx context.Context, contour *models.Contour, persist bool) (*models.Contour, error) {
+	if len(contour.Data.PolygonCoordinates) == 0 && len(contour.Data.MultiPolygonCoordinates) == 0 {
+		return nil, constants.ErrEmptyBuffer
+	}
+
+	if !persist {
+		return contour, nil
+	}
+
+	if !contour.Data.IsPolygon() {
+		return nil, constants.ErrBufferNotPolygon
+	}
+
+	if err := s.CreateContour(ctx, contour); err != nil {
+		return nil, err
+	}
+
+	return contour, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func square() *models.Contour {
+	return &models.Contour{Data: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+	}}
+}
+
+func TestGeometryService_BufferPoint(t *testing.T) {
+	buffer := models.Buffer{Distance: 100, Segments: 8, EndCap: models.EndCapRound}
+	layer := uint(2)
+
+	tests := []struct {
+		name          string
+		buffer        models.Buffer
+		persist       bool
+		mocks         func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository)
+		expectedID    uint
+		expectedError error
+	}{
+		{
+			name:   "ReturnsBuffer",
+			buffer: buffer,
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointBuffer(gomock.Any(), uint(1), buffer).Return(square(), nil).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+		},
+		{
+			name:    "PersistsBuffer",
+			buffer:  buffer,
+			persist: true,
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointBuffer(gomock.Any(), uint(1), buffer).Return(square(), nil).Times(1)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().CreateContour(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, contour *models.Contour) error {
+					assert.Equal(t, &layer, contour.LayerID)
+					contour.ID = 7
+					return nil
+				}).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+			expectedID: 7,
+		},
+		{
+			name:   "NegativeDistance",
+			buffer: models.Buffer{Distance: -100, Segments: 8, EndCap: models.EndCapRound},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidDistance,
+		},
+		{
+			name:   "InvalidEndCap",
+			buffer: models.Buffer{Distance: 100, Segments: 8, EndCap: "butt"},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidEndCap,
+		},
+		{
+			name:   "PointNotFound",
+			buffer: buffer,
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointBuffer(gomock.Any(), uint(1), buffer).Return(nil, constants.ErrPointNotFound).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrPointNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(gomock.NewController(t)))
+
+			contour, err := svc.BufferPoint(models.WithLayerScope(context.Background(), models.LayerScope{Contours: &layer}), 1, tt.buffer, tt.persist)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, contour.ID)
+			assert.Equal(t, square().Data, contour.Data)
+		})
+	}
+}
+
+func TestGeometryService_BufferContour(t *testing.T) {
+	shrink := models.Buffer{Distance: -100, Segments: 8, EndCap: models.EndCapRound}
+	split := &models.Contour{Data: models.Geometry{
+		Type:                    models.MultiPolygon,
+		MultiPolygonCoordinates: [][][][2]float64{square().Data.PolygonCoordinates, square().Data.PolygonCoordinates},
+	}}
+
+	tests := []struct {
+		name          string
+		persist       bool
+		result        *models.Contour
+		stored        bool
+		expectedError error
+	}{
+		{name: "ShrinksContour", result: square()},
+		{name: "PersistsShrunkContour", persist: true, result: square(), stored: true},
+		{name: "ReturnsSplitContour", result: split},
+		{name: "RejectsStoringSplitContour", persist: true, result: split, expectedError: constants.ErrBufferNotPolygon},
+		{
+			name:          "RejectsEmptyBuffer",
+			result:        &models.Contour{Data: models.Geometry{Type: models.PolygonType}},
+			expectedError: constants.ErrEmptyBuffer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+			mockContourRepo.EXPECT().GetContourBuffer(gomock.Any(), uint(1), shrink).Return(tt.result, nil).Times(1)
+			if tt.stored {
+				mockContourRepo.EXPECT().CreateContour(gomock.Any(), tt.result).Return(nil).Times(1)
+			}
+
+			svc := NewGeometryService(nil, mockContourRepo)
+
+			contour, err := svc.BufferContour(context.Background(), 1, shrink, tt.persist)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, contour)
+		})
+	}
+}
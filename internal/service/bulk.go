@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+type BulkMode string
+
+const (
+	// BulkModeAtomic inserts every feature or none of them.
+	BulkModeAtomic BulkMode = "atomic"
+	// BulkModeBestEffort inserts every valid feature and reports the rest.
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+func (m BulkMode) IsValid() bool {
+	return m == BulkModeAtomic || m == BulkModeBestEffort
+}
+
+// BulkResult is the outcome of a single feature of a bulk import, Index being
+// its position in the submitted payload, Line its line in line oriented
+// payloads and Record its record number in record oriented ones.
+type BulkResult struct {
+	Index  int
+	Line   int
+	Record int
+	ID     uint
+	Err    error
+}
+
+// BulkCreatePoints creates points from features, in the layer the points of
+// ctx are scoped to, if any.
+func (s *GeometryServiceImpl) BulkCreatePoints(ctx context.Context, features []codec.Feature, mode BulkMode) ([]BulkResult, error) {
+	results, indexes := validateFeatures(features, models.PointType)
+	if mode == BulkModeAtomic && len(indexes) != len(features) {
+		return results, nil
+	}
+
+	layer := models.LayerScopeFrom(ctx).Points
+	points := make([]models.Point, len(indexes))
+	for i, idx := range indexes {
+		points[i] = models.Point{Data: features[idx].Geometry, Properties: features[idx].Properties, LayerID: layer}
+	}
+
+	errs, err := insertBulk(ctx, points, mode, s.pointRepo.CreatePoints, s.pointRepo.CreatePoint)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, idx := range indexes {
+		results[idx].ID = points[i].ID
+		results[idx].Err = errs[i]
+		if errs[i] == nil {
+			s.clusters.insert(&points[i])
+		}
+	}
+
+	return results, nil
+}
+
+// BulkCreateContours creates contours from features, in the layer the
+// contours of ctx are scoped to, if any.
+func (s *GeometryServiceImpl) BulkCreateContours(ctx context.Context, features []codec.Feature, mode BulkMode) ([]BulkResult, error) {
+	results, indexes := validateFeatures(features, models.PolygonType)
+	if mode == BulkModeAtomic && len(indexes) != len(features) {
+		return results, nil
+	}
+
+	layer := models.LayerScopeFrom(ctx).Contours
+	contours := make([]models.Contour, len(indexes))
+	for i, idx := range indexes {
+		contours[i] = models.Contour{Data: features[idx].Geometry, Properties: features[idx].Properties, LayerID: layer}
+	}
+
+	errs, err := insertBulk(ctx, contours, mode, s.contourRepo.CreateContours, s.contourRepo.CreateContour)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, idx := range indexes {
+		results[idx].ID = contours[i].ID
+		results[idx].Err = errs[i]
+	}
+
+	return results, nil
+}
+
+// validateFeatures checks every feature against the expected geometry type and
+// Geometry.Validate. It returns one result per feature and the indexes of the
+// features that passed.
+func validateFeatures(features []codec.Feature, geometryType models.Type) ([]BulkResult, []int) {
+	results := make([]BulkResult, len(features))
+	indexes := make([]int, 0, len(features))
+
+	for i, f := range features {
+		results[i].Index = i
+		results[i].Line = f.Line
+		results[i].Record = f.Record
+
+		switch {
+		case f.Err != nil:
+			results[i].Err = f.Err
+		case f.Geometry.Type != geometryType:
+			results[i].Err = constants.ErrInvalidGeometryType
+		default:
+			results[i].Err = f.Geometry.Validate()
+		}
+
+		if results[i].Err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return results, indexes
+}
+
+// insertBulk writes items in chunks of constants.BulkChunkSize. In atomic mode
+// all chunks share one transaction and any failure is returned as is. In best
+// effort mode every chunk is committed on its own, and a failing chunk is
+// retried row by row so the offending rows can be reported individually,
+// unless the context is done.
+func insertBulk[T any](ctx context.Context, items []T, mode BulkMode,
+	createMany func(context.Context, []T, int) error, createOne func(context.Context, *T) error,
+) ([]error, error) {
+	errs := make([]error, len(items))
+	if len(items) == 0 {
+		return errs, nil
+	}
+
+	if mode == BulkModeAtomic {
+		return errs, createMany(ctx, items, constants.BulkChunkSize)
+	}
+
+	for start := 0; start < len(items); start += constants.BulkChunkSize {
+		end := min(start+constants.BulkChunkSize, len(items))
+		if err := createMany(ctx, items[start:end], constants.BulkChunkSize); err == nil {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for i := start; i < end; i++ {
+			errs[i] = createOne(ctx, &items[i])
+		}
+	}
+
+	return errs, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_BulkCreatePoints(t *testing.T) {
+	validFeature := codec.Feature{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{125.6, 10.1}}}
+	outOfRangeFeature := codec.Feature{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{125.6, 200.1}}}
+	polygonFeature := codec.Feature{Geometry: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+	}}
+
+	tests := []struct {
+		name         string
+		features     []codec.Feature
+		mode         BulkMode
+		mocks        func() *mock_repository.MockPointRepository
+		expectedIDs  []uint
+		expectedErrs []error
+		wantErr      bool
+	}{
+		{
+			name:     "AtomicAllValid",
+			features: []codec.Feature{validFeature, validFeature},
+			mode:     BulkModeAtomic,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().CreatePoints(gomock.Any(), gomock.Len(2), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, points []models.Point, _ int) error {
+					points[0].ID = 1
+					points[1].ID = 2
+					return nil
+				}).Times(1)
+				return mockPointRepo
+			},
+			expectedIDs:  []uint{1, 2},
+			expectedErrs: []error{nil, nil},
+		},
+		{
+			name:     "AtomicRejectsInvalid",
+			features: []codec.Feature{validFeature, outOfRangeFeature, polygonFeature, {Err: constants.ErrInvalidFeature}},
+			mode:     BulkModeAtomic,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				return mock_repository.NewMockPointRepository(ctrl)
+			},
+			expectedIDs:  []uint{0, 0, 0, 0},
+			expectedErrs: []error{nil, constants.ErrCoordinatesOutOfRange, constants.ErrInvalidGeometryType, constants.ErrInvalidFeature},
+		},
+		{
+			name:     "AtomicRepositoryError",
+			features: []codec.Feature{validFeature},
+			mode:     BulkModeAtomic,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().CreatePoints(gomock.Any(), gomock.Any(), gomock.Any()).Return(constants.ErrInternal).Times(1)
+				return mockPointRepo
+			},
+			wantErr: true,
+		},
+		{
+			name:     "BestEffortSkipsInvalid",
+			features: []codec.Feature{outOfRangeFeature, validFeature},
+			mode:     BulkModeBestEffort,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().CreatePoints(gomock.Any(), gomock.Len(1), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, points []models.Point, _ int) error {
+					points[0].ID = 7
+					return nil
+				}).Times(1)
+				return mockPointRepo
+			},
+			expectedIDs:  []uint{0, 7},
+			expectedErrs: []error{constants.ErrCoordinatesOutOfRange, nil},
+		},
+		{
+			name:     "BestEffortRetriesFailedChunk",
+			features: []codec.Feature{validFeature, validFeature},
+			mode:     BulkModeBestEffort,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().CreatePoints(gomock.Any(), gomock.Len(2), constants.BulkChunkSize).Return(constants.ErrInternal).Times(1)
+				mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+					point.ID = 3
+					return nil
+				}).Times(1)
+				mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).Return(constants.ErrInternal).Times(1)
+				return mockPointRepo
+			},
+			expectedIDs:  []uint{3, 0},
+			expectedErrs: []error{nil, constants.ErrInternal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(), nil)
+
+			results, err := svc.BulkCreatePoints(context.Background(), tt.features, tt.mode)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, results, len(tt.features))
+			for i, r := range results {
+				assert.Equal(t, i, r.Index)
+				assert.Equal(t, tt.expectedIDs[i], r.ID)
+				assert.ErrorIs(t, r.Err, tt.expectedErrs[i])
+			}
+		})
+	}
+}
+
+func TestGeometryService_BulkCreatePointsCancelled(t *testing.T) {
+	feature := codec.Feature{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{125.6, 10.1}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().CreatePoints(ctx, gomock.Len(2), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, _ []models.Point, _ int) error {
+		cancel()
+		return context.Canceled
+	}).Times(1)
+	svc := NewGeometryService(mockPointRepo, nil)
+
+	// Best effort stops instead of retrying every row of the chunk.
+	_, err := svc.BulkCreatePoints(ctx, []codec.Feature{feature, feature}, BulkModeBestEffort)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGeometryService_BulkCreateContours(t *testing.T) {
+	validFeature := codec.Feature{Geometry: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+	}}
+	openFeature := codec.Feature{Geometry: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}}},
+	}}
+
+	tests := []struct {
+		name         string
+		features     []codec.Feature
+		mode         BulkMode
+		mocks        func() *mock_repository.MockContourRepository
+		expectedIDs  []uint
+		expectedErrs []error
+	}{
+		{
+			name:     "AtomicAllValid",
+			features: []codec.Feature{validFeature},
+			mode:     BulkModeAtomic,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().CreateContours(gomock.Any(), gomock.Len(1), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, contours []models.Contour, _ int) error {
+					contours[0].ID = 1
+					return nil
+				}).Times(1)
+				return mockContourRepo
+			},
+			expectedIDs:  []uint{1},
+			expectedErrs: []error{nil},
+		},
+		{
+			name: "KeepsPropertiesAndRecord",
+			features: []codec.Feature{{
+				Geometry:   validFeature.Geometry,
+				Properties: map[string]any{"NAME": "North parcel"},
+				Record:     3,
+			}},
+			mode: BulkModeAtomic,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().CreateContours(gomock.Any(), gomock.Len(1), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, contours []models.Contour, _ int) error {
+					assert.Equal(t, models.Properties{"NAME": "North parcel"}, contours[0].Properties)
+					contours[0].ID = 1
+					return nil
+				}).Times(1)
+				return mockContourRepo
+			},
+			expectedIDs:  []uint{1},
+			expectedErrs: []error{nil},
+		},
+		{
+			name:     "BestEffortSkipsInvalid",
+			features: []codec.Feature{openFeature, validFeature},
+			mode:     BulkModeBestEffort,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().CreateContours(gomock.Any(), gomock.Len(1), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, contours []models.Contour, _ int) error {
+					contours[0].ID = 2
+					return nil
+				}).Times(1)
+				return mockContourRepo
+			},
+			expectedIDs:  []uint{0, 2},
+			expectedErrs: []error{constants.ErrInvalidContours, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(nil, tt.mocks())
+
+			results, err := svc.BulkCreateContours(context.Background(), tt.features, tt.mode)
+			assert.NoError(t, err)
+			assert.Len(t, results, len(tt.features))
+			for i, r := range results {
+				assert.Equal(t, tt.expectedIDs[i], r.ID)
+				assert.Equal(t, tt.features[i].Record, r.Record)
+				assert.ErrorIs(t, r.Err, tt.expectedErrs[i])
+			}
+		})
+	}
+}
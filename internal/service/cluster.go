@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/supercluster"
+)
+
+// clusterIndex keeps supercluster indexes in sync with the points table: one
+// of every point, under layer 0, and one per layer clustered on its own. An
+// index is loaded from the repository by the first clustering query on it,
+// writes made before that are left to the load to pick up.
+type clusterIndex struct {
+	mu      sync.Mutex
+	options supercluster.Options
+	indexes map[uint]*supercluster.Index
+}
+
+func newClusterIndex(options supercluster.Options) *clusterIndex {
+	return &clusterIndex{options: options, indexes: make(map[uint]*supercluster.Index)}
+}
+
+// insert adds or moves the point in the index of every point and in the one
+// of its layer, and drops it from the indexes of the other layers.
+func (c *clusterIndex) insert(point *models.Point) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !point.Data.IsPoint() {
+		return
+	}
+
+	for layer, index := range c.indexes {
+		if layer == 0 || point.LayerID != nil && *point.LayerID == layer {
+			index.Insert(clusterPoint(point))
+		} else {
+			index.Remove(point.ID)
+		}
+	}
+}
+
+func (c *clusterIndex) remove(id uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, index := range c.indexes {
+		index.Remove(id)
+	}
+}
+
+// load returns the index of the layer, 0 for every point, streaming its
+// points into it unless it is already loaded. Writes wait for the load, so
+// none of them can be overwritten by it.
+func (c *clusterIndex) load(layer uint, stream func(fn func(*models.Point) error) error) (*supercluster.Index, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if index, ok := c.indexes[layer]; ok {
+		return index, nil
+	}
+
+	points := make([]supercluster.Point, 0)
+	err := stream(func(point *models.Point) error {
+		points = append(points, clusterPoint(point))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	index := supercluster.New(c.options)
+	index.Load(points)
+	c.indexes[layer] = index
+
+	return index, nil
+}
+
+func clusterPoint(point *models.Point) supercluster.Point {
+	return supercluster.Point{ID: point.ID, Lon: point.Data.PointCoordinates[0], Lat: point.Data.PointCoordinates[1]}
+}
+
+// GetPointClusters returns the point clusters of the zoom level whose centroid
+// lies in the bounding box. The points of a layer are clustered on their own.
+func (s *GeometryServiceImpl) GetPointClusters(ctx context.Context, bbox models.BBox, zoom int) ([]supercluster.Cluster, error) {
+	if err := bbox.Validate(); err != nil {
+		return nil, err
+	}
+
+	if zoom < 0 || zoom > models.MaxTileZoom {
+		return nil, constants.ErrInvalidZoom
+	}
+
+	var layer uint
+	if scoped := models.LayerScopeFrom(ctx).Points; scoped != nil {
+		layer = *scoped
+	}
+
+	index, err := s.clusters.load(layer, func(fn func(*models.Point) error) error {
+		return s.pointRepo.StreamPoints(ctx, nil, 0, fn)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Clusters(bbox.MinLon, bbox.MinLat, bbox.MaxLon, bbox.MaxLat, zoom), nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func world() models.BBox {
+	return models.BBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+}
+
+func newPoint(id uint, lon, lat float64) models.Point {
+	return models.Point{ID: id, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{lon, lat}}}
+}
+
+func streamPoints(points ...models.Point) func(context.Context, *models.BBox, uint, func(*models.Point) error) error {
+	return func(_ context.Context, _ *models.BBox, _ uint, fn func(*models.Point) error) error {
+		for i := range points {
+			if err := fn(&points[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestGeometryService_GetPointClusters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().StreamPoints(gomock.Any(), nil, uint(0), gomock.Any()).
+		DoAndReturn(streamPoints(newPoint(1, 106.8271, -6.1754), newPoint(2, 106.8272, -6.1755), newPoint(3, 2.3522, 48.8566))).
+		Times(1)
+
+	svc := NewGeometryService(mockPointRepo, nil)
+
+	clusters, err := svc.GetPointClusters(context.Background(), world(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 2)
+
+	clusters, err = svc.GetPointClusters(context.Background(), models.BBox{MinLon: 100, MinLat: -10, MaxLon: 110, MaxLat: 0}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, 2, clusters[0].Count)
+	assert.Greater(t, clusters[0].ExpansionZoom, 0)
+}
+
+func TestGeometryService_GetPointClustersSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().StreamPoints(gomock.Any(), nil, uint(0), gomock.Any()).
+		DoAndReturn(streamPoints(newPoint(1, 106.8271, -6.1754))).
+		Times(1)
+	mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+		point.ID = 2
+		return nil
+	}).Times(1)
+	mockPointRepo.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockPointRepo.EXPECT().DeletePoint(gomock.Any(), uint(1), uint(0)).Return(nil).Times(1)
+
+	svc := NewGeometryService(mockPointRepo, nil)
+	bbox := models.BBox{MinLon: 100, MinLat: -10, MaxLon: 110, MaxLat: 0}
+
+	clusters, err := svc.GetPointClusters(context.Background(), bbox, 0)
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, uint(1), clusters[0].PointID)
+
+	point := newPoint(0, 106.8272, -6.1755)
+	assert.NoError(t, svc.CreatePoint(context.Background(), &point))
+
+	clusters, err = svc.GetPointClusters(context.Background(), bbox, 0)
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, 2, clusters[0].Count)
+
+	point = newPoint(2, 2.3522, 48.8566)
+	assert.NoError(t, svc.UpdatePoint(context.Background(), &point))
+	assert.NoError(t, svc.DeletePoint(context.Background(), 1, 0))
+
+	clusters, err = svc.GetPointClusters(context.Background(), world(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, uint(2), clusters[0].PointID)
+}
+
+func TestGeometryService_GetPointClustersErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		bbox          models.BBox
+		zoom          int
+		mocks         func() *mock_repository.MockPointRepository
+		expectedError error
+	}{
+		{
+			name: "InvalidZoom",
+			bbox: world(),
+			zoom: models.MaxTileZoom + 1,
+			mocks: func() *mock_repository.MockPointRepository {
+				return mock_repository.NewMockPointRepository(gomock.NewController(t))
+			},
+			expectedError: constants.ErrInvalidZoom,
+		},
+		{
+			name: "InvalidBBox",
+			bbox: models.BBox{MinLon: 10, MinLat: 0, MaxLon: 0, MaxLat: 10},
+			mocks: func() *mock_repository.MockPointRepository {
+				return mock_repository.NewMockPointRepository(gomock.NewController(t))
+			},
+			expectedError: constants.ErrInvalidBBox,
+		},
+		{
+			name: "RepositoryError",
+			bbox: world(),
+			mocks: func() *mock_repository.MockPointRepository {
+				mockPointRepo := mock_repository.NewMockPointRepository(gomock.NewController(t))
+				mockPointRepo.EXPECT().StreamPoints(gomock.Any(), nil, uint(0), gomock.Any()).Return(constants.ErrInternal).Times(1)
+				return mockPointRepo
+			},
+			expectedError: constants.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(), nil)
+
+			_, err := svc.GetPointClusters(context.Background(), tt.bbox, tt.zoom)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+)
+
+type DuplicatePolicy string
+
+const (
+	// DuplicateAllow creates points whatever the points around them.
+	DuplicateAllow DuplicatePolicy = "allow"
+	// DuplicateReject refuses to create a duplicate of an existing point.
+	DuplicateReject DuplicatePolicy = "reject"
+	// DuplicateReturn returns the existing point instead of a duplicate.
+	DuplicateReturn DuplicatePolicy = "return"
+)
+
+func (p DuplicatePolicy) IsValid() bool {
+	return p == DuplicateAllow || p == DuplicateReject || p == DuplicateReturn
+}
+
+// DuplicateGroup is a cluster of near-identical points, merged into the
+// oldest one, Kept.
+type DuplicateGroup struct {
+	Kept   uint
+	Merged []uint
+}
+
+// CreateUniquePoint creates a point unless it duplicates an existing one, at
+// most tolerance metres away, or at the same position when tolerance is
+// zero. The duplicate is then rejected with constants.ErrDuplicatePoint
+// carrying the id of the existing point, or point is set to the existing
+// point, as told by policy. It returns whether the point was created. The
+// check and the creation share a transaction, so the service must be built
+// WithTxManager.
+func (s *GeometryServiceImpl) CreateUniquePoint(ctx context.Context, point *models.Point, policy DuplicatePolicy, tolerance float64) (bool, error) {
+	if policy == DuplicateAllow {
+		return true, s.CreatePoint(ctx, point)
+	}
+
+	if tolerance < 0 {
+		return false, constants.ErrInvalidDistance.At("tolerance")
+	}
+
+	if err := point.Data.Validate(); err != nil {
+		return false, invalidData(constants.ErrInvalidPoint, err)
+	}
+
+	point.LayerID = models.LayerScopeFrom(ctx).Points
+	created := false
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		existing, err := repos.Points.GetDuplicatePoint(ctx, point, tolerance)
+		switch {
+		case errors.Is(err, constants.ErrPointNotFound):
+			created = true
+			return repos.Points.CreatePoint(ctx, point)
+		case err != nil:
+			return err
+		case policy == DuplicateReject:
+			return constants.ErrDuplicatePoint.WithDetails(map[string]any{"id": existing.ID})
+		}
+
+		*point = *existing
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if created {
+		s.clusters.insert(point)
+	}
+
+	s.trimGeohash(point)
+	return created, nil
+}
+
+// DedupePoints merges the clusters of points within tolerance metres of one
+// another, transitively, or at the same position when tolerance is zero.
+// Each cluster is merged into its oldest point, which gains the properties
+// it lacks from the others, in the order they were created, while the
+// others are moved to the trash. A dry run only reports the clusters. Points
+// cannot be created or changed while the clusters are found and merged, in
+// one transaction, so the service must be built WithTxManager.
+func (s *GeometryServiceImpl) DedupePoints(ctx context.Context, tolerance float64, dryRun bool) ([]DuplicateGroup, error) {
+	if tolerance < 0 {
+		return nil, constants.ErrInvalidDistance.At("tolerance")
+	}
+
+	var groups []DuplicateGroup
+	var kept []models.Point
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		pairs, err := repos.Points.GetDuplicatePointPairs(ctx, tolerance)
+		if err != nil {
+			return err
+		}
+
+		groups = groupDuplicates(pairs)
+		if dryRun || len(groups) == 0 {
+			return nil
+		}
+
+		kept, err = mergeDuplicates(ctx, repos.Points, groups)
+		return err
+	})
+	if err != nil || dryRun {
+		return groups, err
+	}
+
+	for i := range kept {
+		s.clusters.insert(&kept[i])
+	}
+
+	for _, group := range groups {
+		for _, id := range group.Merged {
+			s.clusters.remove(id)
+		}
+	}
+
+	return groups, nil
+}
+
+// groupDuplicates joins pairs of duplicates into clusters, ordered by their
+// oldest point.
+func groupDuplicates(pairs [][2]uint) []DuplicateGroup {
+	roots := make(map[uint]uint)
+	var root func(id uint) uint
+	root = func(id uint) uint {
+		parent, ok := roots[id]
+		if !ok || parent == id {
+			roots[id] = id
+			return id
+		}
+
+		roots[id] = root(parent)
+		return roots[id]
+	}
+
+	for _, pair := range pairs {
+		a, b := root(pair[0]), root(pair[1])
+		roots[max(a, b)] = min(a, b)
+	}
+
+	members := make(map[uint][]uint)
+	for id := range roots {
+		r := root(id)
+		if id != r {
+			members[r] = append(members[r], id)
+		}
+	}
+
+	groups := make([]DuplicateGroup, 0, len(members))
+	for kept, merged := range members {
+		sort.Slice(merged, func(i, j int) bool { return merged[i] < merged[j] })
+		groups = append(groups, DuplicateGroup{Kept: kept, Merged: merged})
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Kept < groups[j].Kept })
+	return groups
+}
+
+// mergeDuplicates merges each group into its kept point and returns the kept
+// points that gained properties. Points are written at the version read, so
+// a point changed in between fails the merge with
+// constants.ErrVersionMismatch.
+func mergeDuplicates(ctx context.Context, repo repository.PointRepository, groups []DuplicateGroup) ([]models.Point, error) {
+	ids := make([]uint, 0)
+	for _, group := range groups {
+		ids = append(append(ids, group.Kept), group.Merged...)
+	}
+
+	points, err := repo.GetPointsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*models.Point, len(points))
+	for i := range points {
+		byID[points[i].ID] = &points[i]
+	}
+
+	updated := make([]models.Point, 0)
+	for _, group := range groups {
+		point, ok := byID[group.Kept]
+		if !ok {
+			return nil, constants.ErrPointNotFound
+		}
+
+		changed := false
+		for _, id := range group.Merged {
+			duplicate, ok := byID[id]
+			if !ok {
+				return nil, constants.ErrPointNotFound
+			}
+
+			for key, value := range duplicate.Properties {
+				if _, ok := point.Properties[key]; !ok {
+					if point.Properties == nil {
+						point.Properties = models.Properties{}
+					}
+
+					point.Properties[key], changed = value, true
+				}
+			}
+
+			if err := repo.DeletePoint(ctx, id, duplicate.Version); err != nil {
+				return nil, err
+			}
+		}
+
+		if changed {
+			if err := repo.UpdatePoint(ctx, point); err != nil {
+				return nil, err
+			}
+
+			updated = append(updated, *point)
+		}
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGroupDuplicates(t *testing.T) {
+	assert.Empty(t, groupDuplicates(nil))
+	assert.Equal(t, []DuplicateGroup{
+		{Kept: 1, Merged: []uint{2, 3, 7}},
+		{Kept: 4, Merged: []uint{5}},
+	}, groupDuplicates([][2]uint{{4, 5}, {3, 7}, {2, 3}, {1, 2}}))
+}
+
+func TestGeometryService_CreateUniquePoint(t *testing.T) {
+	existing := &models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{30, 10}}, Version: 2}
+
+	tests := []struct {
+		name            string
+		policy          DuplicatePolicy
+		mocks           func() *mock_repository.MockPointRepository
+		expectedCreated bool
+		expectedID      uint
+		expectedError   error
+	}{
+		{
+			name:   "Created",
+			policy: DuplicateReject,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetDuplicatePoint(gomock.Any(), gomock.Any(), 5.0).Return(nil, constants.ErrPointNotFound).Times(1)
+				mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+					point.ID = 2
+					return nil
+				}).Times(1)
+				return mockPointRepo
+			},
+			expectedCreated: true,
+			expectedID:      2,
+		},
+		{
+			name:   "Rejected",
+			policy: DuplicateReject,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetDuplicatePoint(gomock.Any(), gomock.Any(), 5.0).Return(existing, nil).Times(1)
+				return mockPointRepo
+			},
+			expectedError: constants.ErrDuplicatePoint,
+		},
+		{
+			name:   "Returned",
+			policy: DuplicateReturn,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetDuplicatePoint(gomock.Any(), gomock.Any(), 5.0).Return(existing, nil).Times(1)
+				return mockPointRepo
+			},
+			expectedID: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(), nil)
+
+			point := &models.Point{Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{30.00001, 10}}}
+			created, err := svc.CreateUniquePoint(context.Background(), point, tt.policy, 5)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Equal(t, tt.expectedID, point.ID)
+		})
+	}
+}
+
+func TestGeometryService_DedupePoints(t *testing.T) {
+	position := models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{30, 10}}
+	points := func() []models.Point {
+		return []models.Point{
+			{ID: 1, Data: position, Properties: models.Properties{"name": "well"}, Version: 1},
+			{ID: 2, Data: position, Properties: models.Properties{"name": "other", "depth": 12.0}, Version: 3},
+			{ID: 3, Data: position, Version: 1},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		dryRun bool
+		mocks  func() *mock_repository.MockPointRepository
+	}{
+		{
+			name:   "DryRun",
+			dryRun: true,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetDuplicatePointPairs(gomock.Any(), 0.0).Return([][2]uint{{1, 2}, {2, 3}}, nil).Times(1)
+				return mockPointRepo
+			},
+		},
+		{
+			name: "Merged",
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetDuplicatePointPairs(gomock.Any(), 0.0).Return([][2]uint{{1, 2}, {2, 3}}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPointsByIDs(gomock.Any(), []uint{1, 2, 3}).Return(points(), nil).Times(1)
+				mockPointRepo.EXPECT().DeletePoint(gomock.Any(), uint(2), uint(3)).Return(nil).Times(1)
+				mockPointRepo.EXPECT().DeletePoint(gomock.Any(), uint(3), uint(1)).Return(nil).Times(1)
+				mockPointRepo.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+					assert.Equal(t, uint(1), point.ID)
+					assert.Equal(t, uint(1), point.Version)
+					assert.Equal(t, models.Properties{"name": "well", "depth": 12.0}, point.Properties)
+					return nil
+				}).Times(1)
+				return mockPointRepo
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(), nil)
+
+			groups, err := svc.DedupePoints(context.Background(), 0, tt.dryRun)
+			assert.NoError(t, err)
+			assert.Equal(t, []DuplicateGroup{{Kept: 1, Merged: []uint{2, 3}}}, groups)
+		})
+	}
+
+	_, err := NewGeometryService(nil, nil).DedupePoints(context.Background(), -1, true)
+	assert.ErrorIs(t, err, constants.ErrInvalidDistance)
+}
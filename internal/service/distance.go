@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// GetPointContourDistance returns the signed distance in metres from a point
+// to the boundary of a contour, negative inside it.
+func (s *GeometryServiceImpl) GetPointContourDistance(ctx context.Context, pointID, contourID uint) (*models.Distance, error) {
+	return s.pointRepo.GetPointContourDistance(ctx, pointID, contourID)
+}
+
+// SnapPoint moves a point lying outside a contour onto its nearest boundary
+// point when it is within tolerance metres of it. A point already covered by
+// the contour is left as is. It returns the point and the distance it was
+// moved by.
+func (s *GeometryServiceImpl) SnapPoint(ctx context.Context, pointID, contourID uint, tolerance float64) (*models.Point, float64, error) {
+	if tolerance < 0 {
+		return nil, 0, constants.ErrInvalidDistance
+	}
+
+	distance, err := s.pointRepo.GetPointContourDistance(ctx, pointID, contourID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if distance.Meters > tolerance {
+		return nil, 0, constants.ErrBeyondTolerance
+	}
+
+	point, err := s.pointRepo.GetPointByID(ctx, pointID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if distance.Inside {
+		s.trimGeohash(point)
+		return point, 0, nil
+	}
+
+	point.Data = distance.Closest
+	if err := s.UpdatePoint(ctx, point); err != nil {
+		return nil, 0, err
+	}
+
+	return point, distance.Meters, nil
+}
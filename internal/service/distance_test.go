@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_GetPointContourDistance(t *testing.T) {
+	distance := &models.Distance{Meters: -12.5, Inside: true}
+
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(distance, nil).Times(1)
+	svc := NewGeometryService(mockPointRepo, nil)
+
+	result, err := svc.GetPointContourDistance(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, distance, result)
+}
+
+func TestGeometryService_SnapPoint(t *testing.T) {
+	closest := models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{10, 5}}
+
+	tests := []struct {
+		name          string
+		tolerance     float64
+		mocks         func() *mock_repository.MockPointRepository
+		expected      [2]float64
+		expectedMoved float64
+		expectedError error
+	}{
+		{
+			name:      "SnapsOutsidePoint",
+			tolerance: 50,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(&models.Distance{Meters: 33.3, Closest: closest}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(&models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{10.0003, 5}}}, nil).Times(1)
+				mockPointRepo.EXPECT().UpdatePoint(gomock.Any(), &models.Point{ID: 1, Data: closest}).Return(nil).Times(1)
+				return mockPointRepo
+			},
+			expected:      [2]float64{10, 5},
+			expectedMoved: 33.3,
+		},
+		{
+			name:      "KeepsInsidePoint",
+			tolerance: 50,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(&models.Distance{Meters: -400, Inside: true, Closest: closest}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(&models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{9, 5}}}, nil).Times(1)
+				return mockPointRepo
+			},
+			expected: [2]float64{9, 5},
+		},
+		{
+			name:      "BeyondTolerance",
+			tolerance: 10,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(&models.Distance{Meters: 33.3, Closest: closest}, nil).Times(1)
+				return mockPointRepo
+			},
+			expectedError: constants.ErrBeyondTolerance,
+		},
+		{
+			name:      "NegativeTolerance",
+			tolerance: -1,
+			mocks: func() *mock_repository.MockPointRepository {
+				return mock_repository.NewMockPointRepository(gomock.NewController(t))
+			},
+			expectedError: constants.ErrInvalidDistance,
+		},
+		{
+			name:      "NotFound",
+			tolerance: 10,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointContourDistance(gomock.Any(), uint(1), uint(2)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mockPointRepo
+			},
+			expectedError: constants.ErrContourNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(), nil)
+
+			point, moved, err := svc.SnapPoint(context.Background(), 1, 2, tt.tolerance)
+			assert.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				return
+			}
+
+			assert.Equal(t, tt.expected, point.Data.PointCoordinates)
+			assert.InDelta(t, tt.expectedMoved, moved, 1e-9)
+		})
+	}
+}
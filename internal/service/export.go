@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// ExportPoints calls fn for every point matching the filters, in the order they
+// are read from the database. A zero contourID disables the contour filter.
+func (s *GeometryServiceImpl) ExportPoints(ctx context.Context, bbox *models.BBox, contourID uint, fn func(*models.Point) error) error {
+	if contourID != 0 {
+		if _, err := s.contourRepo.GetContourByID(ctx, contourID); err != nil {
+			return err
+		}
+	}
+
+	return s.pointRepo.StreamPoints(ctx, bbox, contourID, func(point *models.Point) error {
+		s.trimGeohash(point)
+		return fn(point)
+	})
+}
+
+// ExportContours calls fn for every contour matching the filters, in the order
+// they are read from the database.
+func (s *GeometryServiceImpl) ExportContours(ctx context.Context, bbox *models.BBox, fn func(*models.Contour) error) error {
+	return s.contourRepo.StreamContours(ctx, bbox, fn)
+}
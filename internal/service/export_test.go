@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_ExportPoints(t *testing.T) {
+	bbox := &models.BBox{MinLon: 0, MinLat: 0, MaxLon: 10, MaxLat: 10}
+
+	tests := []struct {
+		name      string
+		bbox      *models.BBox
+		contourID uint
+		mocks     func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository)
+		wantErr   bool
+	}{
+		{
+			name: "WithoutContour",
+			bbox: bbox,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().StreamPoints(gomock.Any(), bbox, uint(0), gomock.Any()).Return(nil).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "WithContour",
+			contourID: 1,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
+				mockPointRepo.EXPECT().StreamPoints(gomock.Any(), nil, uint(1), gomock.Any()).Return(nil).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ContourNotFound",
+			contourID: 1,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mock_repository.NewMockPointRepository(ctrl), mockContourRepo
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPointRepo, mockContourRepo := tt.mocks()
+			svc := NewGeometryService(mockPointRepo, mockContourRepo)
+
+			err := svc.ExportPoints(context.Background(), tt.bbox, tt.contourID, func(*models.Point) error { return nil })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GeometryService.ExportPoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeometryService_ExportContours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+	mockContourRepo.EXPECT().StreamContours(gomock.Any(), nil, gomock.Any()).Return(constants.ErrInternal).Times(1)
+	svc := NewGeometryService(nil, mockContourRepo)
+
+	if err := svc.ExportContours(context.Background(), nil, func(*models.Contour) error { return nil }); err == nil {
+		t.Errorf("GeometryService.ExportContours() expected error")
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_GetPointsByGeohash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().GetPointsByGeohash(gomock.Any(), "w3gv", 0, 10).Return([]models.Point{{ID: 1, Geohash: "w3gvk1td8b6q"}}, nil).Times(1)
+	svc := NewGeometryService(mockPointRepo, nil, WithGeohashPrecision(6))
+
+	points, err := svc.GetPointsByGeohash(context.Background(), "W3GV", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Point{{ID: 1, Geohash: "w3gvk1"}}, points)
+
+	_, err = svc.GetPointsByGeohash(context.Background(), "w3ga", 0, 10)
+	assert.ErrorIs(t, err, constants.ErrInvalidGeohash)
+
+	_, err = svc.GetPointsByGeohash(context.Background(), "w3gv%", 0, 10)
+	assert.ErrorIs(t, err, constants.ErrInvalidGeohash)
+}
+
+func TestGeometryService_GeohashPrecision(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision int
+		expected  string
+	}{
+		{name: "Default", expected: "w3gvk1td8b6q"},
+		{name: "Configured", precision: 5, expected: "w3gvk"},
+		{name: "OutOfRange", precision: 13, expected: "w3gvk1td8b6q"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+			mockPointRepo.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(&models.Point{ID: 1, Geohash: "w3gvk1td8b6q"}, nil).Times(1)
+			mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+				point.Geohash = "w3gvk1td8b6q"
+				return nil
+			}).Times(1)
+			svc := NewGeometryService(mockPointRepo, nil, WithGeohashPrecision(tt.precision))
+
+			point, err := svc.GetPointByID(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, point.Geohash)
+
+			point = &models.Point{Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{106.66, 10.76}}}
+			assert.NoError(t, svc.CreatePoint(context.Background(), point))
+			assert.Equal(t, tt.expected, point.Geohash)
+		})
+	}
+}
@@ -1,106 +1,311 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/codec"
 	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/models"
 	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/pkg/geohash"
+	"github.com/malamsyah/geo-service/pkg/supercluster"
 )
 
 type GeometryService interface {
 	IsValidPoint(point *models.Point) bool
-	CreatePoint(point *models.Point) error
-	GetPoints(offset, limit int) ([]models.Point, error)
-	GetPointByID(id uint) (*models.Point, error)
+	CreatePoint(ctx context.Context, point *models.Point) error
+	GetPoints(ctx context.Context, offset, limit int) ([]models.Point, error)
+	GetPointByID(ctx context.Context, id uint) (*models.Point, error)
+	UpdatePoint(ctx context.Context, point *models.Point) error
+	DeletePoint(ctx context.Context, id, version uint) error
 	IsValidContour(Contour *models.Contour) bool
-	CreateContour(Contour *models.Contour) error
-	GetContours(offset, limit int) ([]models.Contour, error)
-	GetContourByID(id uint) (*models.Contour, error)
-	UpdateContour(Contour *models.Contour) error
-	DeleteContour(id uint) error
+	CreateContour(ctx context.Context, Contour *models.Contour) error
+	GetContours(ctx context.Context, offset, limit int) ([]models.Contour, error)
+	GetContourByID(ctx context.Context, id uint) (*models.Contour, error)
+	UpdateContour(ctx context.Context, Contour *models.Contour) error
+	DeleteContour(ctx context.Context, id, version uint) error
 
 	// Advanced Query
-	GetPointsByContourID(contourID uint) ([]models.Point, error)
-	GetContoursIntersectArea(contourIDA, contourIDB uint) ([]models.Contour, error)
+	GetPointsByContourID(ctx context.Context, contourID uint) ([]models.Point, error)
+	GetPointsByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Point, error)
+	GetPointsByGeohash(ctx context.Context, prefix string, offset, limit int) ([]models.Point, error)
+	GetContoursByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Contour, error)
+	GetContoursIntersectArea(ctx context.Context, contourIDA, contourIDB uint) ([]models.Contour, error)
+	GetPointContourDistance(ctx context.Context, pointID, contourID uint) (*models.Distance, error)
+	SnapPoint(ctx context.Context, pointID, contourID uint, tolerance float64) (*models.Point, float64, error)
+
+	// Bulk Import
+	BulkCreatePoints(ctx context.Context, features []codec.Feature, mode BulkMode) ([]BulkResult, error)
+	BulkCreateContours(ctx context.Context, features []codec.Feature, mode BulkMode) ([]BulkResult, error)
+
+	// Export
+	ExportPoints(ctx context.Context, bbox *models.BBox, contourID uint, fn func(*models.Point) error) error
+	ExportContours(ctx context.Context, bbox *models.BBox, fn func(*models.Contour) error) error
+
+	// Tiles
+	GetTile(ctx context.Context, tile models.Tile) ([]byte, error)
+
+	// Clustering
+	GetPointClusters(ctx context.Context, bbox models.BBox, zoom int) ([]supercluster.Cluster, error)
+
+	// Aggregation
+	AggregatePoints(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error)
+
+	// Buffers
+	BufferPoint(ctx context.Context, id uint, buffer models.Buffer, persist bool) (*models.Contour, error)
+	BufferContour(ctx context.Context, id uint, buffer models.Buffer, persist bool) (*models.Contour, error)
+
+	// Hulls
+	GetPointsHull(ctx context.Context, hull models.Hull, input models.HullInput, persist bool) (*models.Contour, error)
+
+	// Voronoi
+	GetVoronoiCells(ctx context.Context, input models.VoronoiInput, persist bool) ([]models.VoronoiCell, error)
+	GetDelaunayTriangles(ctx context.Context, input models.VoronoiInput) ([]models.Geometry, error)
+
+	// Tessellation
+	TessellateContour(ctx context.Context, id uint, tessellation models.Tessellation, persist bool) ([]models.TessellationCell, error)
+
+	// Simplification
+	SimplifyContour(ctx context.Context, id uint, tolerance float64) (*models.SimplifiedContour, error)
+	GetSimplifiedContour(ctx context.Context, id uint) (*models.SimplifiedContour, error)
+
+	// History
+	GetContourHistory(ctx context.Context, id uint) ([]models.ContourHistory, error)
+	GetContourAsOf(ctx context.Context, id uint, at time.Time) (*models.Contour, error)
+	RestoreContour(ctx context.Context, id, version, expected uint) (*models.Contour, error)
+
+	// Patches
+	PatchPoint(ctx context.Context, id, expected uint, patch models.Patch) (*models.Point, error)
+	PatchContour(ctx context.Context, id, expected uint, patch models.Patch) (*models.Contour, error)
+
+	// Duplicates
+	CreateUniquePoint(ctx context.Context, point *models.Point, policy DuplicatePolicy, tolerance float64) (bool, error)
+	DedupePoints(ctx context.Context, tolerance float64, dryRun bool) ([]DuplicateGroup, error)
+
+	// Trash
+	GetDeletedContours(ctx context.Context, offset, limit int) ([]models.Contour, error)
+	UndeleteContour(ctx context.Context, id uint) (*models.Contour, error)
+
+	// Layers
+	CreateLayer(ctx context.Context, layer *models.Layer) error
+	GetLayers(ctx context.Context, offset, limit int) ([]models.Layer, error)
+	GetLayerByID(ctx context.Context, id uint) (*models.Layer, error)
+	UpdateLayer(ctx context.Context, layer *models.Layer) error
+	DeleteLayer(ctx context.Context, id uint) error
 }
 
 type GeometryServiceImpl struct {
 	pointRepo   repository.PointRepository
 	contourRepo repository.ContourRepository
+	layerRepo   repository.LayerRepository
+	clusters    *clusterIndex
+	txManager   repository.TxManager
+	// geohashPrecision is the length of the geohashes of returned points.
+	geohashPrecision int
 }
 
-func NewGeometryService(pointRepo repository.PointRepository, contourRepo repository.ContourRepository) GeometryService {
-	return &GeometryServiceImpl{pointRepo, contourRepo}
+// Option configures a GeometryService.
+type Option func(*GeometryServiceImpl)
+
+// WithGeohashPrecision sets the length of the geohashes of returned points,
+// values outside [1, geohash.MaxPrecision] keep the full precision.
+func WithGeohashPrecision(precision int) Option {
+	return func(s *GeometryServiceImpl) {
+		if precision >= 1 && precision <= geohash.MaxPrecision {
+			s.geohashPrecision = precision
+		}
+	}
+}
+
+// WithTxManager runs the units of work of the service, such as a contour
+// update and the refresh of its simplified variant, in transactions. Without
+// it they run directly on the repositories of the service.
+func WithTxManager(txManager repository.TxManager) Option {
+	return func(s *GeometryServiceImpl) {
+		s.txManager = txManager
+	}
+}
+
+func NewGeometryService(pointRepo repository.PointRepository, contourRepo repository.ContourRepository, options ...Option) GeometryService {
+	s := &GeometryServiceImpl{
+		pointRepo:        pointRepo,
+		contourRepo:      contourRepo,
+		clusters:         newClusterIndex(supercluster.DefaultOptions()),
+		txManager:        directTx{repository.Repositories{Points: pointRepo, Contours: contourRepo}},
+		geohashPrecision: geohash.MaxPrecision,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
 }
 
 func (s *GeometryServiceImpl) IsValidPoint(point *models.Point) bool {
 	return point.Data.Validate() == nil
 }
 
-func (s *GeometryServiceImpl) CreatePoint(point *models.Point) error {
-	if !s.IsValidPoint(point) {
-		return constants.ErrInvalidPoint
+// CreatePoint creates a point in the layer the points of ctx are scoped to,
+// if any.
+func (s *GeometryServiceImpl) CreatePoint(ctx context.Context, point *models.Point) error {
+	if err := point.Data.Validate(); err != nil {
+		return invalidData(constants.ErrInvalidPoint, err)
+	}
+
+	point.LayerID = models.LayerScopeFrom(ctx).Points
+
+	if err := s.pointRepo.CreatePoint(ctx, point); err != nil {
+		return err
+	}
+
+	s.clusters.insert(point)
+	s.trimGeohash(point)
+	return nil
+}
+
+func (s *GeometryServiceImpl) GetPoints(ctx context.Context, offset, limit int) ([]models.Point, error) {
+	return s.trimGeohashes(s.pointRepo.GetPoints(ctx, offset, limit))
+}
+
+func (s *GeometryServiceImpl) GetPointByID(ctx context.Context, id uint) (*models.Point, error) {
+	point, err := s.pointRepo.GetPointByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.pointRepo.CreatePoint(point)
+	s.trimGeohash(point)
+	return point, nil
 }
 
-func (s *GeometryServiceImpl) GetPoints(offset, limit int) ([]models.Point, error) {
-	return s.pointRepo.GetPoints(offset, limit)
+func (s *GeometryServiceImpl) UpdatePoint(ctx context.Context, point *models.Point) error {
+	if err := point.Data.Validate(); err != nil {
+		return invalidData(constants.ErrInvalidPoint, err)
+	}
+
+	if err := s.pointRepo.UpdatePoint(ctx, point); err != nil {
+		return err
+	}
+
+	s.clusters.insert(point)
+	s.trimGeohash(point)
+	return nil
 }
 
-func (s *GeometryServiceImpl) GetPointByID(id uint) (*models.Point, error) {
-	return s.pointRepo.GetPointByID(id)
+func (s *GeometryServiceImpl) DeletePoint(ctx context.Context, id, version uint) error {
+	if err := s.pointRepo.DeletePoint(ctx, id, version); err != nil {
+		return err
+	}
+
+	s.clusters.remove(id)
+	return nil
 }
 
 func (s *GeometryServiceImpl) IsValidContour(contour *models.Contour) bool {
 	return contour.Data.Validate() == nil
 }
 
-func (s *GeometryServiceImpl) CreateContour(contour *models.Contour) error {
-	if !s.IsValidContour(contour) {
-		return constants.ErrInvalidContours
+// CreateContour creates a contour in the layer the contours of ctx are scoped
+// to, if any.
+func (s *GeometryServiceImpl) CreateContour(ctx context.Context, contour *models.Contour) error {
+	if err := contour.Data.Validate(); err != nil {
+		return invalidData(constants.ErrInvalidContours, err)
 	}
 
-	return s.contourRepo.CreateContour(contour)
+	contour.LayerID = models.LayerScopeFrom(ctx).Contours
+	return s.contourRepo.CreateContour(ctx, contour)
 }
 
-func (s *GeometryServiceImpl) GetContours(offset, limit int) ([]models.Contour, error) {
-	return s.contourRepo.GetContours(offset, limit)
+func (s *GeometryServiceImpl) GetContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
+	return s.contourRepo.GetContours(ctx, offset, limit)
 }
 
-func (s *GeometryServiceImpl) GetContourByID(id uint) (*models.Contour, error) {
-	return s.contourRepo.GetContourByID(id)
+func (s *GeometryServiceImpl) GetContourByID(ctx context.Context, id uint) (*models.Contour, error) {
+	return s.contourRepo.GetContourByID(ctx, id)
 }
 
-func (s *GeometryServiceImpl) UpdateContour(contour *models.Contour) error {
-	if !s.IsValidContour(contour) {
-		return constants.ErrInvalidContours
+func (s *GeometryServiceImpl) UpdateContour(ctx context.Context, contour *models.Contour) error {
+	if err := contour.Data.Validate(); err != nil {
+		return invalidData(constants.ErrInvalidContours, err)
 	}
 
-	return s.contourRepo.UpdateContour(contour)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.Contours.UpdateContour(ctx, contour); err != nil {
+			return err
+		}
+
+		return s.refreshSimplifiedContour(ctx, repos.Contours, contour)
+	})
 }
 
-func (s *GeometryServiceImpl) DeleteContour(id uint) error {
-	return s.contourRepo.DeleteContour(id)
+// invalidData reports a geometry failing validation as kind, at the field of
+// the geometry err points to.
+func invalidData(kind *constants.Error, err error) error {
+	invalid := kind.Wrap(err)
+	var cause *constants.Error
+	if errors.As(err, &cause) {
+		invalid = invalid.WithDetails(cause.Details).At(cause.Field)
+	}
+
+	return invalid.At("data")
+}
+
+func (s *GeometryServiceImpl) DeleteContour(ctx context.Context, id, version uint) error {
+	return s.contourRepo.DeleteContour(ctx, id, version)
+}
+
+func (s *GeometryServiceImpl) GetPointsByContourID(ctx context.Context, contourID uint) ([]models.Point, error) {
+	return s.trimGeohashes(s.pointRepo.GetPointsByContourID(ctx, contourID))
+}
+
+func (s *GeometryServiceImpl) GetPointsByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Point, error) {
+	return s.trimGeohashes(s.pointRepo.GetPointsByBBox(ctx, bbox, offset, limit))
+}
+
+// GetPointsByGeohash returns the points whose geohash starts with prefix.
+func (s *GeometryServiceImpl) GetPointsByGeohash(ctx context.Context, prefix string, offset, limit int) ([]models.Point, error) {
+	if !geohash.Valid(prefix) {
+		return nil, constants.ErrInvalidGeohash
+	}
+
+	return s.trimGeohashes(s.pointRepo.GetPointsByGeohash(ctx, strings.ToLower(prefix), offset, limit))
+}
+
+// trimGeohash cuts the stored geohash of a point to the configured precision.
+func (s *GeometryServiceImpl) trimGeohash(point *models.Point) {
+	if len(point.Geohash) > s.geohashPrecision {
+		point.Geohash = point.Geohash[:s.geohashPrecision]
+	}
+}
+
+func (s *GeometryServiceImpl) trimGeohashes(points []models.Point, err error) ([]models.Point, error) {
+	for i := range points {
+		s.trimGeohash(&points[i])
+	}
+
+	return points, err
 }
 
-func (s *GeometryServiceImpl) GetPointsByContourID(contourID uint) ([]models.Point, error) {
-	return s.pointRepo.GetPointsByContourID(contourID)
+func (s *GeometryServiceImpl) GetContoursByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Contour, error) {
+	return s.contourRepo.GetContoursByBBox(ctx, bbox, offset, limit)
 }
 
-func (s *GeometryServiceImpl) GetContoursIntersectArea(contourIDA, contourIDB uint) ([]models.Contour, error) {
-	_, err := s.contourRepo.GetContourByID(contourIDA)
+func (s *GeometryServiceImpl) GetContoursIntersectArea(ctx context.Context, contourIDA, contourIDB uint) ([]models.Contour, error) {
+	_, err := s.contourRepo.GetContourByID(ctx, contourIDA)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.contourRepo.GetContourByID(contourIDB)
+	_, err = s.contourRepo.GetContourByID(ctx, contourIDB)
 	if err != nil {
 		return nil, err
 	}
 
 	resutls := make([]models.Contour, 0)
-	geos, err := s.contourRepo.GetContoursIntersectArea(contourIDA, contourIDB)
+	geos, err := s.contourRepo.GetContoursIntersectArea(ctx, contourIDA, contourIDB)
 	if err != nil {
 		return nil, err
 	}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"github.com/malamsyah/geo-service/internal/constants"
 	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
 	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
@@ -71,7 +74,7 @@ func TestGeometryService_CreatePoint(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().CreatePoint(gomock.Any()).Return(nil).Times(1)
+				mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 				return mockPointRepo
 			},
 			wantErr: false,
@@ -109,13 +112,36 @@ func TestGeometryService_CreatePoint(t *testing.T) {
 			mockPointRepo := tt.mocks()
 			svc := NewGeometryService(mockPointRepo, nil)
 
-			if err := svc.CreatePoint(tt.point); (err != nil) != tt.wantErr {
+			if err := svc.CreatePoint(context.Background(), tt.point); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.CreatePoint() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func TestGeometryService_InvalidGeometry(t *testing.T) {
+	svc := NewGeometryService(nil, nil)
+
+	point := &models.Point{Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{180, 200.1}}}
+	err := svc.CreatePoint(context.Background(), point)
+
+	var domainErr *constants.Error
+	assert.ErrorAs(t, err, &domainErr)
+	assert.ErrorIs(t, err, constants.ErrInvalidPoint)
+	assert.ErrorIs(t, err, constants.ErrCoordinatesOutOfRange)
+	assert.Equal(t, "data.coordinates", domainErr.Field)
+	assert.Equal(t, "invalid point: coordinates out of range", err.Error())
+
+	ring := [][2]float64{{0, 0}, {1, 0}, {1, 100}, {0, 0}}
+	contour := &models.Contour{Data: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{ring}}}
+	err = svc.UpdateContour(context.Background(), contour)
+
+	assert.ErrorAs(t, err, &domainErr)
+	assert.ErrorIs(t, err, constants.ErrInvalidContours)
+	assert.Equal(t, "data.coordinates[0][2]", domainErr.Field)
+	assert.Equal(t, map[string]any{"position": [2]float64{1, 100}}, domainErr.Details)
+}
+
 func TestGeometryService_GetPoints(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -131,7 +157,7 @@ func TestGeometryService_GetPoints(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPoints(0, 1).Return([]models.Point{{ID: 1}}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPoints(gomock.Any(), 0, 1).Return([]models.Point{{ID: 1}}, nil).Times(1)
 				return mockPointRepo
 			},
 			wantErr: false,
@@ -143,7 +169,7 @@ func TestGeometryService_GetPoints(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPoints(0, 0).Return([]models.Point{}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPoints(gomock.Any(), 0, 0).Return([]models.Point{}, nil).Times(1)
 				return mockPointRepo
 			},
 			wantErr: false,
@@ -155,7 +181,7 @@ func TestGeometryService_GetPoints(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPoints(0, 10).Return(nil, constants.ErrInternal).Times(1)
+				mockPointRepo.EXPECT().GetPoints(gomock.Any(), 0, 10).Return(nil, constants.ErrInternal).Times(1)
 				return mockPointRepo
 			},
 			wantErr: true,
@@ -167,7 +193,7 @@ func TestGeometryService_GetPoints(t *testing.T) {
 			mockPointRepo := tt.mocks()
 			svc := NewGeometryService(mockPointRepo, nil)
 
-			if _, err := svc.GetPoints(tt.offset, tt.limit); (err != nil) != tt.wantErr {
+			if _, err := svc.GetPoints(context.Background(), tt.offset, tt.limit); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.GetPoints() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -187,7 +213,7 @@ func TestGeometryService_GetPointByID(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPointByID(uint(1)).Return(&models.Point{ID: 1}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(&models.Point{ID: 1}, nil).Times(1)
 				return mockPointRepo
 			},
 			wantErr: false,
@@ -198,7 +224,7 @@ func TestGeometryService_GetPointByID(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPointByID(uint(1)).Return(nil, constants.ErrInternal).Times(1)
+				mockPointRepo.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(nil, constants.ErrInternal).Times(1)
 				return mockPointRepo
 			},
 			wantErr: true,
@@ -210,13 +236,109 @@ func TestGeometryService_GetPointByID(t *testing.T) {
 			mockPointRepo := tt.mocks()
 			svc := NewGeometryService(mockPointRepo, nil)
 
-			if _, err := svc.GetPointByID(tt.id); (err != nil) != tt.wantErr {
+			if _, err := svc.GetPointByID(context.Background(), tt.id); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.GetPointByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func TestGeometryService_UpdatePoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		point   *models.Point
+		mocks   func() *mock_repository.MockPointRepository
+		wantErr bool
+	}{
+		{
+			name:  "ValidPoint",
+			point: &models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{125.6, 10.1}}},
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				return mockPointRepo
+			},
+			wantErr: false,
+		},
+		{
+			name:  "InvalidPoint",
+			point: &models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{190, 10.1}}},
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				return mockPointRepo
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Error",
+			point: &models.Point{ID: 1, Data: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{125.6, 10.1}}},
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).Return(constants.ErrInternal).Times(1)
+				return mockPointRepo
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPointRepo := tt.mocks()
+			svc := NewGeometryService(mockPointRepo, nil)
+
+			if err := svc.UpdatePoint(context.Background(), tt.point); (err != nil) != tt.wantErr {
+				t.Errorf("GeometryService.UpdatePoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeometryService_DeletePoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      uint
+		mocks   func() *mock_repository.MockPointRepository
+		wantErr bool
+	}{
+		{
+			name: "ValidID",
+			id:   1,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().DeletePoint(gomock.Any(), uint(1), uint(0)).Return(nil).Times(1)
+				return mockPointRepo
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			id:   1,
+			mocks: func() *mock_repository.MockPointRepository {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().DeletePoint(gomock.Any(), uint(1), uint(0)).Return(constants.ErrInternal).Times(1)
+				return mockPointRepo
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPointRepo := tt.mocks()
+			svc := NewGeometryService(mockPointRepo, nil)
+
+			if err := svc.DeletePoint(context.Background(), tt.id, 0); (err != nil) != tt.wantErr {
+				t.Errorf("GeometryService.DeletePoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestGeometryService_IsValidContour(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -279,7 +401,7 @@ func TestGeometryService_CreateContour(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().CreateContour(gomock.Any()).Return(nil).Times(1)
+				mockContourRepo.EXPECT().CreateContour(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 				return mockContourRepo
 			},
 			wantErr: false,
@@ -317,7 +439,7 @@ func TestGeometryService_CreateContour(t *testing.T) {
 			mockContourRepo := tt.mocks()
 			svc := NewGeometryService(nil, mockContourRepo)
 
-			if err := svc.CreateContour(tt.Contour); (err != nil) != tt.wantErr {
+			if err := svc.CreateContour(context.Background(), tt.Contour); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.CreateContour() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -339,7 +461,7 @@ func TestGeometryService_GetContours(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContours(0, 1).Return([]models.Contour{{ID: 1}}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContours(gomock.Any(), 0, 1).Return([]models.Contour{{ID: 1}}, nil).Times(1)
 				return mockContourRepo
 			},
 			wantErr: false,
@@ -351,7 +473,7 @@ func TestGeometryService_GetContours(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContours(0, 0).Return([]models.Contour{}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContours(gomock.Any(), 0, 0).Return([]models.Contour{}, nil).Times(1)
 				return mockContourRepo
 			},
 			wantErr: false,
@@ -363,7 +485,7 @@ func TestGeometryService_GetContours(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContours(0, 10).Return(nil, constants.ErrInternal).Times(1)
+				mockContourRepo.EXPECT().GetContours(gomock.Any(), 0, 10).Return(nil, constants.ErrInternal).Times(1)
 				return mockContourRepo
 			},
 			wantErr: true,
@@ -375,7 +497,7 @@ func TestGeometryService_GetContours(t *testing.T) {
 			mockContourRepo := tt.mocks()
 			svc := NewGeometryService(nil, mockContourRepo)
 
-			if _, err := svc.GetContours(tt.offset, tt.limit); (err != nil) != tt.wantErr {
+			if _, err := svc.GetContours(context.Background(), tt.offset, tt.limit); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.GetContours() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -395,7 +517,7 @@ func TestGeometryService_GetContourByID(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
 				return mockContourRepo
 			},
 			wantErr: false,
@@ -406,7 +528,7 @@ func TestGeometryService_GetContourByID(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(nil, constants.ErrInternal).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrInternal).Times(1)
 				return mockContourRepo
 			},
 			wantErr: true,
@@ -418,7 +540,7 @@ func TestGeometryService_GetContourByID(t *testing.T) {
 			mockContourRepo := tt.mocks()
 			svc := NewGeometryService(nil, mockContourRepo)
 
-			if _, err := svc.GetContourByID(tt.id); (err != nil) != tt.wantErr {
+			if _, err := svc.GetContourByID(context.Background(), tt.id); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.GetContourByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -441,7 +563,31 @@ func TestGeometryService_UpdateContour(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().UpdateContour(gomock.Any()).Return(nil).Times(1)
+				mockContourRepo.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockContourRepo.EXPECT().GetSimplifiedContour(gomock.Any(), gomock.Any()).Return(nil, constants.ErrNotFound).Times(1)
+				return mockContourRepo
+			},
+			wantErr: false,
+		},
+		{
+			name: "ValidContourWithSimplifiedVariant",
+			Contour: &models.Contour{ID: 1, Data: models.Geometry{
+				Type:               models.PolygonType,
+				PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+			}},
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockContourRepo.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(&models.SimplifiedContour{ContourID: 1, Tolerance: 100}, nil).Times(1)
+				mockContourRepo.EXPECT().SaveSimplifiedContour(gomock.Any(), &models.SimplifiedContour{
+					ContourID: 1,
+					Tolerance: 100,
+					Data: models.Geometry{
+						Type:               models.PolygonType,
+						PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+					},
+				}).Return(nil).Times(1)
 				return mockContourRepo
 			},
 			wantErr: false,
@@ -479,13 +625,38 @@ func TestGeometryService_UpdateContour(t *testing.T) {
 			mockContourRepo := tt.mocks()
 			svc := NewGeometryService(nil, mockContourRepo)
 
-			if err := svc.UpdateContour(tt.Contour); (err != nil) != tt.wantErr {
+			if err := svc.UpdateContour(context.Background(), tt.Contour); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.UpdateContour() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+func TestGeometryService_UpdateContourWithinTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	contour := &models.Contour{ID: 1, Data: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}},
+	}}
+
+	// Only the repository bound to the transaction may be written to.
+	txContourRepo := mock_repository.NewMockContourRepository(ctrl)
+	txContourRepo.EXPECT().UpdateContour(gomock.Any(), contour).Return(nil).Times(1)
+	txContourRepo.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(&models.SimplifiedContour{ContourID: 1, Tolerance: 100}, nil).Times(1)
+	txContourRepo.EXPECT().SaveSimplifiedContour(gomock.Any(), gomock.Any()).Return(constants.ErrInternal).Times(1)
+
+	txManager := mock_repository.NewMockTxManager(ctrl)
+	txManager.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, repository.Repositories) error) error {
+			return fn(ctx, repository.Repositories{Contours: txContourRepo})
+		}).Times(1)
+
+	svc := NewGeometryService(nil, mock_repository.NewMockContourRepository(ctrl), WithTxManager(txManager))
+
+	err := svc.UpdateContour(context.Background(), contour)
+	assert.ErrorIs(t, err, constants.ErrInternal)
+}
+
 func TestGeometryService_DeleteContour(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -499,7 +670,7 @@ func TestGeometryService_DeleteContour(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().DeleteContour(uint(1)).Return(nil).Times(1)
+				mockContourRepo.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(0)).Return(nil).Times(1)
 				return mockContourRepo
 			},
 			wantErr: false,
@@ -510,7 +681,7 @@ func TestGeometryService_DeleteContour(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().DeleteContour(uint(1)).Return(constants.ErrInternal).Times(1)
+				mockContourRepo.EXPECT().DeleteContour(gomock.Any(), uint(1), uint(0)).Return(constants.ErrInternal).Times(1)
 				return mockContourRepo
 			},
 			wantErr: true,
@@ -522,7 +693,7 @@ func TestGeometryService_DeleteContour(t *testing.T) {
 			mockContourRepo := tt.mocks()
 			svc := NewGeometryService(nil, mockContourRepo)
 
-			if err := svc.DeleteContour(tt.id); (err != nil) != tt.wantErr {
+			if err := svc.DeleteContour(context.Background(), tt.id, 0); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.DeleteContour() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -542,7 +713,7 @@ func TestGeometryService_GetPointsByContourID(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPointsByContourID(uint(1)).Return([]models.Point{{ID: 1}}, nil).Times(1)
+				mockPointRepo.EXPECT().GetPointsByContourID(gomock.Any(), uint(1)).Return([]models.Point{{ID: 1}}, nil).Times(1)
 				return mockPointRepo
 			},
 			wantErr: false,
@@ -553,7 +724,7 @@ func TestGeometryService_GetPointsByContourID(t *testing.T) {
 			mocks: func() *mock_repository.MockPointRepository {
 				ctrl := gomock.NewController(t)
 				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
-				mockPointRepo.EXPECT().GetPointsByContourID(uint(1)).Return(nil, constants.ErrInternal).Times(1)
+				mockPointRepo.EXPECT().GetPointsByContourID(gomock.Any(), uint(1)).Return(nil, constants.ErrInternal).Times(1)
 				return mockPointRepo
 			},
 			wantErr: true,
@@ -565,7 +736,7 @@ func TestGeometryService_GetPointsByContourID(t *testing.T) {
 			mockPointRepo := tt.mocks()
 			svc := NewGeometryService(mockPointRepo, nil)
 
-			if _, err := svc.GetPointsByContourID(tt.contourID); (err != nil) != tt.wantErr {
+			if _, err := svc.GetPointsByContourID(context.Background(), tt.contourID); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.GetPointsByContourID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -588,9 +759,9 @@ func TestGeometryService_GetContoursIntersectArea(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContourByID(uint(2)).Return(&models.Contour{ID: 2}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContoursIntersectArea(uint(1), uint(2)).Return([]models.Contour{
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(2)).Return(&models.Contour{ID: 2}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return([]models.Contour{
 					{ID: 1, Data: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}}}},
 					{ID: 2, Data: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}}}}}, nil).Times(1)
 				return mockContourRepo
@@ -605,9 +776,9 @@ func TestGeometryService_GetContoursIntersectArea(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContourByID(uint(2)).Return(&models.Contour{ID: 2}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContoursIntersectArea(uint(1), uint(2)).Return([]models.Contour{{ID: 1, Data: models.Geometry{
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(2)).Return(&models.Contour{ID: 2}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return([]models.Contour{{ID: 1, Data: models.Geometry{
 					Type:                    models.MultiPolygon,
 					MultiPolygonCoordinates: [][][][2]float64{{{{125.6, 10.1}, {125.7, 10.2}, {125.8, 10.3}, {125.6, 10.1}}}},
 				}}}, nil).Times(1)
@@ -623,7 +794,7 @@ func TestGeometryService_GetContoursIntersectArea(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(nil, constants.ErrNotFound).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrNotFound).Times(1)
 				return mockContourRepo
 			},
 			wantErr:     true,
@@ -636,8 +807,8 @@ func TestGeometryService_GetContoursIntersectArea(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContourByID(uint(2)).Return(nil, constants.ErrNotFound).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(2)).Return(nil, constants.ErrNotFound).Times(1)
 				return mockContourRepo
 			},
 			wantErr:     true,
@@ -650,9 +821,9 @@ func TestGeometryService_GetContoursIntersectArea(t *testing.T) {
 			mocks: func() *mock_repository.MockContourRepository {
 				ctrl := gomock.NewController(t)
 				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
-				mockContourRepo.EXPECT().GetContourByID(uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContourByID(uint(2)).Return(&models.Contour{ID: 2}, nil).Times(1)
-				mockContourRepo.EXPECT().GetContoursIntersectArea(uint(1), uint(2)).Return(nil, constants.ErrInternal).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(2)).Return(&models.Contour{ID: 2}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContoursIntersectArea(gomock.Any(), uint(1), uint(2)).Return(nil, constants.ErrInternal).Times(1)
 				return mockContourRepo
 			},
 			wantErr:     true,
@@ -665,9 +836,34 @@ func TestGeometryService_GetContoursIntersectArea(t *testing.T) {
 			mockContourRepo := tt.mocks()
 			svc := NewGeometryService(nil, mockContourRepo)
 
-			if _, err := svc.GetContoursIntersectArea(tt.contourIDA, tt.contourIDB); (err != nil) != tt.wantErr {
+			if _, err := svc.GetContoursIntersectArea(context.Background(), tt.contourIDA, tt.contourIDB); (err != nil) != tt.wantErr {
 				t.Errorf("GeometryService.GetContoursIntersectArea() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestGeometryService_GetPointsByBBox(t *testing.T) {
+	bbox := models.BBox{MinLon: 0, MinLat: 0, MaxLon: 10, MaxLat: 10}
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().GetPointsByBBox(gomock.Any(), bbox, 0, 10).Return([]models.Point{{ID: 1}}, nil).Times(1)
+	svc := NewGeometryService(mockPointRepo, nil)
+
+	points, err := svc.GetPointsByBBox(context.Background(), bbox, 0, 10)
+	if err != nil || len(points) != 1 {
+		t.Errorf("GeometryService.GetPointsByBBox() = %v, %v", points, err)
+	}
+}
+
+func TestGeometryService_GetContoursByBBox(t *testing.T) {
+	bbox := models.BBox{MinLon: 0, MinLat: 0, MaxLon: 10, MaxLat: 10}
+	ctrl := gomock.NewController(t)
+	mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+	mockContourRepo.EXPECT().GetContoursByBBox(gomock.Any(), bbox, 0, 10).Return(nil, constants.ErrInternal).Times(1)
+	svc := NewGeometryService(nil, mockContourRepo)
+
+	if _, err := svc.GetContoursByBBox(context.Background(), bbox, 0, 10); err == nil {
+		t.Errorf("GeometryService.GetContoursByBBox() expected error")
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// GetContourHistory returns the versions a contour went through before the
+// current one, oldest first. Contours that were deleted keep their history.
+func (s *GeometryServiceImpl) GetContourHistory(ctx context.Context, id uint) ([]models.ContourHistory, error) {
+	history, err := s.contourRepo.GetContourHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		if _, err := s.contourRepo.GetContourByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+func (s *GeometryServiceImpl) GetContourAsOf(ctx context.Context, id uint, at time.Time) (*models.Contour, error) {
+	return s.contourRepo.GetContourAsOf(ctx, id, at)
+}
+
+// RestoreContour reverts a contour to a version of its history, as an update
+// making a new version. expected is the version the caller expects to
+// replace, zero to replace whichever is current. The contour stays in its
+// current layer, which the history does not record.
+func (s *GeometryServiceImpl) RestoreContour(ctx context.Context, id, version, expected uint) (*models.Contour, error) {
+	history, err := s.contourRepo.GetContourHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range history {
+		if entry.Version != version {
+			continue
+		}
+
+		current, err := s.contourRepo.GetContourByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		contour := &models.Contour{ID: id, Data: entry.Data, Properties: entry.Properties, LayerID: current.LayerID, Version: expected}
+		if err := s.UpdateContour(ctx, contour); err != nil {
+			return nil, err
+		}
+
+		return contour, nil
+	}
+
+	return nil, constants.ErrContourVersionNotFound
+}
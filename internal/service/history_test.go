@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_GetContourHistory(t *testing.T) {
+	tests := []struct {
+		name          string
+		mocks         func() *mock_repository.MockContourRepository
+		expectedLen   int
+		expectedError error
+	}{
+		{
+			name: "History",
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return([]models.ContourHistory{{ContourID: 1, Version: 1}}, nil).Times(1)
+				return mockContourRepo
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "NoHistory",
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return([]models.ContourHistory{}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(circleContour(1), nil).Times(1)
+				return mockContourRepo
+			},
+		},
+		{
+			name: "ContourNotFound",
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return([]models.ContourHistory{}, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrContourNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(nil, tt.mocks())
+
+			history, err := svc.GetContourHistory(context.Background(), 1)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, history, tt.expectedLen)
+		})
+	}
+}
+
+func TestGeometryService_RestoreContour(t *testing.T) {
+	old := circleContour(1)
+	history := []models.ContourHistory{{ContourID: 1, Version: 1, Data: old.Data, Properties: models.Properties{"name": "old"}}}
+	layer := uint(2)
+
+	tests := []struct {
+		name          string
+		version       uint
+		mocks         func() *mock_repository.MockContourRepository
+		expectedError error
+	}{
+		{
+			name:    "Restores",
+			version: 1,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return(history, nil).Times(1)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(&models.Contour{ID: 1, LayerID: &layer, Version: 3}, nil).Times(1)
+				mockContourRepo.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, contour *models.Contour) error {
+					assert.Equal(t, uint(3), contour.Version)
+					assert.Equal(t, old.Data, contour.Data)
+					assert.Equal(t, &layer, contour.LayerID)
+					contour.Version = 4
+					return nil
+				}).Times(1)
+				mockContourRepo.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mockContourRepo
+			},
+		},
+		{
+			name:    "VersionNotFound",
+			version: 2,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourHistory(gomock.Any(), uint(1)).Return(history, nil).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrContourVersionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(nil, tt.mocks())
+
+			contour, err := svc.RestoreContour(context.Background(), 1, tt.version, 3)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint(4), contour.Version)
+			assert.Equal(t, "old", contour.Properties["name"])
+		})
+	}
+}
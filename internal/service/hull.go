@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/convex"
+)
+
+// GetPointsHull returns the convex or concave hull of a point set as a new
+// contour, stored when persist is set in the layer the contours of ctx are
+// scoped to, if any. The convex hull of inline points is
+// computed in memory, the other hulls by the database.
+func (s *GeometryServiceImpl) GetPointsHull(ctx context.Context, hull models.Hull, input models.HullInput, persist bool) (*models.Contour, error) {
+	if err := hull.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var contour *models.Contour
+	if len(input.Positions) > 0 && hull.Type == models.HullConvex {
+		contour = &models.Contour{Data: models.Geometry{
+			Type:               models.PolygonType,
+			PolygonCoordinates: [][][2]float64{convex.Hull(input.Positions)},
+		}}
+	} else {
+		var err error
+		if contour, err = s.pointRepo.GetPointsHull(ctx, hull, input); err != nil {
+			return nil, err
+		}
+	}
+
+	if !contour.Data.IsPolygon() || len(contour.Data.PolygonCoordinates) == 0 || len(contour.Data.PolygonCoordinates[0]) < 4 {
+		return nil, constants.ErrDegenerateHull
+	}
+
+	if persist {
+		if err := s.CreateContour(ctx, contour); err != nil {
+			return nil, err
+		}
+	}
+
+	return contour, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_GetPointsHull(t *testing.T) {
+	convexHull := models.Hull{Type: models.HullConvex}
+	concaveHull := models.Hull{Type: models.HullConcave, TargetPercent: 0.5}
+	positions := [][2]float64{{0, 0}, {1, 0}, {0.5, 0.2}, {1, 1}, {0, 1}}
+	bbox := &models.BBox{MinLon: 0, MinLat: 0, MaxLon: 1, MaxLat: 1}
+	layer := uint(2)
+
+	tests := []struct {
+		name          string
+		hull          models.Hull
+		input         models.HullInput
+		persist       bool
+		mocks         func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository)
+		expected      models.Geometry
+		expectedID    uint
+		expectedError error
+	}{
+		{
+			name:  "ConvexHullInMemory",
+			hull:  convexHull,
+			input: models.HullInput{Positions: positions},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expected: square().Data,
+		},
+		{
+			name:  "ConcaveHullOfInlinePoints",
+			hull:  concaveHull,
+			input: models.HullInput{Positions: positions},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointsHull(gomock.Any(), concaveHull, models.HullInput{Positions: positions}).Return(square(), nil).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+			expected: square().Data,
+		},
+		{
+			name:    "PersistsHullOfStoredPoints",
+			hull:    convexHull,
+			input:   models.HullInput{BBox: bbox},
+			persist: true,
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointsHull(gomock.Any(), convexHull, models.HullInput{BBox: bbox}).Return(square(), nil).Times(1)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().CreateContour(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, contour *models.Contour) error {
+					assert.Equal(t, &layer, contour.LayerID)
+					contour.ID = 3
+					return nil
+				}).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+			expected:   square().Data,
+			expectedID: 3,
+		},
+		{
+			name:  "DegenerateInlinePoints",
+			hull:  convexHull,
+			input: models.HullInput{Positions: [][2]float64{{0, 0}, {1, 1}, {2, 2}}},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrDegenerateHull,
+		},
+		{
+			name:  "NoStoredPoints",
+			hull:  convexHull,
+			input: models.HullInput{ContourID: 4},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointsHull(gomock.Any(), convexHull, models.HullInput{ContourID: 4}).Return(&models.Contour{}, nil).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrDegenerateHull,
+		},
+		{
+			name:  "ConflictingInput",
+			hull:  convexHull,
+			input: models.HullInput{Positions: positions, ContourID: 4},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrConflictingHullInput,
+		},
+		{
+			name:  "InvalidHull",
+			hull:  models.Hull{Type: "alpha"},
+			input: models.HullInput{Positions: positions},
+			mocks: func(ctrl *gomock.Controller) (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidHull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(tt.mocks(gomock.NewController(t)))
+
+			contour, err := svc.GetPointsHull(models.WithLayerScope(context.Background(), models.LayerScope{Contours: &layer}), tt.hull, tt.input, tt.persist)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, contour.ID)
+			assert.Equal(t, tt.expected, contour.Data)
+		})
+	}
+}
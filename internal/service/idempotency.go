@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+// IdempotencyPurger deletes the idempotency keys that expired.
+type IdempotencyPurger struct {
+	idempotencyRepo repository.IdempotencyRepository
+}
+
+func NewIdempotencyPurger(idempotencyRepo repository.IdempotencyRepository) *IdempotencyPurger {
+	return &IdempotencyPurger{idempotencyRepo}
+}
+
+// Purge deletes the keys that expired.
+func (p *IdempotencyPurger) Purge(ctx context.Context) error {
+	keys, err := p.idempotencyRepo.PurgeIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if keys > 0 {
+		logger.Infof("Purged %d expired idempotency keys", keys)
+	}
+
+	return nil
+}
+
+// Run purges right away and then every interval, DefaultPurgeInterval when it
+// is not positive, until ctx is done. Failures are logged and retried at the
+// next interval.
+func (p *IdempotencyPurger) Run(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, "idempotency keys", p.Purge)
+}
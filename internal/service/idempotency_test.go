@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyPurger_Purge(t *testing.T) {
+	t.Run("Purged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIdempotencyRepo := mock_repository.NewMockIdempotencyRepository(ctrl)
+		mockIdempotencyRepo.EXPECT().PurgeIdempotencyKeys(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now(), before, time.Minute)
+			return 3, nil
+		}).Times(1)
+
+		assert.NoError(t, NewIdempotencyPurger(mockIdempotencyRepo).Purge(context.Background()))
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIdempotencyRepo := mock_repository.NewMockIdempotencyRepository(ctrl)
+		mockIdempotencyRepo.EXPECT().PurgeIdempotencyKeys(gomock.Any(), gomock.Any()).Return(int64(0), assert.AnError).Times(1)
+
+		assert.ErrorIs(t, NewIdempotencyPurger(mockIdempotencyRepo).Purge(context.Background()), assert.AnError)
+	})
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+)
+
+// WithLayerRepository sets the repository of the layers points and contours
+// are grouped in, which the layer methods of the service need.
+func WithLayerRepository(layerRepo repository.LayerRepository) Option {
+	return func(s *GeometryServiceImpl) {
+		s.layerRepo = layerRepo
+	}
+}
+
+func (s *GeometryServiceImpl) CreateLayer(ctx context.Context, layer *models.Layer) error {
+	if err := layer.Validate(); err != nil {
+		return err
+	}
+
+	return s.layerRepo.CreateLayer(ctx, layer)
+}
+
+func (s *GeometryServiceImpl) GetLayers(ctx context.Context, offset, limit int) ([]models.Layer, error) {
+	return s.layerRepo.GetLayers(ctx, offset, limit)
+}
+
+func (s *GeometryServiceImpl) GetLayerByID(ctx context.Context, id uint) (*models.Layer, error) {
+	return s.layerRepo.GetLayerByID(ctx, id)
+}
+
+func (s *GeometryServiceImpl) UpdateLayer(ctx context.Context, layer *models.Layer) error {
+	if err := layer.Validate(); err != nil {
+		return err
+	}
+
+	return s.layerRepo.UpdateLayer(ctx, layer)
+}
+
+// DeleteLayer deletes a layer left without points and contours.
+func (s *GeometryServiceImpl) DeleteLayer(ctx context.Context, id uint) error {
+	return s.layerRepo.DeleteLayer(ctx, id)
+}
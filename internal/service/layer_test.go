@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/codec"
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_CreateLayer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLayerRepo := mock_repository.NewMockLayerRepository(ctrl)
+	mockLayerRepo.EXPECT().CreateLayer(gomock.Any(), &models.Layer{Name: "stores"}).Return(nil).Times(1)
+
+	svc := NewGeometryService(nil, nil, WithLayerRepository(mockLayerRepo))
+
+	assert.NoError(t, svc.CreateLayer(context.Background(), &models.Layer{Name: "stores"}))
+	assert.ErrorIs(t, svc.CreateLayer(context.Background(), &models.Layer{Name: "Store Locations"}), constants.ErrInvalidLayerName)
+	assert.ErrorIs(t, svc.UpdateLayer(context.Background(), &models.Layer{ID: 1}), constants.ErrInvalidLayerName)
+}
+
+func TestGeometryService_LayerScope(t *testing.T) {
+	layer := uint(3)
+	ctx := models.WithLayerScope(context.Background(), models.LayerScope{Points: &layer})
+
+	t.Run("BulkCreatePoints", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().CreatePoints(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, points []models.Point, _ int) error {
+			assert.Equal(t, &layer, points[0].LayerID)
+			return nil
+		}).Times(1)
+
+		svc := NewGeometryService(mockPointRepo, nil)
+
+		features := []codec.Feature{{Geometry: models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{30, 10}}}}
+		_, err := svc.BulkCreatePoints(ctx, features, BulkModeAtomic)
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetPointClusters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().StreamPoints(gomock.Any(), nil, uint(0), gomock.Any()).
+			DoAndReturn(streamPoints(newPoint(1, 106.8271, -6.1754))).
+			Times(2)
+		mockPointRepo.EXPECT().CreatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+			point.ID = uint(10) + *point.LayerID
+			return nil
+		}).Times(2)
+
+		svc := NewGeometryService(mockPointRepo, nil)
+
+		// The layer is loaded once and kept in sync with the writes to it,
+		// apart from the index of every point.
+		clusters, err := svc.GetPointClusters(ctx, world(), 0)
+		assert.NoError(t, err)
+		assert.Len(t, clusters, 1)
+
+		other := uint(4)
+		for _, layer := range []*uint{&layer, &other} {
+			point := newPoint(0, 2.3522, 48.8566)
+			assert.NoError(t, svc.CreatePoint(models.WithLayerScope(context.Background(), models.LayerScope{Points: layer}), &point))
+			assert.Equal(t, layer, point.LayerID)
+		}
+
+		clusters, err = svc.GetPointClusters(ctx, world(), 0)
+		assert.NoError(t, err)
+		ids := make([]uint, 0, len(clusters))
+		for _, cluster := range clusters {
+			ids = append(ids, cluster.PointID)
+		}
+
+		assert.ElementsMatch(t, []uint{1, 13}, ids)
+
+		clusters, err = svc.GetPointClusters(context.Background(), world(), 0)
+		assert.NoError(t, err)
+		assert.Len(t, clusters, 1)
+	})
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// PatchPoint applies a patch to the current version of a point and saves the
+// result as an update conditional on that version, so concurrent writes are
+// not lost. expected is the version the caller expects to patch, zero to
+// patch whichever is current.
+func (s *GeometryServiceImpl) PatchPoint(ctx context.Context, id, expected uint, patch models.Patch) (*models.Point, error) {
+	current, err := s.pointRepo.GetPointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(current.Version, expected); err != nil {
+		return nil, err
+	}
+
+	point := &models.Point{ID: id, Data: current.Data, Properties: current.Properties, LayerID: current.LayerID, Version: current.Version}
+	if err := patch.Apply(&point.Data, &point.Properties); err != nil {
+		return nil, constants.Wrap(err, constants.ErrInvalidPoint)
+	}
+
+	if err := s.UpdatePoint(ctx, point); err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
+
+// PatchContour applies a patch to the current version of a contour like
+// PatchPoint.
+func (s *GeometryServiceImpl) PatchContour(ctx context.Context, id, expected uint, patch models.Patch) (*models.Contour, error) {
+	current, err := s.contourRepo.GetContourByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(current.Version, expected); err != nil {
+		return nil, err
+	}
+
+	contour := &models.Contour{ID: id, Data: current.Data, Properties: current.Properties, LayerID: current.LayerID, Version: current.Version}
+	if err := patch.Apply(&contour.Data, &contour.Properties); err != nil {
+		return nil, constants.Wrap(err, constants.ErrInvalidContours)
+	}
+
+	if err := s.UpdateContour(ctx, contour); err != nil {
+		return nil, err
+	}
+
+	return contour, nil
+}
+
+// checkVersion fails the way a conditional write does when the current
+// version is not the expected one, if any.
+func checkVersion(current, expected uint) error {
+	if expected == 0 || expected == current {
+		return nil
+	}
+
+	return constants.ErrVersionMismatch.WithDetails(map[string]any{"version": current})
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_PatchContour(t *testing.T) {
+	layer := uint(2)
+	current := func() *models.Contour {
+		contour := circleContour(1)
+		contour.Properties = models.Properties{"name": "field"}
+		contour.LayerID = &layer
+		contour.Version = 3
+		return contour
+	}
+
+	tests := []struct {
+		name          string
+		expected      uint
+		patch         models.Patch
+		mocks         func() *mock_repository.MockContourRepository
+		expectedError error
+	}{
+		{
+			name:     "Patches",
+			expected: 3,
+			patch:    models.Patch{MediaType: models.MediaTypeMergePatch, Body: []byte(`{"properties":{"crop":"rice"}}`)},
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(current(), nil).Times(1)
+				mockContourRepo.EXPECT().UpdateContour(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, contour *models.Contour) error {
+					assert.Equal(t, uint(3), contour.Version)
+					assert.Equal(t, current().Data, contour.Data)
+					assert.Equal(t, &layer, contour.LayerID)
+					contour.Version = 4
+					return nil
+				}).Times(1)
+				mockContourRepo.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mockContourRepo
+			},
+		},
+		{
+			name:     "VersionMismatch",
+			expected: 2,
+			patch:    models.Patch{MediaType: models.MediaTypeMergePatch, Body: []byte(`{"properties":{"crop":"rice"}}`)},
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(current(), nil).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrVersionMismatch,
+		},
+		{
+			name:  "InvalidGeometry",
+			patch: models.Patch{MediaType: models.MediaTypeJSONPatch, Body: []byte(`[{"op":"remove","path":"/data/coordinates/0/0"}]`)},
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(current(), nil).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrInvalidContours,
+		},
+		{
+			name:  "Conflict",
+			patch: models.Patch{MediaType: models.MediaTypeJSONPatch, Body: []byte(`[{"op":"test","path":"/properties/name","value":"other"}]`)},
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(current(), nil).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrPatchConflict,
+		},
+		{
+			name:  "ContourNotFound",
+			patch: models.Patch{MediaType: models.MediaTypeMergePatch, Body: []byte(`{}`)},
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrContourNotFound).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrContourNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(nil, tt.mocks())
+
+			contour, err := svc.PatchContour(context.Background(), 1, tt.expected, tt.patch)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint(4), contour.Version)
+			assert.Equal(t, models.Properties{"name": "field", "crop": "rice"}, contour.Properties)
+		})
+	}
+}
+
+func TestGeometryService_PatchPoint(t *testing.T) {
+	layer := uint(2)
+	ctrl := gomock.NewController(t)
+	mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+	mockPointRepo.EXPECT().GetPointByID(gomock.Any(), uint(1)).Return(&models.Point{
+		ID:      1,
+		Data:    models.Geometry{Type: models.PointType, PointCoordinates: [2]float64{30, 10}},
+		LayerID: &layer,
+		Version: 1,
+	}, nil).Times(1)
+	mockPointRepo.EXPECT().UpdatePoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *models.Point) error {
+		assert.Equal(t, uint(1), point.Version)
+		assert.Equal(t, &layer, point.LayerID)
+		point.Version = 2
+		return nil
+	}).Times(1)
+
+	svc := NewGeometryService(mockPointRepo, nil)
+
+	point, err := svc.PatchPoint(context.Background(), 1, 0, models.Patch{
+		MediaType: models.MediaTypeJSONPatch,
+		Body:      []byte(`[{"op":"replace","path":"/data/coordinates","value":[31,11]}]`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [2]float64{31, 11}, point.Data.PointCoordinates)
+	assert.Equal(t, uint(2), point.Version)
+	assert.Equal(t, &layer, point.LayerID)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+)
+
+// SimplifyContour simplifies a contour to the tolerance in metres and stores
+// the result as its simplified variant, replacing the previous one.
+func (s *GeometryServiceImpl) SimplifyContour(ctx context.Context, id uint, tolerance float64) (*models.SimplifiedContour, error) {
+	if tolerance <= 0 {
+		return nil, constants.ErrInvalidDistance
+	}
+
+	contour, err := s.contourRepo.GetContourByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveSimplifiedContour(ctx, s.contourRepo, contour, tolerance)
+}
+
+func (s *GeometryServiceImpl) GetSimplifiedContour(ctx context.Context, id uint) (*models.SimplifiedContour, error) {
+	return s.contourRepo.GetSimplifiedContour(ctx, id)
+}
+
+// refreshSimplifiedContour simplifies an updated contour again with the
+// tolerance of its stored variant, if it has one.
+func (s *GeometryServiceImpl) refreshSimplifiedContour(ctx context.Context, contours repository.ContourRepository, contour *models.Contour) error {
+	variant, err := contours.GetSimplifiedContour(ctx, contour.ID)
+	if errors.Is(err, constants.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s.saveSimplifiedContour(ctx, contours, contour, variant.Tolerance)
+	return err
+}
+
+func (s *GeometryServiceImpl) saveSimplifiedContour(ctx context.Context, contours repository.ContourRepository, contour *models.Contour, tolerance float64) (*models.SimplifiedContour, error) {
+	variant := &models.SimplifiedContour{
+		ContourID: contour.ID,
+		Tolerance: tolerance,
+		Data:      contour.Data.Simplify(tolerance),
+	}
+
+	if err := variant.Data.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := contours.SaveSimplifiedContour(ctx, variant); err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// circleContour returns a contour approximating a circle with 360 vertices.
+func circleContour(id uint) *models.Contour {
+	ring := make([][2]float64, 0, 361)
+	for i := 0; i < 360; i++ {
+		angle := float64(i) * math.Pi / 180
+		ring = append(ring, [2]float64{106.8 + 0.1*math.Cos(angle), -6.2 + 0.1*math.Sin(angle)})
+	}
+
+	return &models.Contour{
+		ID:   id,
+		Data: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{append(ring, ring[0])}},
+	}
+}
+
+func TestGeometryService_SimplifyContour(t *testing.T) {
+	tests := []struct {
+		name          string
+		tolerance     float64
+		mocks         func() *mock_repository.MockContourRepository
+		expectedError error
+	}{
+		{
+			name:      "StoresVariant",
+			tolerance: 100,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(circleContour(1), nil).Times(1)
+				mockContourRepo.EXPECT().SaveSimplifiedContour(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				return mockContourRepo
+			},
+		},
+		{
+			name:      "ContourNotFound",
+			tolerance: 100,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(1)).Return(nil, constants.ErrNotFound).Times(1)
+				return mockContourRepo
+			},
+			expectedError: constants.ErrNotFound,
+		},
+		{
+			name:      "InvalidTolerance",
+			tolerance: 0,
+			mocks: func() *mock_repository.MockContourRepository {
+				ctrl := gomock.NewController(t)
+				return mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidDistance,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewGeometryService(nil, tt.mocks())
+
+			variant, err := svc.SimplifyContour(context.Background(), 1, tt.tolerance)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), variant.ContourID)
+			assert.Equal(t, tt.tolerance, variant.Tolerance)
+			assert.NoError(t, variant.Data.Validate())
+			assert.Less(t, len(variant.Data.PolygonCoordinates[0]), 361)
+		})
+	}
+}
+
+func TestGeometryService_GetSimplifiedContour(t *testing.T) {
+	variant := &models.SimplifiedContour{ContourID: 1, Tolerance: 100}
+
+	ctrl := gomock.NewController(t)
+	mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+	mockContourRepo.EXPECT().GetSimplifiedContour(gomock.Any(), uint(1)).Return(variant, nil).Times(1)
+	svc := NewGeometryService(nil, mockContourRepo)
+
+	result, err := svc.GetSimplifiedContour(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, variant, result)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/voronoi"
+)
+
+// TessellateContour splits a contour into square or hexagonal cells of equal
+// area, laid out in an equal-area projection centred on it and clipped to it,
+// ordered by column and row. Every polygon of the cells is stored as a
+// contour whose parent is the tessellated one, in its layer, when persist is
+// set.
+func (s *GeometryServiceImpl) TessellateContour(ctx context.Context, id uint, tessellation models.Tessellation, persist bool) ([]models.TessellationCell, error) {
+	if err := tessellation.Validate(); err != nil {
+		return nil, err
+	}
+
+	contour, err := s.contourRepo.GetContourByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contour.Data.PolygonCoordinates) == 0 {
+		return nil, constants.ErrInvalidContours
+	}
+
+	bounds := contour.Data.Bounds()
+	projection := models.EqualArea{Lon: (bounds.MinLon + bounds.MaxLon) / 2, Lat: (bounds.MinLat + bounds.MaxLat) / 2}
+	ring := project(projection, contour.Data.PolygonCoordinates[0])
+
+	minX, minY, maxX, maxY := extent(ring)
+	if tessellation.Grid().SpanCellCount(maxX-minX, maxY-minY) > models.MaxGridCells {
+		return nil, constants.ErrTooManyCells
+	}
+
+	var cells []models.TessellationCell
+	if tessellation.Engine == models.EnginePostGIS {
+		cells, err = s.contourRepo.GetContourTessellation(ctx, id, tessellation, projection)
+	}
+
+	if tessellation.Engine == models.EngineGo || errors.Is(err, constants.ErrGridUnsupported) {
+		// The cells are clipped to the projected ring, which is what has to
+		// be convex.
+		if len(contour.Data.PolygonCoordinates) > 1 || !voronoi.IsConvex(ring) {
+			return nil, constants.ErrConcaveContour
+		}
+
+		cells, err = tessellate(tessellation.Grid(), projection, ring), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonEmpty := make([]models.TessellationCell, 0, len(cells))
+	for _, cell := range cells {
+		if len(polygons(cell.Data)) > 0 {
+			nonEmpty = append(nonEmpty, cell)
+		}
+	}
+
+	if persist {
+		if err := s.saveTessellationCells(ctx, contour, nonEmpty); err != nil {
+			return nil, err
+		}
+	}
+
+	return nonEmpty, nil
+}
+
+// tessellate clips the cells of the grid to the projected ring of a convex
+// contour without holes in memory.
+func tessellate(grid models.Grid, projection models.EqualArea, ring [][2]float64) []models.TessellationCell {
+	cells := make([]models.TessellationCell, 0)
+
+	for _, cell := range grid.CellsCovering(extent(ring)) {
+		clipped := voronoi.ClipConvex(ring, grid.CellRing(cell[0], cell[1]))
+		if clipped == nil {
+			continue
+		}
+
+		for k, position := range clipped {
+			clipped[k] = projection.Inverse(position[0], position[1])
+		}
+
+		cells = append(cells, models.TessellationCell{
+			I:    cell[0],
+			J:    cell[1],
+			Data: models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{clipped}},
+		})
+	}
+
+	return cells
+}
+
+// saveTessellationCells stores the polygons of the cells as children of the
+// parent contour, in its layer.
+func (s *GeometryServiceImpl) saveTessellationCells(ctx context.Context, parent *models.Contour, cells []models.TessellationCell) error {
+	geometries := make([]models.Geometry, len(cells))
+	for i, cell := range cells {
+		geometries[i] = cell.Data
+	}
+
+	ids, err := s.saveCells(ctx, geometries, func(int) models.Contour {
+		return models.Contour{ParentID: &parent.ID, LayerID: parent.LayerID}
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range cells {
+		cells[i].ContourIDs = ids[i]
+	}
+
+	return nil
+}
+
+func project(projection models.EqualArea, ring [][2]float64) [][2]float64 {
+	projected := make([][2]float64, len(ring))
+	for k, position := range ring {
+		projected[k][0], projected[k][1] = projection.Forward(position[0], position[1])
+	}
+
+	return projected
+}
+
+func extent(ring [][2]float64) (float64, float64, float64, float64) {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, position := range ring {
+		minX, minY = math.Min(minX, position[0]), math.Min(minY, position[1])
+		maxX, maxY = math.Max(maxX, position[0]), math.Max(maxY, position[1])
+	}
+
+	return minX, minY, maxX, maxY
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// district is a square of about 2.2 km around the equator.
+func district() *models.Contour {
+	return &models.Contour{ID: 7, Data: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {0.02, 0}, {0.02, 0.02}, {0, 0.02}, {0, 0}}},
+	}}
+}
+
+func TestGeometryService_TessellateContour(t *testing.T) {
+	square := models.Tessellation{Type: models.GridSquare, Size: 1000, Engine: models.EnginePostGIS}
+
+	t.Run("PostGIS", func(t *testing.T) {
+		cells := []models.TessellationCell{
+			{I: -1, J: -1, Data: district().Data},
+			{I: -1, J: 0, Data: models.Geometry{Type: models.MultiPolygon}},
+		}
+
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(district(), nil).Times(1)
+		mockContourRepo.EXPECT().GetContourTessellation(gomock.Any(), uint(7), square, models.EqualArea{Lon: 0.01, Lat: 0.01}).Return(cells, nil).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		result, err := svc.TessellateContour(context.Background(), 7, square, false)
+		assert.NoError(t, err)
+		assert.Equal(t, cells[:1], result)
+	})
+
+	t.Run("FallsBackToGo", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(district(), nil).Times(1)
+		mockContourRepo.EXPECT().GetContourTessellation(gomock.Any(), uint(7), square, gomock.Any()).Return(nil, constants.ErrGridUnsupported).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		result, err := svc.TessellateContour(context.Background(), 7, square, false)
+		assert.NoError(t, err)
+		assert.Len(t, result, 16)
+		assert.Equal(t, [2]int{-2, -2}, [2]int{result[0].I, result[0].J})
+		assert.Equal(t, [2]int{1, 1}, [2]int{result[15].I, result[15].J})
+
+		// Inner cells are whole squares of 1 km, 0.009 degrees at the equator.
+		inner := result[5].Data.Bounds()
+		assert.Equal(t, [2]int{-1, -1}, [2]int{result[5].I, result[5].J})
+		assert.InDelta(t, 0.009, inner.MaxLon-inner.MinLon, 1e-4)
+		assert.InDelta(t, 0.009, inner.MaxLat-inner.MinLat, 1e-4)
+		assert.InDelta(t, 0, result[0].Data.Bounds().MinLon, 1e-9)
+	})
+
+	t.Run("Hexagons", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(district(), nil).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		result, err := svc.TessellateContour(context.Background(), 7, models.Tessellation{Type: models.GridHex, Size: 500, Engine: models.EngineGo}, false)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+		for _, cell := range result {
+			bounds := cell.Data.Bounds()
+			assert.True(t, bounds.MinLon >= -1e-9 && bounds.MaxLon <= 0.02+1e-9, "%d %d", cell.I, cell.J)
+			assert.True(t, bounds.MinLat >= -1e-9 && bounds.MaxLat <= 0.02+1e-9, "%d %d", cell.I, cell.J)
+		}
+	})
+
+	t.Run("PersistsCells", func(t *testing.T) {
+		tessellation := models.Tessellation{Type: models.GridSquare, Size: 2000, Engine: models.EngineGo}
+		layer := uint(2)
+		parent := district()
+		parent.LayerID = &layer
+
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(parent, nil).Times(1)
+		mockContourRepo.EXPECT().CreateContours(gomock.Any(), gomock.Len(4), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, contours []models.Contour, _ int) error {
+			for i := range contours {
+				assert.Equal(t, uint(7), *contours[i].ParentID)
+				assert.Equal(t, &layer, contours[i].LayerID)
+				contours[i].ID = uint(20 + i)
+			}
+
+			return nil
+		}).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		result, err := svc.TessellateContour(context.Background(), 7, tessellation, true)
+		assert.NoError(t, err)
+		assert.Len(t, result, 4)
+		assert.Equal(t, []uint{20}, result[0].ContourIDs)
+		assert.Equal(t, []uint{23}, result[3].ContourIDs)
+	})
+
+	t.Run("HoledContour", func(t *testing.T) {
+		holed := district()
+		holed.Data.PolygonCoordinates = append(holed.Data.PolygonCoordinates, [][2]float64{{0.005, 0.005}, {0.005, 0.015}, {0.015, 0.015}, {0.015, 0.005}, {0.005, 0.005}})
+
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(holed, nil).Times(1)
+		mockContourRepo.EXPECT().GetContourTessellation(gomock.Any(), uint(7), square, gomock.Any()).Return(nil, constants.ErrGridUnsupported).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		_, err := svc.TessellateContour(context.Background(), 7, square, true)
+		assert.ErrorIs(t, err, constants.ErrConcaveContour)
+	})
+
+	t.Run("TooManyCells", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(district(), nil).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		_, err := svc.TessellateContour(context.Background(), 7, models.Tessellation{Type: models.GridSquare, Size: 1, Engine: models.EngineGo}, false)
+		assert.ErrorIs(t, err, constants.ErrTooManyCells)
+	})
+
+	t.Run("InvalidGrid", func(t *testing.T) {
+		svc := NewGeometryService(nil, nil)
+
+		_, err := svc.TessellateContour(context.Background(), 7, models.Tessellation{Type: models.GridContour, Engine: models.EngineGo}, false)
+		assert.ErrorIs(t, err, constants.ErrInvalidGrid)
+	})
+
+	t.Run("ContourNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(7)).Return(nil, constants.ErrContourNotFound).Times(1)
+		svc := NewGeometryService(nil, mockContourRepo)
+
+		_, err := svc.TessellateContour(context.Background(), 7, square, false)
+		assert.ErrorIs(t, err, constants.ErrContourNotFound)
+	})
+}
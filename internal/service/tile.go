@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/models"
+)
+
+// GetTile returns the Mapbox Vector Tile holding the points and contours
+// layers of the tile. Encoded layers are independent protobuf fields, so the
+// tile is their concatenation. An empty tile means there is nothing to draw.
+func (s *GeometryServiceImpl) GetTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	if err := tile.Validate(); err != nil {
+		return nil, err
+	}
+
+	points, err := s.pointRepo.GetPointsTile(ctx, tile)
+	if err != nil {
+		return nil, err
+	}
+
+	contours, err := s.contourRepo.GetContoursTile(ctx, tile)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(points, contours...), nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeometryService_GetTile(t *testing.T) {
+	tile := models.Tile{Z: 1, X: 1, Y: 0}
+
+	tests := []struct {
+		name          string
+		tile          models.Tile
+		mocks         func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository)
+		expected      []byte
+		expectedError error
+	}{
+		{
+			name: "ConcatenatesLayers",
+			tile: tile,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointsTile(gomock.Any(), tile).Return([]byte("points"), nil).Times(1)
+				mockContourRepo.EXPECT().GetContoursTile(gomock.Any(), tile).Return([]byte("contours"), nil).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+			expected: []byte("pointscontours"),
+		},
+		{
+			name: "InvalidTile",
+			tile: models.Tile{Z: 1, X: 2, Y: 0},
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				return mock_repository.NewMockPointRepository(ctrl), mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInvalidTile,
+		},
+		{
+			name: "RepositoryError",
+			tile: tile,
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockPointRepo.EXPECT().GetPointsTile(gomock.Any(), tile).Return(nil, constants.ErrInternal).Times(1)
+				return mockPointRepo, mock_repository.NewMockContourRepository(ctrl)
+			},
+			expectedError: constants.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPointRepo, mockContourRepo := tt.mocks()
+			svc := NewGeometryService(mockPointRepo, mockContourRepo)
+
+			data, err := svc.GetTile(context.Background(), tt.tile)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, data)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/internal/repository"
+	"github.com/malamsyah/geo-service/pkg/logger"
+)
+
+func (s *GeometryServiceImpl) GetDeletedContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
+	return s.contourRepo.GetDeletedContours(ctx, offset, limit)
+}
+
+// UndeleteContour takes a contour out of the trash, as a new version.
+func (s *GeometryServiceImpl) UndeleteContour(ctx context.Context, id uint) (*models.Contour, error) {
+	return s.contourRepo.UndeleteContour(ctx, id)
+}
+
+// DefaultPurgeInterval is how often the trash is purged by default.
+const DefaultPurgeInterval = time.Hour
+
+// Purger deletes for good the points and contours that have been in the
+// trash for longer than the retention.
+type Purger struct {
+	pointRepo   repository.PointRepository
+	contourRepo repository.ContourRepository
+	retention   time.Duration
+}
+
+func NewPurger(pointRepo repository.PointRepository, contourRepo repository.ContourRepository, retention time.Duration) *Purger {
+	return &Purger{pointRepo, contourRepo, retention}
+}
+
+// Purge deletes the points and contours trashed before the retention.
+func (p *Purger) Purge(ctx context.Context) error {
+	before := time.Now().Add(-p.retention)
+
+	points, err := p.pointRepo.PurgePoints(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	contours, err := p.contourRepo.PurgeContours(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if points > 0 || contours > 0 {
+		logger.Infof("Purged %d points and %d contours from the trash", points, contours)
+	}
+
+	return nil
+}
+
+// Run purges right away and then every interval, DefaultPurgeInterval when it
+// is not positive, until ctx is done. Failures are logged and retried at the
+// next interval.
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, "trash", p.Purge)
+}
+
+// runEvery calls purge right away and then every interval, DefaultPurgeInterval
+// when it is not positive, until ctx is done. Failures are logged as failing
+// to purge what and retried at the next interval.
+func runEvery(ctx context.Context, interval time.Duration, what string, purge func(context.Context) error) {
+	if interval <= 0 {
+		interval = DefaultPurgeInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := purge(ctx); err != nil {
+			logger.Errorf("Failed to purge %s: %v", what, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
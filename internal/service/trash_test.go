@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	tests := []struct {
+		name          string
+		mocks         func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository)
+		expectedError error
+	}{
+		{
+			name: "Purged",
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockPointRepo.EXPECT().PurgePoints(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+					assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+					return 2, nil
+				}).Times(1)
+				mockContourRepo.EXPECT().PurgeContours(gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+		},
+		{
+			name: "PointsFailed",
+			mocks: func() (*mock_repository.MockPointRepository, *mock_repository.MockContourRepository) {
+				ctrl := gomock.NewController(t)
+				mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+				mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+				mockPointRepo.EXPECT().PurgePoints(gomock.Any(), gomock.Any()).Return(int64(0), assert.AnError).Times(1)
+				return mockPointRepo, mockContourRepo
+			},
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pointRepo, contourRepo := tt.mocks()
+			err := NewPurger(pointRepo, contourRepo, 24*time.Hour).Purge(context.Background())
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
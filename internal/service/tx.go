@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/malamsyah/geo-service/internal/repository"
+)
+
+// directTx runs units of work on the repositories of a service built without
+// a TxManager, outside of any transaction.
+type directTx struct {
+	repos repository.Repositories
+}
+
+func (d directTx) WithinTx(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	return fn(ctx, d.repos)
+}
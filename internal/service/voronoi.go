@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/pkg/voronoi"
+)
+
+// SeedPointProperty is the contour property linking a stored Voronoi cell to
+// its seed point.
+const SeedPointProperty = "seed_point_id"
+
+// GetVoronoiCells returns the Voronoi cells of the seed points clipped to the
+// contour, ordered by point, and stores every polygon of them as a contour
+// linked to its seed when persist is set. Cells outside the contour are left
+// out.
+func (s *GeometryServiceImpl) GetVoronoiCells(ctx context.Context, input models.VoronoiInput, persist bool) ([]models.VoronoiCell, error) {
+	if err := input.Validate(true); err != nil {
+		return nil, err
+	}
+
+	var cells []models.VoronoiCell
+	var err error
+	if input.Engine == models.EnginePostGIS {
+		cells, err = s.pointRepo.GetVoronoiCells(ctx, input)
+	}
+
+	if input.Engine == models.EngineGo || errors.Is(err, constants.ErrVoronoiUnsupported) {
+		cells, err = s.voronoiCells(ctx, input)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonEmpty := make([]models.VoronoiCell, 0, len(cells))
+	for _, cell := range cells {
+		if len(polygons(cell.Data)) > 0 {
+			nonEmpty = append(nonEmpty, cell)
+		}
+	}
+
+	if persist {
+		if err := s.saveVoronoiCells(ctx, nonEmpty); err != nil {
+			return nil, err
+		}
+	}
+
+	return nonEmpty, nil
+}
+
+// GetDelaunayTriangles returns the Delaunay triangulation of the seed points.
+func (s *GeometryServiceImpl) GetDelaunayTriangles(ctx context.Context, input models.VoronoiInput) ([]models.Geometry, error) {
+	if err := input.Validate(false); err != nil {
+		return nil, err
+	}
+
+	if input.Engine == models.EnginePostGIS {
+		triangles, err := s.pointRepo.GetDelaunayTriangles(ctx, input)
+		if !errors.Is(err, constants.ErrVoronoiUnsupported) {
+			return triangles, err
+		}
+	}
+
+	seeds, err := s.voronoiSeeds(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := seedPositions(seeds)
+	triangles := make([]models.Geometry, 0)
+	for _, t := range voronoi.Triangulate(positions) {
+		ring := [][2]float64{positions[t[0]], positions[t[1]], positions[t[2]], positions[t[0]]}
+		triangles = append(triangles, models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{ring}})
+	}
+
+	return triangles, nil
+}
+
+// voronoiCells computes the Voronoi cells in memory, which only clips them to
+// convex contours without holes.
+func (s *GeometryServiceImpl) voronoiCells(ctx context.Context, input models.VoronoiInput) ([]models.VoronoiCell, error) {
+	contour, err := s.contourRepo.GetContourByID(ctx, input.ContourID)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds, err := s.voronoiSeeds(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	clip, err := convexRing(contour.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([]models.VoronoiCell, 0, len(seeds))
+	for i, ring := range voronoi.Cells(seedPositions(seeds), clip) {
+		if ring != nil {
+			cells = append(cells, models.VoronoiCell{
+				PointID: seeds[i].ID,
+				Data:    models.Geometry{Type: models.PolygonType, PolygonCoordinates: [][][2]float64{ring}},
+			})
+		}
+	}
+
+	return cells, nil
+}
+
+// convexRing returns the ring of a polygon the Go engine can clip to, failing
+// with constants.ErrConcaveContour when it has holes or is not convex, as the
+// cells would then come out wrong.
+func convexRing(data models.Geometry) ([][2]float64, error) {
+	if len(data.PolygonCoordinates) == 0 {
+		return nil, constants.ErrInvalidContours
+	}
+
+	if len(data.PolygonCoordinates) > 1 || !voronoi.IsConvex(data.PolygonCoordinates[0]) {
+		return nil, constants.ErrConcaveContour
+	}
+
+	return data.PolygonCoordinates[0], nil
+}
+
+// voronoiSeeds returns the seed points ordered by id.
+func (s *GeometryServiceImpl) voronoiSeeds(ctx context.Context, input models.VoronoiInput) ([]models.Point, error) {
+	var seeds []models.Point
+	var err error
+	if len(input.IDs) > 0 {
+		seeds, err = s.pointRepo.GetPointsByIDs(ctx, input.IDs)
+	} else {
+		seeds, err = s.pointRepo.GetPointsByContourID(ctx, input.ContourID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(seeds, func(a, b int) bool { return seeds[a].ID < seeds[b].ID })
+
+	return seeds, nil
+}
+
+// saveVoronoiCells stores the polygons of the cells as contours linked to
+// their seed, in the layer the contours of ctx are scoped to, if any.
+func (s *GeometryServiceImpl) saveVoronoiCells(ctx context.Context, cells []models.VoronoiCell) error {
+	geometries := make([]models.Geometry, len(cells))
+	for i, cell := range cells {
+		geometries[i] = cell.Data
+	}
+
+	layer := models.LayerScopeFrom(ctx).Contours
+	ids, err := s.saveCells(ctx, geometries, func(i int) models.Contour {
+		return models.Contour{Properties: models.Properties{SeedPointProperty: cells[i].PointID}, LayerID: layer}
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range cells {
+		cells[i].ContourIDs = ids[i]
+	}
+
+	return nil
+}
+
+// saveCells stores the polygons of the cell geometries as contours at once,
+// newContour returning the contour of the cell i without its data, and
+// returns the ids of the contours of every cell.
+func (s *GeometryServiceImpl) saveCells(ctx context.Context, cells []models.Geometry, newContour func(i int) models.Contour) ([][]uint, error) {
+	contours := make([]models.Contour, 0, len(cells))
+	owners := make([]int, 0, len(cells))
+
+	for i, cell := range cells {
+		for _, polygon := range polygons(cell) {
+			contour := newContour(i)
+			contour.Data = models.Geometry{Type: models.PolygonType, PolygonCoordinates: polygon}
+			if !s.IsValidContour(&contour) {
+				return nil, constants.ErrInvalidContours
+			}
+
+			contours = append(contours, contour)
+			owners = append(owners, i)
+		}
+	}
+
+	ids := make([][]uint, len(cells))
+	if len(contours) == 0 {
+		return ids, nil
+	}
+
+	if err := s.contourRepo.CreateContours(ctx, contours, constants.BulkChunkSize); err != nil {
+		return nil, err
+	}
+
+	for k, contour := range contours {
+		ids[owners[k]] = append(ids[owners[k]], contour.ID)
+	}
+
+	return ids, nil
+}
+
+// polygons returns the non empty polygons of a Polygon or MultiPolygon.
+func polygons(g models.Geometry) [][][][2]float64 {
+	var result [][][][2]float64
+
+	switch {
+	case g.IsPolygon() && len(g.PolygonCoordinates) > 0:
+		result = append(result, g.PolygonCoordinates)
+	case g.IsMultiPolygon():
+		for _, polygon := range g.MultiPolygonCoordinates {
+			if len(polygon) > 0 {
+				result = append(result, polygon)
+			}
+		}
+	}
+
+	return result
+}
+
+func seedPositions(seeds []models.Point) [][2]float64 {
+	positions := make([][2]float64, len(seeds))
+	for i, seed := range seeds {
+		positions[i] = seed.Data.PointCoordinates
+	}
+
+	return positions
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/malamsyah/geo-service/internal/constants"
+	"github.com/malamsyah/geo-service/internal/models"
+	"github.com/malamsyah/geo-service/mocks/mock_internal/mock_repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func rectangle() *models.Contour {
+	return &models.Contour{ID: 5, Data: models.Geometry{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {4, 0}, {4, 2}, {0, 2}, {0, 0}}},
+	}}
+}
+
+func TestGeometryService_GetVoronoiCells(t *testing.T) {
+	t.Run("PostGIS", func(t *testing.T) {
+		input := models.VoronoiInput{ContourID: 5, Engine: models.EnginePostGIS}
+		cells := []models.VoronoiCell{
+			{PointID: 1, Data: rectangle().Data},
+			{PointID: 2, Data: models.Geometry{Type: models.MultiPolygon}},
+		}
+
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().GetVoronoiCells(gomock.Any(), input).Return(cells, nil).Times(1)
+		svc := NewGeometryService(mockPointRepo, nil)
+
+		result, err := svc.GetVoronoiCells(context.Background(), input, false)
+		assert.NoError(t, err)
+		assert.Equal(t, cells[:1], result)
+	})
+
+	t.Run("FallsBackToGo", func(t *testing.T) {
+		input := models.VoronoiInput{ContourID: 5, Engine: models.EnginePostGIS}
+
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().GetVoronoiCells(gomock.Any(), input).Return(nil, constants.ErrVoronoiUnsupported).Times(1)
+		mockPointRepo.EXPECT().GetPointsByContourID(gomock.Any(), uint(5)).Return([]models.Point{newPoint(2, 3, 1), newPoint(1, 1, 1)}, nil).Times(1)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(5)).Return(rectangle(), nil).Times(1)
+		svc := NewGeometryService(mockPointRepo, mockContourRepo)
+
+		result, err := svc.GetVoronoiCells(context.Background(), input, false)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, uint(1), result[0].PointID)
+		assert.Equal(t, models.BBox{MinLon: 0, MinLat: 0, MaxLon: 2, MaxLat: 2}, result[0].Data.Bounds())
+		assert.Equal(t, models.BBox{MinLon: 2, MinLat: 0, MaxLon: 4, MaxLat: 2}, result[1].Data.Bounds())
+	})
+
+	t.Run("PersistsCells", func(t *testing.T) {
+		input := models.VoronoiInput{ContourID: 5, IDs: []uint{1, 2}, Engine: models.EngineGo}
+		layer := uint(2)
+
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().GetPointsByIDs(gomock.Any(), []uint{1, 2}).Return([]models.Point{newPoint(1, 1, 1), newPoint(2, 3, 1)}, nil).Times(1)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(5)).Return(rectangle(), nil).Times(1)
+		mockContourRepo.EXPECT().CreateContours(gomock.Any(), gomock.Len(2), constants.BulkChunkSize).DoAndReturn(func(_ context.Context, contours []models.Contour, _ int) error {
+			for i := range contours {
+				assert.Equal(t, uint(i+1), contours[i].Properties[SeedPointProperty])
+				assert.Equal(t, &layer, contours[i].LayerID)
+				contours[i].ID = uint(10 + i)
+			}
+
+			return nil
+		}).Times(1)
+		svc := NewGeometryService(mockPointRepo, mockContourRepo)
+
+		result, err := svc.GetVoronoiCells(models.WithLayerScope(context.Background(), models.LayerScope{Contours: &layer}), input, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{10}, result[0].ContourIDs)
+		assert.Equal(t, []uint{11}, result[1].ContourIDs)
+	})
+
+	t.Run("ConcaveContour", func(t *testing.T) {
+		input := models.VoronoiInput{ContourID: 5, IDs: []uint{1, 2}, Engine: models.EngineGo}
+		u := &models.Contour{ID: 5, Data: models.Geometry{
+			Type:               models.PolygonType,
+			PolygonCoordinates: [][][2]float64{{{0, 0}, {4, 0}, {4, 2}, {3, 2}, {3, 1}, {1, 1}, {1, 2}, {0, 2}, {0, 0}}},
+		}}
+
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().GetPointsByIDs(gomock.Any(), []uint{1, 2}).Return([]models.Point{newPoint(1, 1, 1), newPoint(2, 3, 1)}, nil).Times(1)
+		mockContourRepo := mock_repository.NewMockContourRepository(ctrl)
+		mockContourRepo.EXPECT().GetContourByID(gomock.Any(), uint(5)).Return(u, nil).Times(1)
+		svc := NewGeometryService(mockPointRepo, mockContourRepo)
+
+		_, err := svc.GetVoronoiCells(context.Background(), input, true)
+		assert.ErrorIs(t, err, constants.ErrConcaveContour)
+	})
+
+	t.Run("MissingContour", func(t *testing.T) {
+		svc := NewGeometryService(nil, nil)
+
+		_, err := svc.GetVoronoiCells(context.Background(), models.VoronoiInput{IDs: []uint{1}, Engine: models.EngineGo}, false)
+		assert.ErrorIs(t, err, constants.ErrMissingContour)
+	})
+}
+
+func TestGeometryService_GetDelaunayTriangles(t *testing.T) {
+	seeds := []models.Point{newPoint(1, 0, 0), newPoint(2, 2, 0), newPoint(3, 1, 2)}
+	expected := []models.Geometry{{
+		Type:               models.PolygonType,
+		PolygonCoordinates: [][][2]float64{{{0, 0}, {2, 0}, {1, 2}, {0, 0}}},
+	}}
+
+	t.Run("PostGIS", func(t *testing.T) {
+		input := models.VoronoiInput{IDs: []uint{1, 2, 3}, Engine: models.EnginePostGIS}
+
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().GetDelaunayTriangles(gomock.Any(), input).Return(expected, nil).Times(1)
+		svc := NewGeometryService(mockPointRepo, nil)
+
+		result, err := svc.GetDelaunayTriangles(context.Background(), input)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		input := models.VoronoiInput{ContourID: 5, Engine: models.EngineGo}
+
+		ctrl := gomock.NewController(t)
+		mockPointRepo := mock_repository.NewMockPointRepository(ctrl)
+		mockPointRepo.EXPECT().GetPointsByContourID(gomock.Any(), uint(5)).Return(seeds, nil).Times(1)
+		svc := NewGeometryService(mockPointRepo, nil)
+
+		result, err := svc.GetDelaunayTriangles(context.Background(), input)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.ElementsMatch(t, expected[0].PolygonCoordinates[0][:3], result[0].PolygonCoordinates[0][:3])
+	})
+
+	t.Run("MissingSeeds", func(t *testing.T) {
+		svc := NewGeometryService(nil, nil)
+
+		_, err := svc.GetDelaunayTriangles(context.Background(), models.VoronoiInput{Engine: models.EngineGo})
+		assert.ErrorIs(t, err, constants.ErrMissingSeeds)
+	})
+}
@@ -10,7 +10,9 @@
 package mock_repository
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/malamsyah/geo-service/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -40,88 +42,295 @@ func (m *MockContourRepository) EXPECT() *MockContourRepositoryMockRecorder {
 }
 
 // CreateContour mocks base method.
-func (m *MockContourRepository) CreateContour(Contour *models.Contour) error {
+func (m *MockContourRepository) CreateContour(ctx context.Context, Contour *models.Contour) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateContour", Contour)
+	ret := m.ctrl.Call(m, "CreateContour", ctx, Contour)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateContour indicates an expected call of CreateContour.
-func (mr *MockContourRepositoryMockRecorder) CreateContour(Contour any) *gomock.Call {
+func (mr *MockContourRepositoryMockRecorder) CreateContour(ctx, Contour any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContour", reflect.TypeOf((*MockContourRepository)(nil).CreateContour), Contour)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContour", reflect.TypeOf((*MockContourRepository)(nil).CreateContour), ctx, Contour)
+}
+
+// CreateContours mocks base method.
+func (m *MockContourRepository) CreateContours(ctx context.Context, contours []models.Contour, batchSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContours", ctx, contours, batchSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateContours indicates an expected call of CreateContours.
+func (mr *MockContourRepositoryMockRecorder) CreateContours(ctx, contours, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContours", reflect.TypeOf((*MockContourRepository)(nil).CreateContours), ctx, contours, batchSize)
 }
 
 // DeleteContour mocks base method.
-func (m *MockContourRepository) DeleteContour(id uint) error {
+func (m *MockContourRepository) DeleteContour(ctx context.Context, id, version uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteContour", id)
+	ret := m.ctrl.Call(m, "DeleteContour", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteContour indicates an expected call of DeleteContour.
-func (mr *MockContourRepositoryMockRecorder) DeleteContour(id any) *gomock.Call {
+func (mr *MockContourRepositoryMockRecorder) DeleteContour(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContour", reflect.TypeOf((*MockContourRepository)(nil).DeleteContour), ctx, id, version)
+}
+
+// GetContourAsOf mocks base method.
+func (m *MockContourRepository) GetContourAsOf(ctx context.Context, id uint, at time.Time) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContourAsOf", ctx, id, at)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContourAsOf indicates an expected call of GetContourAsOf.
+func (mr *MockContourRepositoryMockRecorder) GetContourAsOf(ctx, id, at any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContour", reflect.TypeOf((*MockContourRepository)(nil).DeleteContour), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourAsOf", reflect.TypeOf((*MockContourRepository)(nil).GetContourAsOf), ctx, id, at)
+}
+
+// GetContourBuffer mocks base method.
+func (m *MockContourRepository) GetContourBuffer(ctx context.Context, id uint, buffer models.Buffer) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContourBuffer", ctx, id, buffer)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContourBuffer indicates an expected call of GetContourBuffer.
+func (mr *MockContourRepositoryMockRecorder) GetContourBuffer(ctx, id, buffer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourBuffer", reflect.TypeOf((*MockContourRepository)(nil).GetContourBuffer), ctx, id, buffer)
 }
 
 // GetContourByID mocks base method.
-func (m *MockContourRepository) GetContourByID(id uint) (*models.Contour, error) {
+func (m *MockContourRepository) GetContourByID(ctx context.Context, id uint) (*models.Contour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContourByID", id)
+	ret := m.ctrl.Call(m, "GetContourByID", ctx, id)
 	ret0, _ := ret[0].(*models.Contour)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContourByID indicates an expected call of GetContourByID.
-func (mr *MockContourRepositoryMockRecorder) GetContourByID(id any) *gomock.Call {
+func (mr *MockContourRepositoryMockRecorder) GetContourByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourByID", reflect.TypeOf((*MockContourRepository)(nil).GetContourByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourByID", reflect.TypeOf((*MockContourRepository)(nil).GetContourByID), ctx, id)
+}
+
+// GetContourHistory mocks base method.
+func (m *MockContourRepository) GetContourHistory(ctx context.Context, id uint) ([]models.ContourHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContourHistory", ctx, id)
+	ret0, _ := ret[0].([]models.ContourHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContourHistory indicates an expected call of GetContourHistory.
+func (mr *MockContourRepositoryMockRecorder) GetContourHistory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourHistory", reflect.TypeOf((*MockContourRepository)(nil).GetContourHistory), ctx, id)
+}
+
+// GetContourTessellation mocks base method.
+func (m *MockContourRepository) GetContourTessellation(ctx context.Context, id uint, tessellation models.Tessellation, projection models.EqualArea) ([]models.TessellationCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContourTessellation", ctx, id, tessellation, projection)
+	ret0, _ := ret[0].([]models.TessellationCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContourTessellation indicates an expected call of GetContourTessellation.
+func (mr *MockContourRepositoryMockRecorder) GetContourTessellation(ctx, id, tessellation, projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourTessellation", reflect.TypeOf((*MockContourRepository)(nil).GetContourTessellation), ctx, id, tessellation, projection)
 }
 
 // GetContours mocks base method.
-func (m *MockContourRepository) GetContours(offset, limit int) ([]models.Contour, error) {
+func (m *MockContourRepository) GetContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContours", offset, limit)
+	ret := m.ctrl.Call(m, "GetContours", ctx, offset, limit)
 	ret0, _ := ret[0].([]models.Contour)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContours indicates an expected call of GetContours.
-func (mr *MockContourRepositoryMockRecorder) GetContours(offset, limit any) *gomock.Call {
+func (mr *MockContourRepositoryMockRecorder) GetContours(ctx, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContours", reflect.TypeOf((*MockContourRepository)(nil).GetContours), offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContours", reflect.TypeOf((*MockContourRepository)(nil).GetContours), ctx, offset, limit)
+}
+
+// GetContoursByBBox mocks base method.
+func (m *MockContourRepository) GetContoursByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContoursByBBox", ctx, bbox, offset, limit)
+	ret0, _ := ret[0].([]models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContoursByBBox indicates an expected call of GetContoursByBBox.
+func (mr *MockContourRepositoryMockRecorder) GetContoursByBBox(ctx, bbox, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursByBBox", reflect.TypeOf((*MockContourRepository)(nil).GetContoursByBBox), ctx, bbox, offset, limit)
 }
 
 // GetContoursIntersectArea mocks base method.
-func (m *MockContourRepository) GetContoursIntersectArea(idA, idB uint) ([]models.Contour, error) {
+func (m *MockContourRepository) GetContoursIntersectArea(ctx context.Context, idA, idB uint) ([]models.Contour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContoursIntersectArea", idA, idB)
+	ret := m.ctrl.Call(m, "GetContoursIntersectArea", ctx, idA, idB)
 	ret0, _ := ret[0].([]models.Contour)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContoursIntersectArea indicates an expected call of GetContoursIntersectArea.
-func (mr *MockContourRepositoryMockRecorder) GetContoursIntersectArea(idA, idB any) *gomock.Call {
+func (mr *MockContourRepositoryMockRecorder) GetContoursIntersectArea(ctx, idA, idB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursIntersectArea", reflect.TypeOf((*MockContourRepository)(nil).GetContoursIntersectArea), ctx, idA, idB)
+}
+
+// GetContoursPointCount mocks base method.
+func (m *MockContourRepository) GetContoursPointCount(ctx context.Context, bbox *models.BBox) ([]models.Cell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContoursPointCount", ctx, bbox)
+	ret0, _ := ret[0].([]models.Cell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContoursPointCount indicates an expected call of GetContoursPointCount.
+func (mr *MockContourRepositoryMockRecorder) GetContoursPointCount(ctx, bbox any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursPointCount", reflect.TypeOf((*MockContourRepository)(nil).GetContoursPointCount), ctx, bbox)
+}
+
+// GetContoursTile mocks base method.
+func (m *MockContourRepository) GetContoursTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContoursTile", ctx, tile)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContoursTile indicates an expected call of GetContoursTile.
+func (mr *MockContourRepositoryMockRecorder) GetContoursTile(ctx, tile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursTile", reflect.TypeOf((*MockContourRepository)(nil).GetContoursTile), ctx, tile)
+}
+
+// GetDeletedContours mocks base method.
+func (m *MockContourRepository) GetDeletedContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedContours", ctx, offset, limit)
+	ret0, _ := ret[0].([]models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedContours indicates an expected call of GetDeletedContours.
+func (mr *MockContourRepositoryMockRecorder) GetDeletedContours(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedContours", reflect.TypeOf((*MockContourRepository)(nil).GetDeletedContours), ctx, offset, limit)
+}
+
+// GetSimplifiedContour mocks base method.
+func (m *MockContourRepository) GetSimplifiedContour(ctx context.Context, contourID uint) (*models.SimplifiedContour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimplifiedContour", ctx, contourID)
+	ret0, _ := ret[0].(*models.SimplifiedContour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimplifiedContour indicates an expected call of GetSimplifiedContour.
+func (mr *MockContourRepositoryMockRecorder) GetSimplifiedContour(ctx, contourID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimplifiedContour", reflect.TypeOf((*MockContourRepository)(nil).GetSimplifiedContour), ctx, contourID)
+}
+
+// PurgeContours mocks base method.
+func (m *MockContourRepository) PurgeContours(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeContours", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeContours indicates an expected call of PurgeContours.
+func (mr *MockContourRepositoryMockRecorder) PurgeContours(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeContours", reflect.TypeOf((*MockContourRepository)(nil).PurgeContours), ctx, before)
+}
+
+// SaveSimplifiedContour mocks base method.
+func (m *MockContourRepository) SaveSimplifiedContour(ctx context.Context, variant *models.SimplifiedContour) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSimplifiedContour", ctx, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSimplifiedContour indicates an expected call of SaveSimplifiedContour.
+func (mr *MockContourRepositoryMockRecorder) SaveSimplifiedContour(ctx, variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSimplifiedContour", reflect.TypeOf((*MockContourRepository)(nil).SaveSimplifiedContour), ctx, variant)
+}
+
+// StreamContours mocks base method.
+func (m *MockContourRepository) StreamContours(ctx context.Context, bbox *models.BBox, fn func(*models.Contour) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamContours", ctx, bbox, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamContours indicates an expected call of StreamContours.
+func (mr *MockContourRepositoryMockRecorder) StreamContours(ctx, bbox, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamContours", reflect.TypeOf((*MockContourRepository)(nil).StreamContours), ctx, bbox, fn)
+}
+
+// UndeleteContour mocks base method.
+func (m *MockContourRepository) UndeleteContour(ctx context.Context, id uint) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteContour", ctx, id)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndeleteContour indicates an expected call of UndeleteContour.
+func (mr *MockContourRepositoryMockRecorder) UndeleteContour(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursIntersectArea", reflect.TypeOf((*MockContourRepository)(nil).GetContoursIntersectArea), idA, idB)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteContour", reflect.TypeOf((*MockContourRepository)(nil).UndeleteContour), ctx, id)
 }
 
 // UpdateContour mocks base method.
-func (m *MockContourRepository) UpdateContour(contour *models.Contour) error {
+func (m *MockContourRepository) UpdateContour(ctx context.Context, contour *models.Contour) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateContour", contour)
+	ret := m.ctrl.Call(m, "UpdateContour", ctx, contour)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateContour indicates an expected call of UpdateContour.
-func (mr *MockContourRepositoryMockRecorder) UpdateContour(contour any) *gomock.Call {
+func (mr *MockContourRepositoryMockRecorder) UpdateContour(ctx, contour any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContour", reflect.TypeOf((*MockContourRepository)(nil).UpdateContour), contour)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContour", reflect.TypeOf((*MockContourRepository)(nil).UpdateContour), ctx, contour)
 }
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/idempotency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/idempotency.go -destination=mocks/mock_internal/mock_repository/mock_idempotency.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/malamsyah/geo-service/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyRepository is a mock of IdempotencyRepository interface.
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository.
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance.
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) ClaimIdempotencyKey(ctx context.Context, request *models.IdempotentRequest) (*models.IdempotentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimIdempotencyKey", ctx, request)
+	ret0, _ := ret[0].(*models.IdempotentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimIdempotencyKey indicates an expected call of ClaimIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) ClaimIdempotencyKey(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).ClaimIdempotencyKey), ctx, request)
+}
+
+// PurgeIdempotencyKeys mocks base method.
+func (m *MockIdempotencyRepository) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdempotencyKeys", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdempotencyKeys indicates an expected call of PurgeIdempotencyKeys.
+func (mr *MockIdempotencyRepositoryMockRecorder) PurgeIdempotencyKeys(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdempotencyKeys", reflect.TypeOf((*MockIdempotencyRepository)(nil).PurgeIdempotencyKeys), ctx, before)
+}
+
+// ReleaseIdempotencyKey mocks base method.
+func (m *MockIdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, request *models.IdempotentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotencyKey", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIdempotencyKey indicates an expected call of ReleaseIdempotencyKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) ReleaseIdempotencyKey(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotencyKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).ReleaseIdempotencyKey), ctx, request)
+}
+
+// SaveIdempotentResponse mocks base method.
+func (m *MockIdempotencyRepository) SaveIdempotentResponse(ctx context.Context, request *models.IdempotentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotentResponse", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotentResponse indicates an expected call of SaveIdempotentResponse.
+func (mr *MockIdempotencyRepositoryMockRecorder) SaveIdempotentResponse(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotentResponse", reflect.TypeOf((*MockIdempotencyRepository)(nil).SaveIdempotentResponse), ctx, request)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/layer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/layer.go -destination=mocks/mock_internal/mock_repository/mock_layer.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/malamsyah/geo-service/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLayerRepository is a mock of LayerRepository interface.
+type MockLayerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLayerRepositoryMockRecorder
+}
+
+// MockLayerRepositoryMockRecorder is the mock recorder for MockLayerRepository.
+type MockLayerRepositoryMockRecorder struct {
+	mock *MockLayerRepository
+}
+
+// NewMockLayerRepository creates a new mock instance.
+func NewMockLayerRepository(ctrl *gomock.Controller) *MockLayerRepository {
+	mock := &MockLayerRepository{ctrl: ctrl}
+	mock.recorder = &MockLayerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLayerRepository) EXPECT() *MockLayerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLayer mocks base method.
+func (m *MockLayerRepository) CreateLayer(ctx context.Context, layer *models.Layer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLayer", ctx, layer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLayer indicates an expected call of CreateLayer.
+func (mr *MockLayerRepositoryMockRecorder) CreateLayer(ctx, layer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLayer", reflect.TypeOf((*MockLayerRepository)(nil).CreateLayer), ctx, layer)
+}
+
+// DeleteLayer mocks base method.
+func (m *MockLayerRepository) DeleteLayer(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLayer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLayer indicates an expected call of DeleteLayer.
+func (mr *MockLayerRepositoryMockRecorder) DeleteLayer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLayer", reflect.TypeOf((*MockLayerRepository)(nil).DeleteLayer), ctx, id)
+}
+
+// GetLayerByID mocks base method.
+func (m *MockLayerRepository) GetLayerByID(ctx context.Context, id uint) (*models.Layer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerByID", ctx, id)
+	ret0, _ := ret[0].(*models.Layer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerByID indicates an expected call of GetLayerByID.
+func (mr *MockLayerRepositoryMockRecorder) GetLayerByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerByID", reflect.TypeOf((*MockLayerRepository)(nil).GetLayerByID), ctx, id)
+}
+
+// GetLayerByName mocks base method.
+func (m *MockLayerRepository) GetLayerByName(ctx context.Context, name string) (*models.Layer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerByName", ctx, name)
+	ret0, _ := ret[0].(*models.Layer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerByName indicates an expected call of GetLayerByName.
+func (mr *MockLayerRepositoryMockRecorder) GetLayerByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerByName", reflect.TypeOf((*MockLayerRepository)(nil).GetLayerByName), ctx, name)
+}
+
+// GetLayers mocks base method.
+func (m *MockLayerRepository) GetLayers(ctx context.Context, offset, limit int) ([]models.Layer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayers", ctx, offset, limit)
+	ret0, _ := ret[0].([]models.Layer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayers indicates an expected call of GetLayers.
+func (mr *MockLayerRepositoryMockRecorder) GetLayers(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayers", reflect.TypeOf((*MockLayerRepository)(nil).GetLayers), ctx, offset, limit)
+}
+
+// UpdateLayer mocks base method.
+func (m *MockLayerRepository) UpdateLayer(ctx context.Context, layer *models.Layer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLayer", ctx, layer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLayer indicates an expected call of UpdateLayer.
+func (mr *MockLayerRepositoryMockRecorder) UpdateLayer(ctx, layer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLayer", reflect.TypeOf((*MockLayerRepository)(nil).UpdateLayer), ctx, layer)
+}
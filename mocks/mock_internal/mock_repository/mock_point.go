@@ -10,7 +10,9 @@
 package mock_repository
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/malamsyah/geo-service/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -40,88 +42,311 @@ func (m *MockPointRepository) EXPECT() *MockPointRepositoryMockRecorder {
 }
 
 // CreatePoint mocks base method.
-func (m *MockPointRepository) CreatePoint(point *models.Point) error {
+func (m *MockPointRepository) CreatePoint(ctx context.Context, point *models.Point) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePoint", point)
+	ret := m.ctrl.Call(m, "CreatePoint", ctx, point)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePoint indicates an expected call of CreatePoint.
-func (mr *MockPointRepositoryMockRecorder) CreatePoint(point any) *gomock.Call {
+func (mr *MockPointRepositoryMockRecorder) CreatePoint(ctx, point any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoint", reflect.TypeOf((*MockPointRepository)(nil).CreatePoint), point)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoint", reflect.TypeOf((*MockPointRepository)(nil).CreatePoint), ctx, point)
+}
+
+// CreatePoints mocks base method.
+func (m *MockPointRepository) CreatePoints(ctx context.Context, points []models.Point, batchSize int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePoints", ctx, points, batchSize)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePoints indicates an expected call of CreatePoints.
+func (mr *MockPointRepositoryMockRecorder) CreatePoints(ctx, points, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoints", reflect.TypeOf((*MockPointRepository)(nil).CreatePoints), ctx, points, batchSize)
 }
 
 // DeletePoint mocks base method.
-func (m *MockPointRepository) DeletePoint(id uint) error {
+func (m *MockPointRepository) DeletePoint(ctx context.Context, id, version uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePoint", id)
+	ret := m.ctrl.Call(m, "DeletePoint", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePoint indicates an expected call of DeletePoint.
-func (mr *MockPointRepositoryMockRecorder) DeletePoint(id any) *gomock.Call {
+func (mr *MockPointRepositoryMockRecorder) DeletePoint(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoint", reflect.TypeOf((*MockPointRepository)(nil).DeletePoint), ctx, id, version)
+}
+
+// GetDelaunayTriangles mocks base method.
+func (m *MockPointRepository) GetDelaunayTriangles(ctx context.Context, input models.VoronoiInput) ([]models.Geometry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelaunayTriangles", ctx, input)
+	ret0, _ := ret[0].([]models.Geometry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelaunayTriangles indicates an expected call of GetDelaunayTriangles.
+func (mr *MockPointRepositoryMockRecorder) GetDelaunayTriangles(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelaunayTriangles", reflect.TypeOf((*MockPointRepository)(nil).GetDelaunayTriangles), ctx, input)
+}
+
+// GetDuplicatePoint mocks base method.
+func (m *MockPointRepository) GetDuplicatePoint(ctx context.Context, point *models.Point, tolerance float64) (*models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicatePoint", ctx, point, tolerance)
+	ret0, _ := ret[0].(*models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicatePoint indicates an expected call of GetDuplicatePoint.
+func (mr *MockPointRepositoryMockRecorder) GetDuplicatePoint(ctx, point, tolerance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicatePoint", reflect.TypeOf((*MockPointRepository)(nil).GetDuplicatePoint), ctx, point, tolerance)
+}
+
+// GetDuplicatePointPairs mocks base method.
+func (m *MockPointRepository) GetDuplicatePointPairs(ctx context.Context, tolerance float64) ([][2]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicatePointPairs", ctx, tolerance)
+	ret0, _ := ret[0].([][2]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicatePointPairs indicates an expected call of GetDuplicatePointPairs.
+func (mr *MockPointRepositoryMockRecorder) GetDuplicatePointPairs(ctx, tolerance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicatePointPairs", reflect.TypeOf((*MockPointRepository)(nil).GetDuplicatePointPairs), ctx, tolerance)
+}
+
+// GetPointBuffer mocks base method.
+func (m *MockPointRepository) GetPointBuffer(ctx context.Context, id uint, buffer models.Buffer) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointBuffer", ctx, id, buffer)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointBuffer indicates an expected call of GetPointBuffer.
+func (mr *MockPointRepositoryMockRecorder) GetPointBuffer(ctx, id, buffer any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoint", reflect.TypeOf((*MockPointRepository)(nil).DeletePoint), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointBuffer", reflect.TypeOf((*MockPointRepository)(nil).GetPointBuffer), ctx, id, buffer)
 }
 
 // GetPointByID mocks base method.
-func (m *MockPointRepository) GetPointByID(id uint) (*models.Point, error) {
+func (m *MockPointRepository) GetPointByID(ctx context.Context, id uint) (*models.Point, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPointByID", id)
+	ret := m.ctrl.Call(m, "GetPointByID", ctx, id)
 	ret0, _ := ret[0].(*models.Point)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPointByID indicates an expected call of GetPointByID.
-func (mr *MockPointRepositoryMockRecorder) GetPointByID(id any) *gomock.Call {
+func (mr *MockPointRepositoryMockRecorder) GetPointByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointByID", reflect.TypeOf((*MockPointRepository)(nil).GetPointByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointByID", reflect.TypeOf((*MockPointRepository)(nil).GetPointByID), ctx, id)
+}
+
+// GetPointContourDistance mocks base method.
+func (m *MockPointRepository) GetPointContourDistance(ctx context.Context, pointID, contourID uint) (*models.Distance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointContourDistance", ctx, pointID, contourID)
+	ret0, _ := ret[0].(*models.Distance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointContourDistance indicates an expected call of GetPointContourDistance.
+func (mr *MockPointRepositoryMockRecorder) GetPointContourDistance(ctx, pointID, contourID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointContourDistance", reflect.TypeOf((*MockPointRepository)(nil).GetPointContourDistance), ctx, pointID, contourID)
 }
 
 // GetPoints mocks base method.
-func (m *MockPointRepository) GetPoints(offset, limit int) ([]models.Point, error) {
+func (m *MockPointRepository) GetPoints(ctx context.Context, offset, limit int) ([]models.Point, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPoints", offset, limit)
+	ret := m.ctrl.Call(m, "GetPoints", ctx, offset, limit)
 	ret0, _ := ret[0].([]models.Point)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPoints indicates an expected call of GetPoints.
-func (mr *MockPointRepositoryMockRecorder) GetPoints(offset, limit any) *gomock.Call {
+func (mr *MockPointRepositoryMockRecorder) GetPoints(ctx, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoints", reflect.TypeOf((*MockPointRepository)(nil).GetPoints), offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoints", reflect.TypeOf((*MockPointRepository)(nil).GetPoints), ctx, offset, limit)
+}
+
+// GetPointsByBBox mocks base method.
+func (m *MockPointRepository) GetPointsByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsByBBox", ctx, bbox, offset, limit)
+	ret0, _ := ret[0].([]models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsByBBox indicates an expected call of GetPointsByBBox.
+func (mr *MockPointRepositoryMockRecorder) GetPointsByBBox(ctx, bbox, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByBBox", reflect.TypeOf((*MockPointRepository)(nil).GetPointsByBBox), ctx, bbox, offset, limit)
 }
 
 // GetPointsByContourID mocks base method.
-func (m *MockPointRepository) GetPointsByContourID(contourID uint) ([]models.Point, error) {
+func (m *MockPointRepository) GetPointsByContourID(ctx context.Context, contourID uint) ([]models.Point, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPointsByContourID", contourID)
+	ret := m.ctrl.Call(m, "GetPointsByContourID", ctx, contourID)
 	ret0, _ := ret[0].([]models.Point)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPointsByContourID indicates an expected call of GetPointsByContourID.
-func (mr *MockPointRepositoryMockRecorder) GetPointsByContourID(contourID any) *gomock.Call {
+func (mr *MockPointRepositoryMockRecorder) GetPointsByContourID(ctx, contourID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByContourID", reflect.TypeOf((*MockPointRepository)(nil).GetPointsByContourID), ctx, contourID)
+}
+
+// GetPointsByGeohash mocks base method.
+func (m *MockPointRepository) GetPointsByGeohash(ctx context.Context, prefix string, offset, limit int) ([]models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsByGeohash", ctx, prefix, offset, limit)
+	ret0, _ := ret[0].([]models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsByGeohash indicates an expected call of GetPointsByGeohash.
+func (mr *MockPointRepositoryMockRecorder) GetPointsByGeohash(ctx, prefix, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByGeohash", reflect.TypeOf((*MockPointRepository)(nil).GetPointsByGeohash), ctx, prefix, offset, limit)
+}
+
+// GetPointsByIDs mocks base method.
+func (m *MockPointRepository) GetPointsByIDs(ctx context.Context, ids []uint) ([]models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsByIDs indicates an expected call of GetPointsByIDs.
+func (mr *MockPointRepositoryMockRecorder) GetPointsByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByIDs", reflect.TypeOf((*MockPointRepository)(nil).GetPointsByIDs), ctx, ids)
+}
+
+// GetPointsGrid mocks base method.
+func (m *MockPointRepository) GetPointsGrid(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsGrid", ctx, grid, bbox, contourID)
+	ret0, _ := ret[0].([]models.Cell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsGrid indicates an expected call of GetPointsGrid.
+func (mr *MockPointRepositoryMockRecorder) GetPointsGrid(ctx, grid, bbox, contourID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsGrid", reflect.TypeOf((*MockPointRepository)(nil).GetPointsGrid), ctx, grid, bbox, contourID)
+}
+
+// GetPointsHull mocks base method.
+func (m *MockPointRepository) GetPointsHull(ctx context.Context, hull models.Hull, input models.HullInput) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsHull", ctx, hull, input)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsHull indicates an expected call of GetPointsHull.
+func (mr *MockPointRepositoryMockRecorder) GetPointsHull(ctx, hull, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsHull", reflect.TypeOf((*MockPointRepository)(nil).GetPointsHull), ctx, hull, input)
+}
+
+// GetPointsTile mocks base method.
+func (m *MockPointRepository) GetPointsTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsTile", ctx, tile)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsTile indicates an expected call of GetPointsTile.
+func (mr *MockPointRepositoryMockRecorder) GetPointsTile(ctx, tile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsTile", reflect.TypeOf((*MockPointRepository)(nil).GetPointsTile), ctx, tile)
+}
+
+// GetVoronoiCells mocks base method.
+func (m *MockPointRepository) GetVoronoiCells(ctx context.Context, input models.VoronoiInput) ([]models.VoronoiCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoronoiCells", ctx, input)
+	ret0, _ := ret[0].([]models.VoronoiCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoronoiCells indicates an expected call of GetVoronoiCells.
+func (mr *MockPointRepositoryMockRecorder) GetVoronoiCells(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoronoiCells", reflect.TypeOf((*MockPointRepository)(nil).GetVoronoiCells), ctx, input)
+}
+
+// PurgePoints mocks base method.
+func (m *MockPointRepository) PurgePoints(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePoints", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgePoints indicates an expected call of PurgePoints.
+func (mr *MockPointRepositoryMockRecorder) PurgePoints(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePoints", reflect.TypeOf((*MockPointRepository)(nil).PurgePoints), ctx, before)
+}
+
+// StreamPoints mocks base method.
+func (m *MockPointRepository) StreamPoints(ctx context.Context, bbox *models.BBox, contourID uint, fn func(*models.Point) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPoints", ctx, bbox, contourID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPoints indicates an expected call of StreamPoints.
+func (mr *MockPointRepositoryMockRecorder) StreamPoints(ctx, bbox, contourID, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByContourID", reflect.TypeOf((*MockPointRepository)(nil).GetPointsByContourID), contourID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPoints", reflect.TypeOf((*MockPointRepository)(nil).StreamPoints), ctx, bbox, contourID, fn)
 }
 
 // UpdatePoint mocks base method.
-func (m *MockPointRepository) UpdatePoint(point *models.Point) error {
+func (m *MockPointRepository) UpdatePoint(ctx context.Context, point *models.Point) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePoint", point)
+	ret := m.ctrl.Call(m, "UpdatePoint", ctx, point)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePoint indicates an expected call of UpdatePoint.
-func (mr *MockPointRepositoryMockRecorder) UpdatePoint(point any) *gomock.Call {
+func (mr *MockPointRepositoryMockRecorder) UpdatePoint(ctx, point any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePoint", reflect.TypeOf((*MockPointRepository)(nil).UpdatePoint), point)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePoint", reflect.TypeOf((*MockPointRepository)(nil).UpdatePoint), ctx, point)
 }
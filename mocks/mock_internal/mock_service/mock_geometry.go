@@ -10,9 +10,14 @@
 package mock_service
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
+	codec "github.com/malamsyah/geo-service/internal/codec"
 	models "github.com/malamsyah/geo-service/internal/models"
+	service "github.com/malamsyah/geo-service/internal/service"
+	supercluster "github.com/malamsyah/geo-service/pkg/supercluster"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,136 +44,536 @@ func (m *MockGeometryService) EXPECT() *MockGeometryServiceMockRecorder {
 	return m.recorder
 }
 
+// AggregatePoints mocks base method.
+func (m *MockGeometryService) AggregatePoints(ctx context.Context, grid models.Grid, bbox *models.BBox, contourID uint) ([]models.Cell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregatePoints", ctx, grid, bbox, contourID)
+	ret0, _ := ret[0].([]models.Cell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregatePoints indicates an expected call of AggregatePoints.
+func (mr *MockGeometryServiceMockRecorder) AggregatePoints(ctx, grid, bbox, contourID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregatePoints", reflect.TypeOf((*MockGeometryService)(nil).AggregatePoints), ctx, grid, bbox, contourID)
+}
+
+// BufferContour mocks base method.
+func (m *MockGeometryService) BufferContour(ctx context.Context, id uint, buffer models.Buffer, persist bool) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BufferContour", ctx, id, buffer, persist)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BufferContour indicates an expected call of BufferContour.
+func (mr *MockGeometryServiceMockRecorder) BufferContour(ctx, id, buffer, persist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BufferContour", reflect.TypeOf((*MockGeometryService)(nil).BufferContour), ctx, id, buffer, persist)
+}
+
+// BufferPoint mocks base method.
+func (m *MockGeometryService) BufferPoint(ctx context.Context, id uint, buffer models.Buffer, persist bool) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BufferPoint", ctx, id, buffer, persist)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BufferPoint indicates an expected call of BufferPoint.
+func (mr *MockGeometryServiceMockRecorder) BufferPoint(ctx, id, buffer, persist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BufferPoint", reflect.TypeOf((*MockGeometryService)(nil).BufferPoint), ctx, id, buffer, persist)
+}
+
+// BulkCreateContours mocks base method.
+func (m *MockGeometryService) BulkCreateContours(ctx context.Context, features []codec.Feature, mode service.BulkMode) ([]service.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateContours", ctx, features, mode)
+	ret0, _ := ret[0].([]service.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateContours indicates an expected call of BulkCreateContours.
+func (mr *MockGeometryServiceMockRecorder) BulkCreateContours(ctx, features, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateContours", reflect.TypeOf((*MockGeometryService)(nil).BulkCreateContours), ctx, features, mode)
+}
+
+// BulkCreatePoints mocks base method.
+func (m *MockGeometryService) BulkCreatePoints(ctx context.Context, features []codec.Feature, mode service.BulkMode) ([]service.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreatePoints", ctx, features, mode)
+	ret0, _ := ret[0].([]service.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreatePoints indicates an expected call of BulkCreatePoints.
+func (mr *MockGeometryServiceMockRecorder) BulkCreatePoints(ctx, features, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreatePoints", reflect.TypeOf((*MockGeometryService)(nil).BulkCreatePoints), ctx, features, mode)
+}
+
 // CreateContour mocks base method.
-func (m *MockGeometryService) CreateContour(Contour *models.Contour) error {
+func (m *MockGeometryService) CreateContour(ctx context.Context, Contour *models.Contour) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateContour", Contour)
+	ret := m.ctrl.Call(m, "CreateContour", ctx, Contour)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateContour indicates an expected call of CreateContour.
-func (mr *MockGeometryServiceMockRecorder) CreateContour(Contour any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) CreateContour(ctx, Contour any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContour", reflect.TypeOf((*MockGeometryService)(nil).CreateContour), Contour)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContour", reflect.TypeOf((*MockGeometryService)(nil).CreateContour), ctx, Contour)
+}
+
+// CreateLayer mocks base method.
+func (m *MockGeometryService) CreateLayer(ctx context.Context, layer *models.Layer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLayer", ctx, layer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLayer indicates an expected call of CreateLayer.
+func (mr *MockGeometryServiceMockRecorder) CreateLayer(ctx, layer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLayer", reflect.TypeOf((*MockGeometryService)(nil).CreateLayer), ctx, layer)
 }
 
 // CreatePoint mocks base method.
-func (m *MockGeometryService) CreatePoint(point *models.Point) error {
+func (m *MockGeometryService) CreatePoint(ctx context.Context, point *models.Point) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePoint", point)
+	ret := m.ctrl.Call(m, "CreatePoint", ctx, point)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePoint indicates an expected call of CreatePoint.
-func (mr *MockGeometryServiceMockRecorder) CreatePoint(point any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) CreatePoint(ctx, point any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoint", reflect.TypeOf((*MockGeometryService)(nil).CreatePoint), point)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoint", reflect.TypeOf((*MockGeometryService)(nil).CreatePoint), ctx, point)
+}
+
+// CreateUniquePoint mocks base method.
+func (m *MockGeometryService) CreateUniquePoint(ctx context.Context, point *models.Point, policy service.DuplicatePolicy, tolerance float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUniquePoint", ctx, point, policy, tolerance)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUniquePoint indicates an expected call of CreateUniquePoint.
+func (mr *MockGeometryServiceMockRecorder) CreateUniquePoint(ctx, point, policy, tolerance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUniquePoint", reflect.TypeOf((*MockGeometryService)(nil).CreateUniquePoint), ctx, point, policy, tolerance)
+}
+
+// DedupePoints mocks base method.
+func (m *MockGeometryService) DedupePoints(ctx context.Context, tolerance float64, dryRun bool) ([]service.DuplicateGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DedupePoints", ctx, tolerance, dryRun)
+	ret0, _ := ret[0].([]service.DuplicateGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DedupePoints indicates an expected call of DedupePoints.
+func (mr *MockGeometryServiceMockRecorder) DedupePoints(ctx, tolerance, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedupePoints", reflect.TypeOf((*MockGeometryService)(nil).DedupePoints), ctx, tolerance, dryRun)
 }
 
 // DeleteContour mocks base method.
-func (m *MockGeometryService) DeleteContour(id uint) error {
+func (m *MockGeometryService) DeleteContour(ctx context.Context, id, version uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteContour", id)
+	ret := m.ctrl.Call(m, "DeleteContour", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteContour indicates an expected call of DeleteContour.
-func (mr *MockGeometryServiceMockRecorder) DeleteContour(id any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) DeleteContour(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContour", reflect.TypeOf((*MockGeometryService)(nil).DeleteContour), ctx, id, version)
+}
+
+// DeleteLayer mocks base method.
+func (m *MockGeometryService) DeleteLayer(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLayer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLayer indicates an expected call of DeleteLayer.
+func (mr *MockGeometryServiceMockRecorder) DeleteLayer(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContour", reflect.TypeOf((*MockGeometryService)(nil).DeleteContour), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLayer", reflect.TypeOf((*MockGeometryService)(nil).DeleteLayer), ctx, id)
+}
+
+// DeletePoint mocks base method.
+func (m *MockGeometryService) DeletePoint(ctx context.Context, id, version uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePoint", ctx, id, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePoint indicates an expected call of DeletePoint.
+func (mr *MockGeometryServiceMockRecorder) DeletePoint(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePoint", reflect.TypeOf((*MockGeometryService)(nil).DeletePoint), ctx, id, version)
+}
+
+// ExportContours mocks base method.
+func (m *MockGeometryService) ExportContours(ctx context.Context, bbox *models.BBox, fn func(*models.Contour) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportContours", ctx, bbox, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportContours indicates an expected call of ExportContours.
+func (mr *MockGeometryServiceMockRecorder) ExportContours(ctx, bbox, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportContours", reflect.TypeOf((*MockGeometryService)(nil).ExportContours), ctx, bbox, fn)
+}
+
+// ExportPoints mocks base method.
+func (m *MockGeometryService) ExportPoints(ctx context.Context, bbox *models.BBox, contourID uint, fn func(*models.Point) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPoints", ctx, bbox, contourID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportPoints indicates an expected call of ExportPoints.
+func (mr *MockGeometryServiceMockRecorder) ExportPoints(ctx, bbox, contourID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPoints", reflect.TypeOf((*MockGeometryService)(nil).ExportPoints), ctx, bbox, contourID, fn)
+}
+
+// GetContourAsOf mocks base method.
+func (m *MockGeometryService) GetContourAsOf(ctx context.Context, id uint, at time.Time) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContourAsOf", ctx, id, at)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContourAsOf indicates an expected call of GetContourAsOf.
+func (mr *MockGeometryServiceMockRecorder) GetContourAsOf(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourAsOf", reflect.TypeOf((*MockGeometryService)(nil).GetContourAsOf), ctx, id, at)
 }
 
 // GetContourByID mocks base method.
-func (m *MockGeometryService) GetContourByID(id uint) (*models.Contour, error) {
+func (m *MockGeometryService) GetContourByID(ctx context.Context, id uint) (*models.Contour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContourByID", id)
+	ret := m.ctrl.Call(m, "GetContourByID", ctx, id)
 	ret0, _ := ret[0].(*models.Contour)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContourByID indicates an expected call of GetContourByID.
-func (mr *MockGeometryServiceMockRecorder) GetContourByID(id any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) GetContourByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourByID", reflect.TypeOf((*MockGeometryService)(nil).GetContourByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourByID", reflect.TypeOf((*MockGeometryService)(nil).GetContourByID), ctx, id)
+}
+
+// GetContourHistory mocks base method.
+func (m *MockGeometryService) GetContourHistory(ctx context.Context, id uint) ([]models.ContourHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContourHistory", ctx, id)
+	ret0, _ := ret[0].([]models.ContourHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContourHistory indicates an expected call of GetContourHistory.
+func (mr *MockGeometryServiceMockRecorder) GetContourHistory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContourHistory", reflect.TypeOf((*MockGeometryService)(nil).GetContourHistory), ctx, id)
 }
 
 // GetContours mocks base method.
-func (m *MockGeometryService) GetContours(offset, limit int) ([]models.Contour, error) {
+func (m *MockGeometryService) GetContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContours", offset, limit)
+	ret := m.ctrl.Call(m, "GetContours", ctx, offset, limit)
 	ret0, _ := ret[0].([]models.Contour)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContours indicates an expected call of GetContours.
-func (mr *MockGeometryServiceMockRecorder) GetContours(offset, limit any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) GetContours(ctx, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContours", reflect.TypeOf((*MockGeometryService)(nil).GetContours), offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContours", reflect.TypeOf((*MockGeometryService)(nil).GetContours), ctx, offset, limit)
+}
+
+// GetContoursByBBox mocks base method.
+func (m *MockGeometryService) GetContoursByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContoursByBBox", ctx, bbox, offset, limit)
+	ret0, _ := ret[0].([]models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContoursByBBox indicates an expected call of GetContoursByBBox.
+func (mr *MockGeometryServiceMockRecorder) GetContoursByBBox(ctx, bbox, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursByBBox", reflect.TypeOf((*MockGeometryService)(nil).GetContoursByBBox), ctx, bbox, offset, limit)
 }
 
 // GetContoursIntersectArea mocks base method.
-func (m *MockGeometryService) GetContoursIntersectArea(contourIDA, contourIDB uint) ([]models.Contour, error) {
+func (m *MockGeometryService) GetContoursIntersectArea(ctx context.Context, contourIDA, contourIDB uint) ([]models.Contour, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContoursIntersectArea", contourIDA, contourIDB)
+	ret := m.ctrl.Call(m, "GetContoursIntersectArea", ctx, contourIDA, contourIDB)
 	ret0, _ := ret[0].([]models.Contour)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContoursIntersectArea indicates an expected call of GetContoursIntersectArea.
-func (mr *MockGeometryServiceMockRecorder) GetContoursIntersectArea(contourIDA, contourIDB any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) GetContoursIntersectArea(ctx, contourIDA, contourIDB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursIntersectArea", reflect.TypeOf((*MockGeometryService)(nil).GetContoursIntersectArea), ctx, contourIDA, contourIDB)
+}
+
+// GetDelaunayTriangles mocks base method.
+func (m *MockGeometryService) GetDelaunayTriangles(ctx context.Context, input models.VoronoiInput) ([]models.Geometry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelaunayTriangles", ctx, input)
+	ret0, _ := ret[0].([]models.Geometry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelaunayTriangles indicates an expected call of GetDelaunayTriangles.
+func (mr *MockGeometryServiceMockRecorder) GetDelaunayTriangles(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelaunayTriangles", reflect.TypeOf((*MockGeometryService)(nil).GetDelaunayTriangles), ctx, input)
+}
+
+// GetDeletedContours mocks base method.
+func (m *MockGeometryService) GetDeletedContours(ctx context.Context, offset, limit int) ([]models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedContours", ctx, offset, limit)
+	ret0, _ := ret[0].([]models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedContours indicates an expected call of GetDeletedContours.
+func (mr *MockGeometryServiceMockRecorder) GetDeletedContours(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedContours", reflect.TypeOf((*MockGeometryService)(nil).GetDeletedContours), ctx, offset, limit)
+}
+
+// GetLayerByID mocks base method.
+func (m *MockGeometryService) GetLayerByID(ctx context.Context, id uint) (*models.Layer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerByID", ctx, id)
+	ret0, _ := ret[0].(*models.Layer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerByID indicates an expected call of GetLayerByID.
+func (mr *MockGeometryServiceMockRecorder) GetLayerByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContoursIntersectArea", reflect.TypeOf((*MockGeometryService)(nil).GetContoursIntersectArea), contourIDA, contourIDB)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerByID", reflect.TypeOf((*MockGeometryService)(nil).GetLayerByID), ctx, id)
+}
+
+// GetLayers mocks base method.
+func (m *MockGeometryService) GetLayers(ctx context.Context, offset, limit int) ([]models.Layer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayers", ctx, offset, limit)
+	ret0, _ := ret[0].([]models.Layer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayers indicates an expected call of GetLayers.
+func (mr *MockGeometryServiceMockRecorder) GetLayers(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayers", reflect.TypeOf((*MockGeometryService)(nil).GetLayers), ctx, offset, limit)
 }
 
 // GetPointByID mocks base method.
-func (m *MockGeometryService) GetPointByID(id uint) (*models.Point, error) {
+func (m *MockGeometryService) GetPointByID(ctx context.Context, id uint) (*models.Point, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPointByID", id)
+	ret := m.ctrl.Call(m, "GetPointByID", ctx, id)
 	ret0, _ := ret[0].(*models.Point)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPointByID indicates an expected call of GetPointByID.
-func (mr *MockGeometryServiceMockRecorder) GetPointByID(id any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) GetPointByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointByID", reflect.TypeOf((*MockGeometryService)(nil).GetPointByID), ctx, id)
+}
+
+// GetPointClusters mocks base method.
+func (m *MockGeometryService) GetPointClusters(ctx context.Context, bbox models.BBox, zoom int) ([]supercluster.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointClusters", ctx, bbox, zoom)
+	ret0, _ := ret[0].([]supercluster.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointClusters indicates an expected call of GetPointClusters.
+func (mr *MockGeometryServiceMockRecorder) GetPointClusters(ctx, bbox, zoom any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointClusters", reflect.TypeOf((*MockGeometryService)(nil).GetPointClusters), ctx, bbox, zoom)
+}
+
+// GetPointContourDistance mocks base method.
+func (m *MockGeometryService) GetPointContourDistance(ctx context.Context, pointID, contourID uint) (*models.Distance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointContourDistance", ctx, pointID, contourID)
+	ret0, _ := ret[0].(*models.Distance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointContourDistance indicates an expected call of GetPointContourDistance.
+func (mr *MockGeometryServiceMockRecorder) GetPointContourDistance(ctx, pointID, contourID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointByID", reflect.TypeOf((*MockGeometryService)(nil).GetPointByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointContourDistance", reflect.TypeOf((*MockGeometryService)(nil).GetPointContourDistance), ctx, pointID, contourID)
 }
 
 // GetPoints mocks base method.
-func (m *MockGeometryService) GetPoints(offset, limit int) ([]models.Point, error) {
+func (m *MockGeometryService) GetPoints(ctx context.Context, offset, limit int) ([]models.Point, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPoints", offset, limit)
+	ret := m.ctrl.Call(m, "GetPoints", ctx, offset, limit)
 	ret0, _ := ret[0].([]models.Point)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPoints indicates an expected call of GetPoints.
-func (mr *MockGeometryServiceMockRecorder) GetPoints(offset, limit any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) GetPoints(ctx, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoints", reflect.TypeOf((*MockGeometryService)(nil).GetPoints), offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoints", reflect.TypeOf((*MockGeometryService)(nil).GetPoints), ctx, offset, limit)
+}
+
+// GetPointsByBBox mocks base method.
+func (m *MockGeometryService) GetPointsByBBox(ctx context.Context, bbox models.BBox, offset, limit int) ([]models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsByBBox", ctx, bbox, offset, limit)
+	ret0, _ := ret[0].([]models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsByBBox indicates an expected call of GetPointsByBBox.
+func (mr *MockGeometryServiceMockRecorder) GetPointsByBBox(ctx, bbox, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByBBox", reflect.TypeOf((*MockGeometryService)(nil).GetPointsByBBox), ctx, bbox, offset, limit)
 }
 
 // GetPointsByContourID mocks base method.
-func (m *MockGeometryService) GetPointsByContourID(contourID uint) ([]models.Point, error) {
+func (m *MockGeometryService) GetPointsByContourID(ctx context.Context, contourID uint) ([]models.Point, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPointsByContourID", contourID)
+	ret := m.ctrl.Call(m, "GetPointsByContourID", ctx, contourID)
 	ret0, _ := ret[0].([]models.Point)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPointsByContourID indicates an expected call of GetPointsByContourID.
-func (mr *MockGeometryServiceMockRecorder) GetPointsByContourID(contourID any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) GetPointsByContourID(ctx, contourID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByContourID", reflect.TypeOf((*MockGeometryService)(nil).GetPointsByContourID), ctx, contourID)
+}
+
+// GetPointsByGeohash mocks base method.
+func (m *MockGeometryService) GetPointsByGeohash(ctx context.Context, prefix string, offset, limit int) ([]models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsByGeohash", ctx, prefix, offset, limit)
+	ret0, _ := ret[0].([]models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsByGeohash indicates an expected call of GetPointsByGeohash.
+func (mr *MockGeometryServiceMockRecorder) GetPointsByGeohash(ctx, prefix, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByGeohash", reflect.TypeOf((*MockGeometryService)(nil).GetPointsByGeohash), ctx, prefix, offset, limit)
+}
+
+// GetPointsHull mocks base method.
+func (m *MockGeometryService) GetPointsHull(ctx context.Context, hull models.Hull, input models.HullInput, persist bool) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsHull", ctx, hull, input, persist)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsHull indicates an expected call of GetPointsHull.
+func (mr *MockGeometryServiceMockRecorder) GetPointsHull(ctx, hull, input, persist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsHull", reflect.TypeOf((*MockGeometryService)(nil).GetPointsHull), ctx, hull, input, persist)
+}
+
+// GetSimplifiedContour mocks base method.
+func (m *MockGeometryService) GetSimplifiedContour(ctx context.Context, id uint) (*models.SimplifiedContour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimplifiedContour", ctx, id)
+	ret0, _ := ret[0].(*models.SimplifiedContour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimplifiedContour indicates an expected call of GetSimplifiedContour.
+func (mr *MockGeometryServiceMockRecorder) GetSimplifiedContour(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimplifiedContour", reflect.TypeOf((*MockGeometryService)(nil).GetSimplifiedContour), ctx, id)
+}
+
+// GetTile mocks base method.
+func (m *MockGeometryService) GetTile(ctx context.Context, tile models.Tile) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTile", ctx, tile)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTile indicates an expected call of GetTile.
+func (mr *MockGeometryServiceMockRecorder) GetTile(ctx, tile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTile", reflect.TypeOf((*MockGeometryService)(nil).GetTile), ctx, tile)
+}
+
+// GetVoronoiCells mocks base method.
+func (m *MockGeometryService) GetVoronoiCells(ctx context.Context, input models.VoronoiInput, persist bool) ([]models.VoronoiCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoronoiCells", ctx, input, persist)
+	ret0, _ := ret[0].([]models.VoronoiCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoronoiCells indicates an expected call of GetVoronoiCells.
+func (mr *MockGeometryServiceMockRecorder) GetVoronoiCells(ctx, input, persist any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsByContourID", reflect.TypeOf((*MockGeometryService)(nil).GetPointsByContourID), contourID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoronoiCells", reflect.TypeOf((*MockGeometryService)(nil).GetVoronoiCells), ctx, input, persist)
 }
 
 // IsValidContour mocks base method.
@@ -199,16 +604,150 @@ func (mr *MockGeometryServiceMockRecorder) IsValidPoint(point any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValidPoint", reflect.TypeOf((*MockGeometryService)(nil).IsValidPoint), point)
 }
 
+// PatchContour mocks base method.
+func (m *MockGeometryService) PatchContour(ctx context.Context, id, expected uint, patch models.Patch) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchContour", ctx, id, expected, patch)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchContour indicates an expected call of PatchContour.
+func (mr *MockGeometryServiceMockRecorder) PatchContour(ctx, id, expected, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchContour", reflect.TypeOf((*MockGeometryService)(nil).PatchContour), ctx, id, expected, patch)
+}
+
+// PatchPoint mocks base method.
+func (m *MockGeometryService) PatchPoint(ctx context.Context, id, expected uint, patch models.Patch) (*models.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchPoint", ctx, id, expected, patch)
+	ret0, _ := ret[0].(*models.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchPoint indicates an expected call of PatchPoint.
+func (mr *MockGeometryServiceMockRecorder) PatchPoint(ctx, id, expected, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchPoint", reflect.TypeOf((*MockGeometryService)(nil).PatchPoint), ctx, id, expected, patch)
+}
+
+// RestoreContour mocks base method.
+func (m *MockGeometryService) RestoreContour(ctx context.Context, id, version, expected uint) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreContour", ctx, id, version, expected)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreContour indicates an expected call of RestoreContour.
+func (mr *MockGeometryServiceMockRecorder) RestoreContour(ctx, id, version, expected any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreContour", reflect.TypeOf((*MockGeometryService)(nil).RestoreContour), ctx, id, version, expected)
+}
+
+// SimplifyContour mocks base method.
+func (m *MockGeometryService) SimplifyContour(ctx context.Context, id uint, tolerance float64) (*models.SimplifiedContour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimplifyContour", ctx, id, tolerance)
+	ret0, _ := ret[0].(*models.SimplifiedContour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimplifyContour indicates an expected call of SimplifyContour.
+func (mr *MockGeometryServiceMockRecorder) SimplifyContour(ctx, id, tolerance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimplifyContour", reflect.TypeOf((*MockGeometryService)(nil).SimplifyContour), ctx, id, tolerance)
+}
+
+// SnapPoint mocks base method.
+func (m *MockGeometryService) SnapPoint(ctx context.Context, pointID, contourID uint, tolerance float64) (*models.Point, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapPoint", ctx, pointID, contourID, tolerance)
+	ret0, _ := ret[0].(*models.Point)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SnapPoint indicates an expected call of SnapPoint.
+func (mr *MockGeometryServiceMockRecorder) SnapPoint(ctx, pointID, contourID, tolerance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapPoint", reflect.TypeOf((*MockGeometryService)(nil).SnapPoint), ctx, pointID, contourID, tolerance)
+}
+
+// TessellateContour mocks base method.
+func (m *MockGeometryService) TessellateContour(ctx context.Context, id uint, tessellation models.Tessellation, persist bool) ([]models.TessellationCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TessellateContour", ctx, id, tessellation, persist)
+	ret0, _ := ret[0].([]models.TessellationCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TessellateContour indicates an expected call of TessellateContour.
+func (mr *MockGeometryServiceMockRecorder) TessellateContour(ctx, id, tessellation, persist any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TessellateContour", reflect.TypeOf((*MockGeometryService)(nil).TessellateContour), ctx, id, tessellation, persist)
+}
+
+// UndeleteContour mocks base method.
+func (m *MockGeometryService) UndeleteContour(ctx context.Context, id uint) (*models.Contour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteContour", ctx, id)
+	ret0, _ := ret[0].(*models.Contour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndeleteContour indicates an expected call of UndeleteContour.
+func (mr *MockGeometryServiceMockRecorder) UndeleteContour(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteContour", reflect.TypeOf((*MockGeometryService)(nil).UndeleteContour), ctx, id)
+}
+
 // UpdateContour mocks base method.
-func (m *MockGeometryService) UpdateContour(Contour *models.Contour) error {
+func (m *MockGeometryService) UpdateContour(ctx context.Context, Contour *models.Contour) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateContour", Contour)
+	ret := m.ctrl.Call(m, "UpdateContour", ctx, Contour)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateContour indicates an expected call of UpdateContour.
-func (mr *MockGeometryServiceMockRecorder) UpdateContour(Contour any) *gomock.Call {
+func (mr *MockGeometryServiceMockRecorder) UpdateContour(ctx, Contour any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContour", reflect.TypeOf((*MockGeometryService)(nil).UpdateContour), ctx, Contour)
+}
+
+// UpdateLayer mocks base method.
+func (m *MockGeometryService) UpdateLayer(ctx context.Context, layer *models.Layer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLayer", ctx, layer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLayer indicates an expected call of UpdateLayer.
+func (mr *MockGeometryServiceMockRecorder) UpdateLayer(ctx, layer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLayer", reflect.TypeOf((*MockGeometryService)(nil).UpdateLayer), ctx, layer)
+}
+
+// UpdatePoint mocks base method.
+func (m *MockGeometryService) UpdatePoint(ctx context.Context, point *models.Point) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePoint", ctx, point)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePoint indicates an expected call of UpdatePoint.
+func (mr *MockGeometryServiceMockRecorder) UpdatePoint(ctx, point any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContour", reflect.TypeOf((*MockGeometryService)(nil).UpdateContour), Contour)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePoint", reflect.TypeOf((*MockGeometryService)(nil).UpdatePoint), ctx, point)
 }
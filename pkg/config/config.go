@@ -4,19 +4,40 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	AppPort    string
-	DBHost     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBPort     string
-	TZ         string
-	Host       string
+	AppPort          string
+	DBHost           string
+	DBUser           string
+	DBPassword       string
+	DBName           string
+	DBPort           string
+	TZ               string
+	Host             string
+	GeohashPrecision int
+	// RequestTimeout bounds every request, RouteTimeouts overriding it per
+	// route. Zero means no timeout.
+	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration
+	// TrashRetention is how long deleted points and contours stay in the
+	// trash before they are purged, every PurgeInterval. Zero keeps them.
+	TrashRetention time.Duration
+	PurgeInterval  time.Duration
+	// IdempotencyTTL is how long the responses to requests made with an
+	// Idempotency-Key are kept for their retries. Expired ones are purged
+	// every IdempotencyPurgeInterval.
+	IdempotencyTTL           time.Duration
+	IdempotencyPurgeInterval time.Duration
+	// AuthIssuerURL is the OIDC provider whose tokens, issued for
+	// AuthClientID, authenticate requests. Requests are not authenticated
+	// when it is empty.
+	AuthIssuerURL string
+	AuthClientID  string
 }
 
 // nolint: gochecknoglobals
@@ -38,19 +59,52 @@ func Load(path string) *Config {
 	}
 
 	configInstance = &Config{
-		AppPort:    viper.GetString("APP_PORT"),
-		DBHost:     viper.GetString("DB_HOST"),
-		DBUser:     viper.GetString("DB_USER"),
-		DBPassword: viper.GetString("DB_PASSWORD"),
-		DBName:     viper.GetString("DB_NAME"),
-		DBPort:     viper.GetString("DB_PORT"),
-		TZ:         viper.GetString("TZ"),
-		Host:       viper.GetString("HOST"),
+		AppPort:                  viper.GetString("APP_PORT"),
+		DBHost:                   viper.GetString("DB_HOST"),
+		DBUser:                   viper.GetString("DB_USER"),
+		DBPassword:               viper.GetString("DB_PASSWORD"),
+		DBName:                   viper.GetString("DB_NAME"),
+		DBPort:                   viper.GetString("DB_PORT"),
+		TZ:                       viper.GetString("TZ"),
+		Host:                     viper.GetString("HOST"),
+		GeohashPrecision:         viper.GetInt("GEOHASH_PRECISION"),
+		RequestTimeout:           viper.GetDuration("REQUEST_TIMEOUT"),
+		RouteTimeouts:            parseRouteTimeouts(viper.GetString("ROUTE_TIMEOUTS")),
+		TrashRetention:           viper.GetDuration("TRASH_RETENTION"),
+		PurgeInterval:            viper.GetDuration("PURGE_INTERVAL"),
+		IdempotencyTTL:           viper.GetDuration("IDEMPOTENCY_TTL"),
+		IdempotencyPurgeInterval: viper.GetDuration("IDEMPOTENCY_PURGE_INTERVAL"),
+		AuthIssuerURL:            viper.GetString("AUTH_ISSUER_URL"),
+		AuthClientID:             viper.GetString("AUTH_CLIENT_ID"),
 	}
 
 	return configInstance
 }
 
+// parseRouteTimeouts reads comma separated route timeouts written as
+// "METHOD /route/:param=duration". Malformed entries are logged and skipped.
+func parseRouteTimeouts(s string) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, value, found := strings.Cut(entry, "=")
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if !found || err != nil {
+			log.Printf("invalid route timeout %q, skipped", entry)
+			continue
+		}
+
+		timeouts[strings.Join(strings.Fields(route), " ")] = timeout
+	}
+
+	return timeouts
+}
+
 func Instance() *Config {
 	return configInstance
 }
@@ -0,0 +1,59 @@
+// Package convex computes the convex hull of planar positions with Andrew's
+// monotone chain algorithm.
+package convex
+
+import "sort"
+
+// Hull returns the convex hull of the positions as a closed counterclockwise
+// ring, starting from the lowest leftmost position. Collinear positions are
+// left out, so fewer than three distinct positions not on a line yield a ring
+// shorter than four positions.
+func Hull(positions [][2]float64) [][2]float64 {
+	sorted := make([][2]float64, len(positions))
+	copy(sorted, positions)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+
+		return sorted[i][1] < sorted[j][1]
+	})
+
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	hull := make([][2]float64, 0, 2*len(sorted))
+
+	// Lower hull from left to right, then upper hull from right to left.
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, p)
+	}
+
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, p)
+	}
+
+	// The last position closes the ring on the first one.
+	if len(hull) < 4 {
+		return hull[:len(hull)-1]
+	}
+
+	return hull
+}
+
+// cross returns the z component of the cross product of (b - a) and (c - a),
+// positive when a, b, c turn counterclockwise.
+func cross(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
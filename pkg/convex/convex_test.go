@@ -0,0 +1,22 @@
+package convex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHull(t *testing.T) {
+	positions := [][2]float64{{1, 1}, {0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 0}, {0.5, 1.5}, {2, 2}}
+	assert.Equal(t, [][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}, Hull(positions))
+
+	triangle := [][2]float64{{0, 0}, {1, 3}, {2, 0}}
+	assert.Equal(t, [][2]float64{{0, 0}, {2, 0}, {1, 3}, {0, 0}}, Hull(triangle))
+}
+
+func TestHull_Degenerate(t *testing.T) {
+	assert.Empty(t, Hull(nil))
+	assert.Len(t, Hull([][2]float64{{1, 1}}), 1)
+	assert.Less(t, len(Hull([][2]float64{{0, 0}, {1, 1}, {2, 2}, {3, 3}})), 4)
+	assert.Less(t, len(Hull([][2]float64{{1, 1}, {1, 1}, {1, 1}})), 4)
+}
@@ -0,0 +1,117 @@
+// Package geohash encodes lon/lat positions as geohashes and decodes
+// geohashes back to the cell they stand for.
+package geohash
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxPrecision is the longest geohash handled, about 37mm by 19mm.
+const MaxPrecision = 12
+
+const alphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+var ErrInvalidGeohash = errors.New("invalid geohash")
+
+// Box is the cell of a geohash.
+type Box struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// Center returns the lon/lat centre of the cell.
+func (b Box) Center() (float64, float64) {
+	return (b.MinLon + b.MaxLon) / 2, (b.MinLat + b.MaxLat) / 2
+}
+
+// Encode returns the geohash of a position with precision characters, clamped
+// to [1, MaxPrecision].
+func Encode(lon, lat float64, precision int) string {
+	precision = max(1, min(precision, MaxPrecision))
+	box := Box{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+
+	var sb strings.Builder
+	even := true
+	bits, ch := 0, 0
+
+	for sb.Len() < precision {
+		// Bits alternate between longitude and latitude, starting with
+		// longitude.
+		if even {
+			mid := (box.MinLon + box.MaxLon) / 2
+			if lon >= mid {
+				ch = ch<<1 | 1
+				box.MinLon = mid
+			} else {
+				ch <<= 1
+				box.MaxLon = mid
+			}
+		} else {
+			mid := (box.MinLat + box.MaxLat) / 2
+			if lat >= mid {
+				ch = ch<<1 | 1
+				box.MinLat = mid
+			} else {
+				ch <<= 1
+				box.MaxLat = mid
+			}
+		}
+
+		even = !even
+		if bits++; bits == 5 {
+			sb.WriteByte(alphabet[ch])
+			bits, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// Decode returns the cell of a geohash. Geohashes are case insensitive.
+func Decode(hash string) (Box, error) {
+	if len(hash) == 0 || len(hash) > MaxPrecision {
+		return Box{}, ErrInvalidGeohash
+	}
+
+	box := Box{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90}
+	even := true
+
+	for _, c := range strings.ToLower(hash) {
+		value := strings.IndexRune(alphabet, c)
+		if value < 0 {
+			return Box{}, ErrInvalidGeohash
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			set := value>>bit&1 == 1
+			if even {
+				mid := (box.MinLon + box.MaxLon) / 2
+				if set {
+					box.MinLon = mid
+				} else {
+					box.MaxLon = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if set {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+
+			even = !even
+		}
+	}
+
+	return box, nil
+}
+
+// Valid reports whether hash is a geohash.
+func Valid(hash string) bool {
+	_, err := Decode(hash)
+	return err == nil
+}
@@ -0,0 +1,43 @@
+package geohash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "u4pruydqqvj", Encode(10.40744, 57.64911, 11))
+	assert.Equal(t, "qqguygv", Encode(106.8271, -6.1754, 7))
+	assert.Equal(t, "s", Encode(0, 0, 0))
+	assert.Len(t, Encode(0, 0, 20), MaxPrecision)
+}
+
+func TestDecode(t *testing.T) {
+	box, err := Decode("u4pruydqqvj")
+	assert.NoError(t, err)
+	lon, lat := box.Center()
+	assert.InDelta(t, 10.40744, lon, 1e-5)
+	assert.InDelta(t, 57.64911, lat, 1e-5)
+
+	box, err = Decode("S")
+	assert.NoError(t, err)
+	assert.Equal(t, Box{MinLon: 0, MinLat: 0, MaxLon: 45, MaxLat: 45}, box)
+
+	for _, hash := range []string{"", "a", "w3gva", "0123456789bcd"} {
+		_, err = Decode(hash)
+		assert.ErrorIs(t, err, ErrInvalidGeohash, hash)
+	}
+
+	assert.True(t, Valid("w3gv"))
+	assert.False(t, Valid("w3ga"))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, position := range [][2]float64{{-73.9857, 40.7484}, {151.2093, -33.8688}, {-180, -90}, {179.9999, 89.9999}} {
+		box, err := Decode(Encode(position[0], position[1], MaxPrecision))
+		assert.NoError(t, err)
+		assert.True(t, box.MinLon <= position[0] && position[0] <= box.MaxLon)
+		assert.True(t, box.MinLat <= position[1] && position[1] <= box.MaxLat)
+	}
+}
@@ -0,0 +1,374 @@
+// Package jsonpatch applies RFC 7396 merge patches and RFC 6902 JSON Patches
+// to JSON documents.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	ErrPathNotFound = errors.New("path not found")
+	ErrTestFailed   = errors.New("test failed")
+)
+
+// MergePatch applies an RFC 7396 merge patch to doc: members of patch objects
+// replace those of doc recursively, null removing them, and any other patch
+// value replaces doc as a whole.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, changes any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(merge(target, changes))
+}
+
+func merge(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	members, ok := target.(map[string]any)
+	if !ok {
+		members = make(map[string]any, len(changes))
+	}
+
+	for name, value := range changes {
+		if value == nil {
+			delete(members, name)
+			continue
+		}
+
+		members[name] = merge(members[name], value)
+	}
+
+	return members
+}
+
+// Operation is an operation of a JSON Patch. Value is nil when absent, which
+// tells it apart from null.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies the operations of an RFC 6902 JSON Patch to doc in order. The
+// patch applies as a whole or not at all: ErrPathNotFound and ErrTestFailed
+// are returned, wrapped with the index of the operation, when one of them
+// does not apply to the document, ErrInvalidPatch when it is malformed.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		var err error
+		if target, err = operation.apply(target); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func (o Operation) apply(doc any) (any, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		value, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+
+		switch o.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			return replace(doc, path, value)
+		}
+
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: %s", ErrTestFailed, o.Path)
+		}
+
+		return doc, nil
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+
+		return o.transfer(doc, from, path)
+	}
+
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, o.Op)
+}
+
+func (o Operation) value() (any, error) {
+	if o.Value == nil {
+		return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+	}
+
+	var value any
+	if err := json.Unmarshal(o.Value, &value); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	return value, nil
+}
+
+// transfer moves or copies the value at from to path. A value cannot be moved
+// into one of its own children.
+func (o Operation) transfer(doc any, from, path []string) (any, error) {
+	if o.Op == "copy" {
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		return add(doc, path, clone(value))
+	}
+
+	if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+		return nil, fmt.Errorf("%w: cannot move %s into itself", ErrInvalidPatch, o.From)
+	}
+
+	doc, value, err := remove(doc, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return add(doc, path, value)
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped reference
+// tokens, none for the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%w: invalid pointer %q", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// index returns the array index a token stands for, which must be below
+// size.
+func index(token string, size int) (int, error) {
+	if token == "" || strings.Trim(token, "0123456789") != "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: index %s", ErrPathNotFound, token)
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i >= size {
+		return 0, fmt.Errorf("%w: index %s", ErrPathNotFound, token)
+	}
+
+	return i, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %s", ErrPathNotFound, token)
+			}
+
+			doc = value
+		case []any:
+			i, err := index(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%w: %s is not in a container", ErrPathNotFound, token)
+		}
+	}
+
+	return doc, nil
+}
+
+// edit applies fn to the container holding the last token of path and
+// returns doc with the container fn returns in its place, arrays changing
+// size being new slices.
+func edit(doc any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: member %s", ErrPathNotFound, path[0])
+		}
+
+		updated, err := edit(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+
+		node[path[0]] = updated
+		return node, nil
+	case []any:
+		i, err := index(path[0], len(node))
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := edit(node[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+
+		node[i] = updated
+		return node, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s is not in a container", ErrPathNotFound, path[0])
+}
+
+// add sets a member of an object or inserts an element into an array, "-"
+// appending it.
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return edit(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			i := len(node)
+			if token != "-" {
+				var err error
+				if i, err = index(token, len(node)+1); err != nil {
+					return nil, err
+				}
+			}
+
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s is not in a container", ErrPathNotFound, token)
+	})
+}
+
+// remove removes the value at path, which must exist, and returns it.
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	var removed any
+	doc, err := edit(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %s", ErrPathNotFound, token)
+			}
+
+			removed = value
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, err := index(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+
+			removed = node[i]
+			return append(node[:i:i], node[i+1:]...), nil
+		}
+
+		return nil, fmt.Errorf("%w: %s is not in a container", ErrPathNotFound, token)
+	})
+
+	return doc, removed, err
+}
+
+// replace replaces the value at path, which must exist.
+func replace(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	doc, _, err := remove(doc, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return add(doc, path, value)
+}
+
+// clone deep copies a decoded document, so copies do not share containers.
+func clone(doc any) any {
+	switch node := doc.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(node))
+		for name, value := range node {
+			c[name] = clone(value)
+		}
+
+		return c
+	case []any:
+		c := make([]any, len(node))
+		for i, value := range node {
+			c[i] = clone(value)
+		}
+
+		return c
+	}
+
+	return doc
+}
@@ -19,6 +19,10 @@ func Errorf(format string, v ...interface{}) {
 	logger.Sugar().Errorf(format, v...)
 }
 
+func Infof(format string, v ...interface{}) {
+	logger.Sugar().Infof(format, v...)
+}
+
 func Debugf(format string, v ...interface{}) {
 	logger.Sugar().Debugf(format, v...)
 }
@@ -0,0 +1,251 @@
+// Package proj converts projected coordinates described by an ESRI .prj file
+// back to WGS84 longitude and latitude. Datum shifts are not applied, which
+// keeps WGS84, NAD83 and ETRS89 based systems within a metre or so.
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrInvalidPRJ            = errors.New("invalid prj")
+	ErrUnsupportedProjection = errors.New("unsupported projection")
+)
+
+// Projection converts coordinates of a reference system to longitude and
+// latitude in degrees.
+type Projection interface {
+	Inverse(x, y float64) (lon, lat float64)
+}
+
+// WGS84 returns the identity projection of EPSG:4326.
+func WGS84() Projection {
+	return geographic{unit: 1}
+}
+
+// ParsePRJ reads the WKT of a .prj file. Geographic systems, Transverse
+// Mercator (UTM, Gauss-Kruger) and Mercator, including Web Mercator, are
+// supported.
+func ParsePRJ(wkt string) (Projection, error) {
+	root, err := parseWKT(strings.TrimSpace(wkt))
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToUpper(root.name) {
+	case "GEOGCS", "GEOGCRS":
+		return newGeographic(root), nil
+	case "PROJCS", "PROJCRS":
+		return newProjected(root)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProjection, root.name)
+	}
+}
+
+// geographic converts angles of a geographic system to degrees east of
+// Greenwich.
+type geographic struct {
+	unit          float64
+	primeMeridian float64
+}
+
+func newGeographic(n *node) geographic {
+	// UNIT is given in radians per unit.
+	unit := n.child("UNIT").number(1, math.Pi/180) * 180 / math.Pi
+
+	return geographic{unit: unit, primeMeridian: n.child("PRIMEM").number(1, 0) * unit}
+}
+
+func (g geographic) Inverse(x, y float64) (float64, float64) {
+	return x*g.unit + g.primeMeridian, y * g.unit
+}
+
+// ellipsoid holds the semi-major axis in metres and the first eccentricity
+// squared.
+type ellipsoid struct {
+	a  float64
+	e2 float64
+}
+
+func newProjected(n *node) (Projection, error) {
+	geogcs := n.child("GEOGCS")
+	if geogcs == nil {
+		return nil, fmt.Errorf("%w: missing GEOGCS", ErrInvalidPRJ)
+	}
+
+	geo := newGeographic(geogcs)
+	spheroid := geogcs.child("DATUM").child("SPHEROID")
+	if spheroid == nil {
+		return nil, fmt.Errorf("%w: missing SPHEROID", ErrInvalidPRJ)
+	}
+
+	ell := ellipsoid{a: spheroid.number(1, 0)}
+	if invf := spheroid.number(2, 0); invf != 0 {
+		f := 1 / invf
+		ell.e2 = f * (2 - f)
+	}
+
+	if ell.a <= 0 {
+		return nil, fmt.Errorf("%w: invalid SPHEROID", ErrInvalidPRJ)
+	}
+
+	params := make(map[string]float64)
+	for _, c := range n.children {
+		if strings.EqualFold(c.name, "PARAMETER") {
+			params[strings.ToLower(c.text(0))] = c.number(1, 0)
+		}
+	}
+
+	param := func(name string, fallback float64) float64 {
+		if v, ok := params[name]; ok {
+			return v
+		}
+
+		return fallback
+	}
+
+	// Linear parameters are given in the unit of the projected system,
+	// angular ones in the unit of its geographic system.
+	unit := n.child("UNIT").number(1, 1)
+	base := projected{
+		geo:           geo,
+		unit:          unit,
+		falseEasting:  param("false_easting", 0) * unit,
+		falseNorthing: param("false_northing", 0) * unit,
+		lon0:          radians(param("central_meridian", param("longitude_of_center", 0)) * geo.unit),
+	}
+
+	name := strings.ToLower(strings.ReplaceAll(n.child("PROJECTION").text(0), " ", "_"))
+	switch name {
+	case "transverse_mercator", "gauss_kruger":
+		return &transverseMercator{
+			projected: base,
+			ellipsoid: ell,
+			k0:        param("scale_factor", 1),
+			lat0:      radians(param("latitude_of_origin", 0) * geo.unit),
+		}, nil
+	case "mercator_auxiliary_sphere", "popular_visualisation_pseudo_mercator":
+		return &mercator{projected: base, ellipsoid: ellipsoid{a: ell.a}, k0: 1}, nil
+	case "mercator", "mercator_1sp", "mercator_2sp":
+		k0 := param("scale_factor", 1)
+		if phi1, ok := params["standard_parallel_1"]; ok {
+			phi1 = radians(phi1 * geo.unit)
+			k0 = math.Cos(phi1) / math.Sqrt(1-ell.e2*math.Sin(phi1)*math.Sin(phi1))
+		}
+
+		return &mercator{projected: base, ellipsoid: ell, k0: k0}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProjection, n.child("PROJECTION").text(0))
+	}
+}
+
+// projected holds what every projected system shares: the geographic system
+// results are expressed in, the linear unit in metres and the false origin.
+type projected struct {
+	geo           geographic
+	unit          float64
+	falseEasting  float64
+	falseNorthing float64
+	lon0          float64
+}
+
+// result converts longitude and latitude in radians to degrees of the
+// geographic system.
+func (p projected) result(lon, lat float64) (float64, float64) {
+	return degrees(lon) + p.geo.primeMeridian, degrees(lat)
+}
+
+type transverseMercator struct {
+	projected
+	ellipsoid
+	k0   float64
+	lat0 float64
+}
+
+// Inverse follows Snyder, Map Projections: A Working Manual, equations 8-12
+// to 8-25.
+func (t *transverseMercator) Inverse(x, y float64) (float64, float64) {
+	x = x*t.unit - t.falseEasting
+	y = y*t.unit - t.falseNorthing
+
+	e2 := t.e2
+	ep2 := e2 / (1 - e2)
+	m := t.meridianArc(t.lat0) + y/t.k0
+	mu := m / (t.a * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+
+	phi1 := mu +
+		(3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*mu)
+
+	sin, cos, tan := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	c1 := ep2 * cos * cos
+	t1 := tan * tan
+	n1 := t.a / math.Sqrt(1-e2*sin*sin)
+	r1 := t.a * (1 - e2) / math.Pow(1-e2*sin*sin, 1.5)
+	d := x / (n1 * t.k0)
+
+	lat := phi1 - (n1*tan/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ep2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ep2-3*c1*c1)*math.Pow(d, 6)/720)
+	lon := t.lon0 + (d-
+		(1+2*t1+c1)*math.Pow(d, 3)/6+
+		(5-2*c1+28*t1-3*c1*c1+8*ep2+24*t1*t1)*math.Pow(d, 5)/120)/cos
+
+	return t.result(lon, lat)
+}
+
+// meridianArc is the distance along the meridian from the equator to the
+// given latitude.
+func (e ellipsoid) meridianArc(phi float64) float64 {
+	e2, e4, e6 := e.e2, e.e2*e.e2, e.e2*e.e2*e.e2
+
+	return e.a * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+}
+
+type mercator struct {
+	projected
+	ellipsoid
+	k0 float64
+}
+
+// Inverse follows Snyder equations 7-4 and 7-9, iterating the latitude until
+// it converges on ellipsoids.
+func (m *mercator) Inverse(x, y float64) (float64, float64) {
+	x = x*m.unit - m.falseEasting
+	y = y*m.unit - m.falseNorthing
+
+	lon := m.lon0 + x/(m.a*m.k0)
+	t := math.Exp(-y / (m.a * m.k0))
+	lat := math.Pi/2 - 2*math.Atan(t)
+
+	e := math.Sqrt(m.e2)
+	for i := 0; i < 15 && e > 0; i++ {
+		sin := e * math.Sin(lat)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-sin)/(1+sin), e/2))
+		if math.Abs(next-lat) < 1e-12 {
+			lat = next
+			break
+		}
+
+		lat = next
+	}
+
+	return m.result(lon, lat)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,80 @@
+package proj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	utm33N      = `PROJCS["WGS_1984_UTM_Zone_33N",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["False_Easting",500000.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",15.0],PARAMETER["Scale_Factor",0.9996],PARAMETER["Latitude_Of_Origin",0.0],UNIT["Meter",1.0]]`
+	webMercator = `PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Mercator_Auxiliary_Sphere"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],PARAMETER["Central_Meridian",0.0],PARAMETER["Standard_Parallel_1",0.0],PARAMETER["Auxiliary_Sphere_Type",0.0],UNIT["Meter",1.0]]`
+	wgs84       = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+	lambert     = `PROJCS["RGF93_Lambert_93",GEOGCS["GCS_RGF_1993",DATUM["D_RGF_1993",SPHEROID["GRS_1980",6378137.0,298.257222101]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],PROJECTION["Lambert_Conformal_Conic"],UNIT["Meter",1.0]]`
+)
+
+func TestParsePRJ(t *testing.T) {
+	tests := []struct {
+		name          string
+		prj           string
+		x, y          float64
+		lon, lat      float64
+		expectedError error
+	}{
+		{
+			name: "Geographic",
+			prj:  wgs84,
+			x:    106.8, y: -6.2,
+			lon: 106.8, lat: -6.2,
+		},
+		{
+			name: "UTMCentralMeridian",
+			prj:  utm33N,
+			x:    500000, y: 4982950.4,
+			lon: 15, lat: 45,
+		},
+		{
+			name: "UTMOffMeridian",
+			prj:  utm33N,
+			x:    736446.026, y: 4987329.505,
+			lon: 18, lat: 45,
+		},
+		{
+			name: "UTMNorth",
+			prj:  utm33N,
+			x:    611544.042, y: 6653097.435,
+			lon: 17, lat: 60,
+		},
+		{
+			name: "WebMercator",
+			prj:  webMercator,
+			x:    20037508.342789244, y: 20037508.342789244,
+			lon: 180, lat: 85.0511287798,
+		},
+		{
+			name:          "UnsupportedProjection",
+			prj:           lambert,
+			expectedError: ErrUnsupportedProjection,
+		},
+		{
+			name:          "Malformed",
+			prj:           `PROJCS["x",GEOGCS[`,
+			expectedError: ErrInvalidPRJ,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePRJ(tt.prj)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			lon, lat := p.Inverse(tt.x, tt.y)
+			assert.InDelta(t, tt.lon, lon, 1e-5)
+			assert.InDelta(t, tt.lat, lat, 1e-5)
+		})
+	}
+}
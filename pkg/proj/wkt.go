@@ -0,0 +1,158 @@
+package proj
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// node is an element of a WKT coordinate reference system definition such as
+// PROJCS["name",GEOGCS[...],PARAMETER["False_Easting",500000.0],...].
+type node struct {
+	name     string
+	values   []string
+	children []*node
+}
+
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if strings.EqualFold(c.name, name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// number returns the i-th value of the node as a float, or fallback when the
+// node or the value is missing.
+func (n *node) number(i int, fallback float64) float64 {
+	if n == nil || i >= len(n.values) {
+		return fallback
+	}
+
+	v, err := strconv.ParseFloat(n.values[i], 64)
+	if err != nil {
+		return fallback
+	}
+
+	return v
+}
+
+func (n *node) text(i int) string {
+	if n == nil || i >= len(n.values) {
+		return ""
+	}
+
+	return n.values[i]
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func parseWKT(s string) (*node, error) {
+	p := &wktParser{s: s}
+	n, err := p.node()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.skipSpaces(); p.pos != len(p.s) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidPRJ, p.s[p.pos:])
+	}
+
+	return n, nil
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *wktParser) node() (*node, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (isLetter(p.s[p.pos]) || p.s[p.pos] == '_' || (p.pos > start && isDigit(p.s[p.pos]))) {
+		p.pos++
+	}
+
+	n := &node{name: p.s[start:p.pos]}
+	if n.name == "" {
+		return nil, fmt.Errorf("%w: expected a keyword at %d", ErrInvalidPRJ, p.pos)
+	}
+
+	if p.skipSpaces(); p.pos >= len(p.s) || (p.s[p.pos] != '[' && p.s[p.pos] != '(') {
+		return nil, fmt.Errorf("%w: expected [ after %s", ErrInvalidPRJ, n.name)
+	}
+
+	p.pos++
+	for {
+		if err := p.item(n); err != nil {
+			return nil, err
+		}
+
+		p.skipSpaces()
+		if p.pos >= len(p.s) {
+			return nil, fmt.Errorf("%w: unterminated %s", ErrInvalidPRJ, n.name)
+		}
+
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case ']', ')':
+			p.pos++
+			return n, nil
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q in %s", ErrInvalidPRJ, p.s[p.pos], n.name)
+		}
+	}
+}
+
+func (p *wktParser) item(n *node) error {
+	p.skipSpaces()
+	if p.pos >= len(p.s) {
+		return fmt.Errorf("%w: unterminated %s", ErrInvalidPRJ, n.name)
+	}
+
+	switch c := p.s[p.pos]; {
+	case c == '"':
+		end := strings.IndexByte(p.s[p.pos+1:], '"')
+		if end < 0 {
+			return fmt.Errorf("%w: unterminated string", ErrInvalidPRJ)
+		}
+
+		n.values = append(n.values, p.s[p.pos+1:p.pos+1+end])
+		p.pos += end + 2
+	case isLetter(c):
+		child, err := p.node()
+		if err != nil {
+			return err
+		}
+
+		n.children = append(n.children, child)
+	default:
+		start := p.pos
+		for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+			p.pos++
+		}
+
+		if start == p.pos {
+			return fmt.Errorf("%w: unexpected %q in %s", ErrInvalidPRJ, c, n.name)
+		}
+
+		n.values = append(n.values, p.s[start:p.pos])
+	}
+
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,143 @@
+package shapefile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	dbfHeaderSize     = 32
+	dbfFieldSize      = 32
+	dbfFieldSeparator = 0x0D
+
+	// maxPreallocatedRecords bounds the records allocated from the header
+	// count before any is read.
+	maxPreallocatedRecords = 1024
+)
+
+type dbfField struct {
+	name     string
+	kind     byte
+	length   int
+	decimals int
+}
+
+// ReadAttributes reads the records of a .dbf file, one attribute map per
+// record in file order. Text is decoded as UTF-8, falling back to Latin-1 for
+// values that are not valid UTF-8.
+func ReadAttributes(r io.Reader) ([]map[string]any, error) {
+	header := make([]byte, dbfHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: dbf: %s", ErrInvalidShapefile, err.Error())
+	}
+
+	numRecords := int(binary.LittleEndian.Uint32(header[4:8]))
+	headerLength := int(binary.LittleEndian.Uint16(header[8:10]))
+	recordLength := int(binary.LittleEndian.Uint16(header[10:12]))
+	if headerLength < dbfHeaderSize+1 || recordLength < 1 {
+		return nil, fmt.Errorf("%w: dbf: invalid header", ErrInvalidShapefile)
+	}
+
+	descriptors := make([]byte, headerLength-dbfHeaderSize)
+	if _, err := io.ReadFull(r, descriptors); err != nil {
+		return nil, fmt.Errorf("%w: dbf: %s", ErrInvalidShapefile, err.Error())
+	}
+
+	fields := make([]dbfField, 0)
+	for offset := 0; offset+dbfFieldSize <= len(descriptors) && descriptors[offset] != dbfFieldSeparator; offset += dbfFieldSize {
+		d := descriptors[offset : offset+dbfFieldSize]
+		name := string(d[:11])
+		if idx := strings.IndexByte(name, 0); idx >= 0 {
+			name = name[:idx]
+		}
+
+		fields = append(fields, dbfField{name: name, kind: d[11], length: int(d[16]), decimals: int(d[17])})
+	}
+
+	records := make([]map[string]any, 0, min(numRecords, maxPreallocatedRecords))
+	data := make([]byte, recordLength)
+	for i := 0; i < numRecords; i++ {
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("%w: dbf record %d: %s", ErrInvalidShapefile, i+1, err.Error())
+		}
+
+		// The first byte is the deletion flag.
+		offset := 1
+		attributes := make(map[string]any, len(fields))
+		for _, field := range fields {
+			if offset+field.length > len(data) {
+				return nil, fmt.Errorf("%w: dbf field %s overflows its record", ErrInvalidShapefile, field.name)
+			}
+
+			attributes[field.name] = field.value(data[offset : offset+field.length])
+			offset += field.length
+		}
+
+		records = append(records, attributes)
+	}
+
+	return records, nil
+}
+
+// value converts a raw field to a string, number, bool or nil for blank and
+// unknown values.
+func (f dbfField) value(raw []byte) any {
+	s := strings.TrimSpace(decodeText(raw))
+	switch f.kind {
+	case 'N', 'F':
+		if s == "" {
+			return nil
+		}
+
+		if f.decimals == 0 {
+			if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return v
+			}
+		}
+
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			return v
+		}
+
+		return nil
+	case 'L':
+		switch s {
+		case "T", "t", "Y", "y":
+			return true
+		case "F", "f", "N", "n":
+			return false
+		default:
+			return nil
+		}
+	case 'D':
+		if len(s) != 8 {
+			return nil
+		}
+
+		return s[0:4] + "-" + s[4:6] + "-" + s[6:8]
+	default:
+		if s == "" {
+			return nil
+		}
+
+		return s
+	}
+}
+
+func decodeText(raw []byte) string {
+	raw = []byte(strings.TrimRight(string(raw), "\x00"))
+	if utf8.Valid(raw) {
+		return string(raw)
+	}
+
+	runes := make([]rune, len(raw))
+	for i, b := range raw {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
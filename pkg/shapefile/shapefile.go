@@ -0,0 +1,220 @@
+// Package shapefile reads ESRI Shapefiles: the .shp geometries, the .dbf
+// attributes and the .prj projection of each layer.
+package shapefile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+type ShapeType int32
+
+const (
+	Null        ShapeType = 0
+	Point       ShapeType = 1
+	PolyLine    ShapeType = 3
+	Polygon     ShapeType = 5
+	MultiPoint  ShapeType = 8
+	PointZ      ShapeType = 11
+	PolyLineZ   ShapeType = 13
+	PolygonZ    ShapeType = 15
+	MultiPointZ ShapeType = 18
+	PointM      ShapeType = 21
+	PolyLineM   ShapeType = 23
+	PolygonM    ShapeType = 25
+	MultiPointM ShapeType = 28
+)
+
+const (
+	fileCode        = 9994
+	headerSize      = 100
+	recordHeadSize  = 8
+	maxRecordLength = 256 * 1024 * 1024
+)
+
+var ErrInvalidShapefile = errors.New("invalid shapefile")
+
+// IsPoint reports whether the shape type holds a single position, ignoring
+// any Z or M value.
+func (t ShapeType) IsPoint() bool {
+	return t == Point || t == PointZ || t == PointM
+}
+
+// IsPolygon reports whether the shape type holds polygon rings, ignoring any
+// Z or M values.
+func (t ShapeType) IsPolygon() bool {
+	return t == Polygon || t == PolygonZ || t == PolygonM
+}
+
+// Record is a single shape of a layer. Parts holds the rings of a polygon, a
+// single one element part for a point, and nothing for a null shape or a
+// shape type that is not supported. Number is 1-based as in the file.
+type Record struct {
+	Number     int
+	Type       ShapeType
+	Parts      [][][2]float64
+	Attributes map[string]any
+}
+
+// ReadShapes reads every record of a .shp file. Only the X and Y of point and
+// polygon shapes are decoded, other shape types are returned without parts.
+func ReadShapes(r io.Reader) ([]Record, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidShapefile, err.Error())
+	}
+
+	if binary.BigEndian.Uint32(header[0:4]) != fileCode {
+		return nil, fmt.Errorf("%w: bad file code", ErrInvalidShapefile)
+	}
+
+	records := make([]Record, 0)
+	head := make([]byte, recordHeadSize)
+	for {
+		_, err := io.ReadFull(r, head)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidShapefile, err.Error())
+		}
+
+		number := int(binary.BigEndian.Uint32(head[0:4]))
+		length := int64(binary.BigEndian.Uint32(head[4:8])) * 2
+		if length < 4 || length > maxRecordLength {
+			return nil, fmt.Errorf("%w: record %d has invalid length %d", ErrInvalidShapefile, number, length)
+		}
+
+		content := make([]byte, length)
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, fmt.Errorf("%w: record %d: %s", ErrInvalidShapefile, number, err.Error())
+		}
+
+		record, err := decodeShape(number, content)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+}
+
+func decodeShape(number int, content []byte) (Record, error) {
+	record := Record{Number: number, Type: ShapeType(int32(binary.LittleEndian.Uint32(content[0:4])))}
+	invalid := fmt.Errorf("%w: record %d is truncated", ErrInvalidShapefile, number)
+
+	switch {
+	case record.Type.IsPoint():
+		if len(content) < 20 {
+			return Record{}, invalid
+		}
+
+		record.Parts = [][][2]float64{{readPosition(content[4:])}}
+	case record.Type.IsPolygon():
+		// Shape type, bounding box, part and point counts.
+		if len(content) < 44 {
+			return Record{}, invalid
+		}
+
+		numParts := int(binary.LittleEndian.Uint32(content[36:40]))
+		numPoints := int(binary.LittleEndian.Uint32(content[40:44]))
+		partsStart := 44
+		pointsStart := partsStart + 4*numParts
+		if numParts < 0 || numPoints < 0 || pointsStart+16*numPoints > len(content) {
+			return Record{}, invalid
+		}
+
+		record.Parts = make([][][2]float64, 0, numParts)
+		for i := 0; i < numParts; i++ {
+			start := int(binary.LittleEndian.Uint32(content[partsStart+4*i:]))
+			end := numPoints
+			if i+1 < numParts {
+				end = int(binary.LittleEndian.Uint32(content[partsStart+4*(i+1):]))
+			}
+
+			if start < 0 || start > end || end > numPoints {
+				return Record{}, fmt.Errorf("%w: record %d has invalid parts", ErrInvalidShapefile, number)
+			}
+
+			ring := make([][2]float64, 0, end-start)
+			for j := start; j < end; j++ {
+				ring = append(ring, readPosition(content[pointsStart+16*j:]))
+			}
+
+			record.Parts = append(record.Parts, ring)
+		}
+	}
+
+	return record, nil
+}
+
+func readPosition(b []byte) [2]float64 {
+	return [2]float64{
+		math.Float64frombits(binary.LittleEndian.Uint64(b[0:8])),
+		math.Float64frombits(binary.LittleEndian.Uint64(b[8:16])),
+	}
+}
+
+// Polygons groups the rings of a polygon record into polygons. Shapefiles
+// store outer rings clockwise and holes counterclockwise, each hole is
+// attached to the outer ring containing it. A record without any clockwise
+// ring is read as a set of outer rings.
+func (r Record) Polygons() [][][][2]float64 {
+	polygons := make([][][][2]float64, 0)
+	holes := make([][][2]float64, 0)
+	for _, ring := range r.Parts {
+		if signedArea(ring) <= 0 {
+			polygons = append(polygons, [][][2]float64{ring})
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	if len(polygons) == 0 {
+		for _, ring := range holes {
+			polygons = append(polygons, [][][2]float64{ring})
+		}
+
+		return polygons
+	}
+
+	for _, hole := range holes {
+		owner := len(polygons) - 1
+		for i, polygon := range polygons {
+			if len(hole) > 0 && containsPosition(polygon[0], hole[0]) {
+				owner = i
+				break
+			}
+		}
+
+		polygons[owner] = append(polygons[owner], hole)
+	}
+
+	return polygons
+}
+
+// signedArea is positive for counterclockwise rings.
+func signedArea(ring [][2]float64) float64 {
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+
+	return area / 2
+}
+
+func containsPosition(ring [][2]float64, p [2]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
@@ -0,0 +1,73 @@
+package shapefile
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadZip(t *testing.T) {
+	data, err := os.ReadFile("testdata/parcels.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layers, err := ReadZip(bytes.NewReader(data), int64(len(data)), 1<<20)
+	assert.NoError(t, err)
+	assert.Len(t, layers, 1)
+
+	layer := layers[0]
+	assert.Equal(t, "parcels", layer.Name)
+	assert.Contains(t, layer.Projection, "Transverse_Mercator")
+	assert.Len(t, layer.Records, 3)
+
+	multi := layer.Records[0]
+	assert.Equal(t, 1, multi.Number)
+	assert.Equal(t, Polygon, multi.Type)
+	assert.Equal(t, map[string]any{"NAME": "North parcel", "POP": int64(1200), "AREA": 1.5, "ACTIVE": true, "SINCE": "2024-01-31"}, multi.Attributes)
+
+	polygons := multi.Polygons()
+	assert.Len(t, polygons, 2)
+	assert.Len(t, polygons[0], 2, "the hole belongs to the first outer ring")
+	assert.Len(t, polygons[1], 1)
+	assert.Equal(t, [2]float64{510000, 4982950.4}, polygons[1][0][0])
+
+	null := layer.Records[1]
+	assert.Equal(t, Null, null.Type)
+	assert.Empty(t, null.Parts)
+	assert.Equal(t, map[string]any{"NAME": "Zürich", "POP": nil, "AREA": nil, "ACTIVE": nil, "SINCE": nil}, null.Attributes)
+
+	assert.Len(t, layer.Records[2].Polygons(), 1)
+}
+
+func TestReadZipErrors(t *testing.T) {
+	_, err := ReadZip(bytes.NewReader([]byte("not a zip")), 9, 1<<20)
+	assert.ErrorIs(t, err, ErrInvalidShapefile)
+
+	_, err = ReadShapes(bytes.NewReader(make([]byte, headerSize)))
+	assert.ErrorIs(t, err, ErrInvalidShapefile)
+
+	data, err := os.ReadFile("testdata/parcels.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ReadZip(bytes.NewReader(data), int64(len(data)), 100)
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestLimitedReader(t *testing.T) {
+	budget := int64(4)
+	data, err := io.ReadAll(&limitedReader{r: strings.NewReader("abcd"), n: &budget})
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", string(data))
+	assert.Equal(t, int64(0), budget)
+
+	budget = 4
+	_, err = io.ReadAll(&limitedReader{r: strings.NewReader("abcde"), n: &budget})
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
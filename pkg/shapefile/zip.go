@@ -0,0 +1,177 @@
+package shapefile
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Layer is one shapefile of an archive. Projection holds the WKT of its .prj
+// file, empty when the archive has none.
+type Layer struct {
+	Name       string
+	Projection string
+	Records    []Record
+}
+
+// ErrTooLarge is returned when the files of an archive decompress to more than
+// the limit given to ReadZip.
+var ErrTooLarge = errors.New("shapefile too large")
+
+// ReadZip reads every shapefile of a zip archive. Companion files are matched
+// to their .shp by name, the .dbf and .prj being optional. The files read may
+// not decompress to more than limit bytes in total, whatever sizes the archive
+// claims for them.
+func ReadZip(r io.ReaderAt, size, limit int64) ([]Layer, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidShapefile, err.Error())
+	}
+
+	files := make(map[string]*zip.File)
+	names := make([]string, 0)
+	for _, f := range archive.File {
+		// Skip the resource forks macOS adds to archives.
+		if strings.HasPrefix(f.Name, "__MACOSX/") || strings.HasPrefix(path.Base(f.Name), ".") {
+			continue
+		}
+
+		ext := strings.ToLower(path.Ext(f.Name))
+		base := strings.TrimSuffix(f.Name, path.Ext(f.Name))
+		files[base+ext] = f
+		if ext == ".shp" {
+			names = append(names, base)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no .shp file in archive", ErrInvalidShapefile)
+	}
+
+	// Reject archives claiming too much up front. The sizes are only trusted
+	// to do so, readZipFile enforcing the limit on the bytes actually read.
+	remaining := uint64(limit)
+	for _, name := range names {
+		for _, ext := range []string{".shp", ".dbf", ".prj"} {
+			f, ok := files[name+ext]
+			if !ok {
+				continue
+			}
+
+			if f.UncompressedSize64 > remaining {
+				return nil, fmt.Errorf("%w: files larger than %d bytes", ErrTooLarge, limit)
+			}
+
+			remaining -= f.UncompressedSize64
+		}
+	}
+
+	sort.Strings(names)
+	layers := make([]Layer, 0, len(names))
+	for _, name := range names {
+		layer, err := readLayer(name, files, &limit)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, layer)
+	}
+
+	return layers, nil
+}
+
+func readLayer(name string, files map[string]*zip.File, budget *int64) (Layer, error) {
+	layer := Layer{Name: path.Base(name)}
+
+	var err error
+	err = readZipFile(files[name+".shp"], budget, func(r io.Reader) error {
+		layer.Records, err = ReadShapes(r)
+		return err
+	})
+	if err != nil {
+		return Layer{}, err
+	}
+
+	if f, ok := files[name+".dbf"]; ok {
+		var attributes []map[string]any
+		err = readZipFile(f, budget, func(r io.Reader) error {
+			attributes, err = ReadAttributes(r)
+			return err
+		})
+		if err != nil {
+			return Layer{}, err
+		}
+
+		if len(attributes) != len(layer.Records) {
+			return Layer{}, fmt.Errorf("%w: %s has %d shapes but %d attribute records", ErrInvalidShapefile, layer.Name, len(layer.Records), len(attributes))
+		}
+
+		for i := range layer.Records {
+			layer.Records[i].Attributes = attributes[i]
+		}
+	}
+
+	if f, ok := files[name+".prj"]; ok {
+		err = readZipFile(f, budget, func(r io.Reader) error {
+			data, err := io.ReadAll(r)
+			layer.Projection = strings.TrimSpace(string(data))
+			return err
+		})
+		if err != nil {
+			return Layer{}, err
+		}
+	}
+
+	return layer, nil
+}
+
+// readZipFile calls read with the content of f, failing with ErrTooLarge once
+// more than budget bytes are read. The bytes read are deducted from budget.
+func readZipFile(f *zip.File, budget *int64, read func(io.Reader) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidShapefile, err.Error())
+	}
+	defer rc.Close()
+
+	// The readers report read errors as invalid shapefiles, so the exhausted
+	// budget is checked rather than the error.
+	err = read(&limitedReader{r: rc, n: budget})
+	if *budget < 0 {
+		return fmt.Errorf("%w: %s is larger than expected", ErrTooLarge, f.Name)
+	}
+
+	return err
+}
+
+// limitedReader reads from r until n bytes are read, then fails with
+// ErrTooLarge rather than reporting the end of the file like io.LimitReader,
+// so a truncated file cannot be mistaken for a complete one.
+type limitedReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if *l.n < 0 {
+		return 0, ErrTooLarge
+	}
+
+	// Read one byte past the budget to tell a file ending right at it from
+	// a longer one.
+	if int64(len(p)) > *l.n+1 {
+		p = p[:*l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	*l.n -= int64(n)
+	if *l.n < 0 {
+		return 0, ErrTooLarge
+	}
+
+	return n, err
+}
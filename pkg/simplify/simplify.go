@@ -0,0 +1,260 @@
+// Package simplify reduces the number of vertices of polygons with the
+// Douglas-Peucker algorithm while keeping them valid: rings stay closed, do
+// not collapse and do not cross themselves or each other.
+package simplify
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	earthRadius = 6371008.8
+	// attempts is the number of times the tolerance is halved when the
+	// simplified polygon is not simple, before the polygon is kept as is.
+	attempts = 6
+)
+
+// Polygon simplifies a lon/lat polygon, the tolerance being in metres.
+// Positions are projected on a local equirectangular plane centred on the
+// polygon, which is accurate enough for tolerances far below its size, and
+// the kept vertices are returned untouched. The outer ring is kept as is
+// rather than collapsed, holes that would collapse are dropped.
+func Polygon(rings [][][2]float64, tolerance float64) [][][2]float64 {
+	if len(rings) == 0 || tolerance <= 0 {
+		return rings
+	}
+
+	p := newPlane(rings[0])
+	projected := make([][][2]float64, len(rings))
+	for i, ring := range rings {
+		projected[i] = p.project(ring)
+	}
+
+	for attempt := 0; attempt < attempts; attempt, tolerance = attempt+1, tolerance/2 {
+		result := make([][][2]float64, 0, len(rings))
+		check := make([][][2]float64, 0, len(rings))
+
+		for i := range rings {
+			indexes := ringIndexes(projected[i], tolerance)
+			if len(indexes) < 4 {
+				if i == 0 {
+					break
+				}
+
+				continue
+			}
+
+			result = append(result, pick(rings[i], indexes))
+			check = append(check, pick(projected[i], indexes))
+		}
+
+		if len(result) > 0 && isSimple(check) {
+			return result
+		}
+	}
+
+	return rings
+}
+
+// Ring simplifies a closed ring of planar positions.
+func Ring(ring [][2]float64, tolerance float64) [][2]float64 {
+	return pick(ring, ringIndexes(ring, tolerance))
+}
+
+// Line simplifies an open line of planar positions, keeping its ends.
+func Line(line [][2]float64, tolerance float64) [][2]float64 {
+	return pick(line, lineIndexes(line, tolerance))
+}
+
+// ringIndexes returns the indexes of the vertices of a closed ring kept by the
+// simplification. The ring is split at the vertex farthest from its first
+// one, so both halves have distinct ends.
+func ringIndexes(ring [][2]float64, tolerance float64) []int {
+	if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+		return lineIndexes(ring, 0)
+	}
+
+	split, farthest := 0, -1.0
+	for i, p := range ring {
+		if d := distance(ring[0], p); d > farthest {
+			split, farthest = i, d
+		}
+	}
+
+	indexes := lineIndexes(ring[:split+1], tolerance)
+	for _, i := range lineIndexes(ring[split:], tolerance)[1:] {
+		indexes = append(indexes, split+i)
+	}
+
+	return indexes
+}
+
+// lineIndexes returns the indexes of the vertices of an open line kept by
+// the Douglas-Peucker algorithm.
+func lineIndexes(line [][2]float64, tolerance float64) []int {
+	keep := make([]bool, len(line))
+	if len(line) > 0 {
+		keep[0], keep[len(line)-1] = true, true
+	}
+
+	stack := [][2]int{{0, len(line) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		index, farthest := -1, tolerance
+		for i := span[0] + 1; i < span[1]; i++ {
+			if d := segmentDistance(line[i], line[span[0]], line[span[1]]); d > farthest {
+				index, farthest = i, d
+			}
+		}
+
+		if index >= 0 {
+			keep[index] = true
+			stack = append(stack, [2]int{span[0], index}, [2]int{index, span[1]})
+		}
+	}
+
+	indexes := make([]int, 0)
+	for i := range line {
+		if keep[i] {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+func pick(positions [][2]float64, indexes []int) [][2]float64 {
+	result := make([][2]float64, len(indexes))
+	for i, index := range indexes {
+		result[i] = positions[index]
+	}
+
+	return result
+}
+
+// isSimple reports whether no two segments of the rings cross or touch,
+// segments sharing a vertex in a ring aside. Segments are swept by their
+// smallest x, so only those whose x ranges overlap are compared, and their y
+// ranges are checked before the exact test.
+func isSimple(rings [][][2]float64) bool {
+	type segment struct {
+		ring, index            int
+		a, b                   [2]float64
+		minX, maxX, minY, maxY float64
+	}
+
+	segments := make([]segment, 0)
+	for r, ring := range rings {
+		for i := 0; i+1 < len(ring); i++ {
+			a, b := ring[i], ring[i+1]
+			segments = append(segments, segment{
+				ring: r, index: i, a: a, b: b,
+				minX: math.Min(a[0], b[0]), maxX: math.Max(a[0], b[0]),
+				minY: math.Min(a[1], b[1]), maxY: math.Max(a[1], b[1]),
+			})
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].minX < segments[j].minX
+	})
+
+	for i := range segments {
+		s := segments[i]
+		for j := i + 1; j < len(segments) && segments[j].minX <= s.maxX; j++ {
+			t := segments[j]
+			if t.minY > s.maxY || t.maxY < s.minY {
+				continue
+			}
+
+			if s.ring == t.ring {
+				first, second := min(s.index, t.index), max(s.index, t.index)
+				last := len(rings[s.ring]) - 2
+				if second == first+1 || (first == 0 && second == last) {
+					continue
+				}
+			}
+
+			if intersects(s.a, s.b, t.a, t.b) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func intersects(p1, p2, q1, q2 [2]float64) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return (d1 == 0 && onSegment(q1, q2, p1)) || (d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) || (d4 == 0 && onSegment(p1, p2, q2))
+}
+
+func orientation(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func onSegment(a, b, p [2]float64) bool {
+	return math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
+
+func distance(a, b [2]float64) float64 {
+	return math.Hypot(b[0]-a[0], b[1]-a[1])
+}
+
+// segmentDistance returns the distance from p to the segment [a, b].
+func segmentDistance(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	if dx == 0 && dy == 0 {
+		return distance(p, a)
+	}
+
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+
+	return distance(p, [2]float64{a[0] + t*dx, a[1] + t*dy})
+}
+
+// plane is a local equirectangular projection in metres.
+type plane struct {
+	lon0, lat0 float64
+	scale      float64
+}
+
+func newPlane(ring [][2]float64) plane {
+	var lon, lat float64
+	for _, p := range ring {
+		lon += p[0]
+		lat += p[1]
+	}
+
+	n := float64(max(len(ring), 1))
+	p := plane{lon0: lon / n, lat0: lat / n}
+	p.scale = math.Cos(p.lat0 * math.Pi / 180)
+
+	return p
+}
+
+func (p plane) project(ring [][2]float64) [][2]float64 {
+	result := make([][2]float64, len(ring))
+	for i, position := range ring {
+		result[i] = [2]float64{
+			(position[0] - p.lon0) * math.Pi / 180 * earthRadius * p.scale,
+			(position[1] - p.lat0) * math.Pi / 180 * earthRadius,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,113 @@
+package simplify
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// circle returns a closed ring of n vertices around (lon, lat), radius being
+// in degrees.
+func circle(lon, lat, radius float64, n int) [][2]float64 {
+	ring := make([][2]float64, 0, n+1)
+	for i := 0; i < n; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		ring = append(ring, [2]float64{lon + radius*math.Cos(angle), lat + radius*math.Sin(angle)})
+	}
+
+	return append(ring, ring[0])
+}
+
+func TestLine(t *testing.T) {
+	line := [][2]float64{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 5}, {4, 6}, {5, 7}, {6, 8.1}, {7, 9}, {8, 9}, {9, 9}}
+	assert.Equal(t, [][2]float64{{0, 0}, {2, -0.1}, {3, 5}, {7, 9}, {9, 9}}, Line(line, 1))
+	assert.Len(t, Line(line, 0.01), 8)
+	assert.Equal(t, [][2]float64{{0, 0}, {9, 9}}, Line(line, 100))
+}
+
+func TestRing(t *testing.T) {
+	ring := [][2]float64{{0, 0}, {5, 0.1}, {10, 0}, {10, 10}, {5, 10.1}, {0, 10}, {0, 0}}
+	assert.Equal(t, [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, Ring(ring, 1))
+}
+
+func TestPolygon(t *testing.T) {
+	t.Run("ReducesVertices", func(t *testing.T) {
+		outer := circle(106.8, -6.2, 0.1, 720)
+		result := Polygon([][][2]float64{outer}, 100)
+
+		assert.Len(t, result, 1)
+		assert.Less(t, len(result[0]), 100)
+		assert.Greater(t, len(result[0]), 4)
+		assert.Equal(t, result[0][0], result[0][len(result[0])-1])
+		assert.True(t, isSimple(result))
+	})
+
+	t.Run("KeepsOriginalVertices", func(t *testing.T) {
+		outer := circle(10, 50, 0.01, 100)
+		positions := make(map[[2]float64]bool)
+		for _, p := range outer {
+			positions[p] = true
+		}
+
+		for _, p := range Polygon([][][2]float64{outer}, 50)[0] {
+			assert.True(t, positions[p])
+		}
+	})
+
+	t.Run("DropsCollapsedHoles", func(t *testing.T) {
+		outer := circle(0, 0, 1, 360)
+		hole := circle(0, 0, 0.0001, 8)
+		result := Polygon([][][2]float64{outer, hole}, 1000)
+
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("KeepsCollapsingPolygon", func(t *testing.T) {
+		rings := [][][2]float64{circle(0, 0, 0.0001, 8)}
+		assert.Equal(t, rings, Polygon(rings, 1000))
+	})
+
+	t.Run("AvoidsCrossingHoles", func(t *testing.T) {
+		// Flattening the notch of the first hole, 22km deep, would make it
+		// cross the second hole poking into it.
+		outer := [][2]float64{{-1, -1}, {5, -1}, {5, 4}, {-1, 4}, {-1, -1}}
+		notched := [][2]float64{{0, 0}, {4, 0}, {4, 1}, {2.2, 1}, {2, 0.8}, {1.8, 1}, {0, 1}, {0, 0}}
+		diamond := [][2]float64{{2, 0.9}, {2.5, 1.5}, {2, 2.5}, {1.5, 1.5}, {2, 0.9}}
+		rings := [][][2]float64{outer, notched, diamond}
+		assert.True(t, isSimple(rings))
+
+		result := Polygon(rings, 30000)
+
+		assert.Len(t, result, 3)
+		assert.True(t, isSimple(result))
+		assert.Contains(t, result[1], [2]float64{2, 0.8})
+	})
+
+	t.Run("LargeRing", func(t *testing.T) {
+		outer := circle(106.8, -6.2, 1, 200000)
+		result := Polygon([][][2]float64{outer}, 10)
+
+		assert.Less(t, len(result[0]), len(outer))
+		assert.True(t, isSimple(result))
+	})
+
+	t.Run("NonPositiveTolerance", func(t *testing.T) {
+		rings := [][][2]float64{circle(0, 0, 1, 16)}
+		assert.Equal(t, rings, Polygon(rings, 0))
+	})
+}
+
+func TestIsSimple(t *testing.T) {
+	square := [][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	assert.True(t, isSimple([][][2]float64{square}))
+
+	bowtie := [][2]float64{{0, 0}, {1, 1}, {1, 0}, {0, 1}, {0, 0}}
+	assert.False(t, isSimple([][][2]float64{bowtie}))
+
+	touching := [][2]float64{{0.5, 0}, {0.5, 0.5}, {0.7, 0.2}, {0.5, 0}}
+	assert.False(t, isSimple([][][2]float64{square, touching}))
+
+	inside := [][2]float64{{0.2, 0.2}, {0.8, 0.2}, {0.5, 0.8}, {0.2, 0.2}}
+	assert.True(t, isSimple([][][2]float64{square, inside}))
+}
@@ -0,0 +1,268 @@
+// Package supercluster clusters points per zoom level the way the supercluster
+// JavaScript library does: points are projected to Web Mercator and, from the
+// deepest zoom up, every item absorbs its neighbours within a pixel radius.
+//
+// The index keeps the raw points and rebuilds its cluster hierarchy lazily,
+// so Insert and Remove are cheap and the cost of clustering is paid by the
+// first query that follows a change.
+package supercluster
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Options tune the clustering. Radius is expressed in pixels of a tile of
+// Extent pixels, a cluster needs at least MinPoints points to be formed.
+type Options struct {
+	MinZoom   int
+	MaxZoom   int
+	Radius    float64
+	Extent    float64
+	MinPoints int
+}
+
+// DefaultOptions returns the defaults of the supercluster library.
+func DefaultOptions() Options {
+	return Options{MinZoom: 0, MaxZoom: 16, Radius: 40, Extent: 512, MinPoints: 2}
+}
+
+// Point is an indexed point, ID being the identifier of the point it stands
+// for.
+type Point struct {
+	ID  uint
+	Lon float64
+	Lat float64
+}
+
+// Cluster is an item of a zoom level. A cluster of a single point carries the
+// ID of that point in PointID. ExpansionZoom is the zoom at which a cluster of
+// several points splits into its children.
+type Cluster struct {
+	Lon           float64
+	Lat           float64
+	Count         int
+	PointID       uint
+	ExpansionZoom int
+}
+
+// node is a cluster in projected coordinates, x and y being in [0, 1]. zoom is
+// the zoom the node was formed at, a point is formed below MaxZoom.
+type node struct {
+	x       float64
+	y       float64
+	count   int
+	pointID uint
+	zoom    int
+}
+
+// Index is a hierarchical cluster index safe for concurrent use.
+type Index struct {
+	mu      sync.Mutex
+	options Options
+	points  map[uint]Point
+	// levels holds the items of every zoom from MinZoom to MaxZoom+1, it is
+	// nil when the points changed since the last build.
+	levels [][]node
+}
+
+func New(options Options) *Index {
+	return &Index{options: options, points: make(map[uint]Point)}
+}
+
+// Load replaces the indexed points.
+func (i *Index) Load(points []Point) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.points = make(map[uint]Point, len(points))
+	for _, p := range points {
+		i.points[p.ID] = p
+	}
+
+	i.levels = nil
+}
+
+// Insert adds a point, replacing any point with the same ID.
+func (i *Index) Insert(p Point) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.points[p.ID] = p
+	i.levels = nil
+}
+
+// Remove drops the point with the given ID, if any.
+func (i *Index) Remove(id uint) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, ok := i.points[id]; !ok {
+		return
+	}
+
+	delete(i.points, id)
+	i.levels = nil
+}
+
+// Len returns the number of indexed points.
+func (i *Index) Len() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return len(i.points)
+}
+
+// Clusters returns the clusters of the zoom level whose centroid lies in the
+// bounding box. zoom is clamped to [MinZoom, MaxZoom+1], past MaxZoom every
+// point is returned on its own.
+func (i *Index) Clusters(minLon, minLat, maxLon, maxLat float64, zoom int) []Cluster {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.levels == nil {
+		i.build()
+	}
+
+	zoom = max(i.options.MinZoom, min(zoom, i.options.MaxZoom+1))
+	minX, maxX := lonX(minLon), lonX(maxLon)
+	minY, maxY := latY(maxLat), latY(minLat)
+
+	clusters := make([]Cluster, 0)
+	for _, n := range i.levels[zoom-i.options.MinZoom] {
+		if n.x < minX || n.x > maxX || n.y < minY || n.y > maxY {
+			continue
+		}
+
+		cluster := Cluster{Lon: xLon(n.x), Lat: yLat(n.y), Count: n.count, PointID: n.pointID}
+		if n.count > 1 {
+			cluster.ExpansionZoom = n.zoom + 1
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters
+}
+
+// build clusters every zoom level from the points, walking up from the
+// deepest one.
+func (i *Index) build() {
+	levels := make([][]node, i.options.MaxZoom-i.options.MinZoom+2)
+
+	ids := make([]uint, 0, len(i.points))
+	for id := range i.points {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+
+	nodes := make([]node, len(ids))
+	for k, id := range ids {
+		p := i.points[id]
+		nodes[k] = node{x: lonX(p.Lon), y: latY(p.Lat), count: 1, pointID: id, zoom: i.options.MaxZoom + 1}
+	}
+
+	levels[len(levels)-1] = nodes
+	for z := i.options.MaxZoom; z >= i.options.MinZoom; z-- {
+		nodes = i.cluster(nodes, z)
+		levels[z-i.options.MinZoom] = nodes
+	}
+
+	i.levels = levels
+}
+
+// cluster merges the items of the zoom below z lying within the radius of
+// each other into weighted centroids. Items are bucketed in a grid of radius
+// sized cells so only the neighbouring cells have to be searched.
+func (i *Index) cluster(nodes []node, z int) []node {
+	r := i.options.Radius / (i.options.Extent * math.Pow(2, float64(z)))
+
+	grid := make(map[[2]int][]int)
+	for k, n := range nodes {
+		cell := cellOf(n, r)
+		grid[cell] = append(grid[cell], k)
+	}
+
+	visited := make([]bool, len(nodes))
+	clusters := make([]node, 0, len(nodes))
+
+	for k, n := range nodes {
+		if visited[k] {
+			continue
+		}
+
+		visited[k] = true
+		neighbours := i.neighbours(nodes, grid, visited, k, r)
+
+		count := n.count
+		for _, j := range neighbours {
+			count += nodes[j].count
+		}
+
+		if len(neighbours) == 0 || count < i.options.MinPoints {
+			clusters = append(clusters, n)
+			continue
+		}
+
+		x, y := n.x*float64(n.count), n.y*float64(n.count)
+		for _, j := range neighbours {
+			visited[j] = true
+			x += nodes[j].x * float64(nodes[j].count)
+			y += nodes[j].y * float64(nodes[j].count)
+		}
+
+		clusters = append(clusters, node{x: x / float64(count), y: y / float64(count), count: count, zoom: z})
+	}
+
+	return clusters
+}
+
+// neighbours returns the unvisited items within r of the item k.
+func (i *Index) neighbours(nodes []node, grid map[[2]int][]int, visited []bool, k int, r float64) []int {
+	n := nodes[k]
+	cell := cellOf(n, r)
+	neighbours := make([]int, 0)
+
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for _, j := range grid[[2]int{cell[0] + dx, cell[1] + dy}] {
+				if visited[j] {
+					continue
+				}
+
+				ddx, ddy := nodes[j].x-n.x, nodes[j].y-n.y
+				if ddx*ddx+ddy*ddy <= r*r {
+					neighbours = append(neighbours, j)
+				}
+			}
+		}
+	}
+
+	return neighbours
+}
+
+func cellOf(n node, r float64) [2]int {
+	return [2]int{int(math.Floor(n.x / r)), int(math.Floor(n.y / r))}
+}
+
+func lonX(lon float64) float64 {
+	return lon/360 + 0.5
+}
+
+func latY(lat float64) float64 {
+	sin := math.Sin(lat * math.Pi / 180)
+	y := 0.5 - 0.25*math.Log((1+sin)/(1-sin))/math.Pi
+
+	return max(0, min(y, 1))
+}
+
+func xLon(x float64) float64 {
+	return (x - 0.5) * 360
+}
+
+func yLat(y float64) float64 {
+	y2 := (180 - y*360) * math.Pi / 180
+	return 360*math.Atan(math.Exp(y2))/math.Pi - 90
+}
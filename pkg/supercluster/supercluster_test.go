@@ -0,0 +1,111 @@
+package supercluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newIndex() *Index {
+	index := New(DefaultOptions())
+	index.Load([]Point{
+		{ID: 1, Lon: 106.8271, Lat: -6.1754},
+		{ID: 2, Lon: 106.8272, Lat: -6.1755},
+		{ID: 3, Lon: 106.8456, Lat: -6.2088},
+		{ID: 4, Lon: 2.3522, Lat: 48.8566},
+	})
+
+	return index
+}
+
+func counts(clusters []Cluster) []int {
+	result := make([]int, len(clusters))
+	for i, c := range clusters {
+		result[i] = c.Count
+	}
+
+	return result
+}
+
+func TestIndex_Clusters(t *testing.T) {
+	index := newIndex()
+
+	clusters := index.Clusters(-180, -85, 180, 85, 0)
+	assert.ElementsMatch(t, []int{3, 1}, counts(clusters))
+
+	for _, c := range clusters {
+		if c.Count == 3 {
+			assert.InDelta(t, 106.8333, c.Lon, 1e-3)
+			assert.InDelta(t, -6.1866, c.Lat, 1e-3)
+			assert.Equal(t, uint(0), c.PointID)
+			assert.Greater(t, c.ExpansionZoom, 0)
+		} else {
+			assert.Equal(t, uint(4), c.PointID)
+			assert.Equal(t, 0, c.ExpansionZoom)
+		}
+	}
+}
+
+func TestIndex_ClustersExpansionZoom(t *testing.T) {
+	index := newIndex()
+
+	var zoom int
+	for _, c := range index.Clusters(100, -10, 110, 0, 0) {
+		zoom = c.ExpansionZoom
+	}
+
+	clusters := index.Clusters(100, -10, 110, 0, zoom)
+	assert.Greater(t, len(clusters), 1)
+
+	clusters = index.Clusters(100, -10, 110, 0, zoom-1)
+	assert.Equal(t, []int{3}, counts(clusters))
+}
+
+func TestIndex_ClustersPastMaxZoom(t *testing.T) {
+	index := newIndex()
+
+	clusters := index.Clusters(100, -10, 110, 0, 30)
+	assert.Equal(t, []int{1, 1, 1}, counts(clusters))
+	assert.Equal(t, uint(1), clusters[0].PointID)
+	assert.InDelta(t, 106.8271, clusters[0].Lon, 1e-9)
+	assert.InDelta(t, -6.1754, clusters[0].Lat, 1e-9)
+}
+
+func TestIndex_ClustersBBox(t *testing.T) {
+	index := newIndex()
+
+	clusters := index.Clusters(0, 40, 10, 50, 0)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, uint(4), clusters[0].PointID)
+}
+
+func TestIndex_InsertRemove(t *testing.T) {
+	index := newIndex()
+	assert.Equal(t, []int{3}, counts(index.Clusters(100, -10, 110, 0, 0)))
+
+	index.Insert(Point{ID: 5, Lon: 106.83, Lat: -6.18})
+	assert.Equal(t, 5, index.Len())
+	assert.Equal(t, []int{4}, counts(index.Clusters(100, -10, 110, 0, 0)))
+
+	index.Insert(Point{ID: 5, Lon: 2.35, Lat: 48.85})
+	assert.Equal(t, 5, index.Len())
+	assert.Equal(t, []int{3}, counts(index.Clusters(100, -10, 110, 0, 0)))
+
+	index.Remove(1)
+	index.Remove(42)
+	assert.Equal(t, 4, index.Len())
+	assert.Equal(t, []int{2}, counts(index.Clusters(100, -10, 110, 0, 0)))
+}
+
+func TestIndex_MinPoints(t *testing.T) {
+	options := DefaultOptions()
+	options.MinPoints = 4
+	index := New(options)
+	index.Load([]Point{
+		{ID: 1, Lon: 10, Lat: 10},
+		{ID: 2, Lon: 10.001, Lat: 10},
+		{ID: 3, Lon: 10, Lat: 10.001},
+	})
+
+	assert.Equal(t, []int{1, 1, 1}, counts(index.Clusters(0, 0, 20, 20, 0)))
+}
@@ -0,0 +1,242 @@
+// Package voronoi computes the Delaunay triangulation and the Voronoi cells
+// of planar sites, and clips rings to convex cells.
+package voronoi
+
+import "math"
+
+// Triangulate returns the Delaunay triangulation of the sites with the
+// Bowyer-Watson algorithm, as counterclockwise triangles of site indexes.
+// Duplicate sites are triangulated once and collinear sites yield none.
+func Triangulate(sites [][2]float64) [][3]int {
+	if len(sites) < 3 {
+		return nil
+	}
+
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, s := range sites {
+		minX, minY = math.Min(minX, s[0]), math.Min(minY, s[1])
+		maxX, maxY = math.Max(maxX, s[0]), math.Max(maxY, s[1])
+	}
+
+	size := math.Max(maxX-minX, maxY-minY)
+	if size == 0 {
+		return nil
+	}
+
+	// The super triangle encloses every site, it is dropped at the end.
+	n := len(sites)
+	midX, midY := (minX+maxX)/2, (minY+maxY)/2
+	points := make([][2]float64, n, n+3)
+	copy(points, sites)
+	points = append(points, [2]float64{midX - 20*size, midY - size}, [2]float64{midX + 20*size, midY - size}, [2]float64{midX, midY + 20*size})
+
+	triangles := []triangle{newTriangle(points, n, n+1, n+2)}
+	for i := 0; i < n; i++ {
+		triangles = insert(points, triangles, i)
+	}
+
+	result := make([][3]int, 0, len(triangles))
+	for _, t := range triangles {
+		if t.v[0] < n && t.v[1] < n && t.v[2] < n {
+			result = append(result, t.v)
+		}
+	}
+
+	return result
+}
+
+type triangle struct {
+	v [3]int
+	// cx, cy and r2 are the centre and squared radius of the circumcircle.
+	cx, cy, r2 float64
+}
+
+// newTriangle returns the counterclockwise triangle a, b, c with its
+// circumcircle, infinite when the vertices are collinear.
+func newTriangle(points [][2]float64, a, b, c int) triangle {
+	if cross(points[a], points[b], points[c]) < 0 {
+		b, c = c, b
+	}
+
+	pa, pb, pc := points[a], points[b], points[c]
+	d := 2 * (pa[0]*(pb[1]-pc[1]) + pb[0]*(pc[1]-pa[1]) + pc[0]*(pa[1]-pb[1]))
+	if d == 0 {
+		return triangle{v: [3]int{a, b, c}, r2: math.Inf(1)}
+	}
+
+	sa, sb, sc := pa[0]*pa[0]+pa[1]*pa[1], pb[0]*pb[0]+pb[1]*pb[1], pc[0]*pc[0]+pc[1]*pc[1]
+	cx := (sa*(pb[1]-pc[1]) + sb*(pc[1]-pa[1]) + sc*(pa[1]-pb[1])) / d
+	cy := (sa*(pc[0]-pb[0]) + sb*(pa[0]-pc[0]) + sc*(pb[0]-pa[0])) / d
+
+	return triangle{v: [3]int{a, b, c}, cx: cx, cy: cy, r2: (pa[0]-cx)*(pa[0]-cx) + (pa[1]-cy)*(pa[1]-cy)}
+}
+
+// insert adds point i to the triangulation: the triangles whose circumcircle
+// holds it are replaced by a fan joining it to the boundary of their union.
+func insert(points [][2]float64, triangles []triangle, i int) []triangle {
+	p := points[i]
+	kept := triangles[:0:0]
+	edges := make(map[[2]int]int)
+
+	for _, t := range triangles {
+		dx, dy := p[0]-t.cx, p[1]-t.cy
+		if !(dx*dx+dy*dy < t.r2) {
+			kept = append(kept, t)
+			continue
+		}
+
+		for k := 0; k < 3; k++ {
+			a, b := t.v[k], t.v[(k+1)%3]
+			if a > b {
+				a, b = b, a
+			}
+
+			edges[[2]int{a, b}]++
+		}
+	}
+
+	for edge, count := range edges {
+		if count == 1 {
+			kept = append(kept, newTriangle(points, edge[0], edge[1], i))
+		}
+	}
+
+	return kept
+}
+
+// Cells returns the Voronoi cell of every site clipped to the clip ring, as
+// closed rings in the order of the sites, nil for a cell outside the ring.
+// Each cell is the ring cut by the bisectors of its site and the others,
+// which only yields valid rings for a convex clip ring: see IsConvex.
+func Cells(sites [][2]float64, clip [][2]float64) [][][2]float64 {
+	cells := make([][][2]float64, len(sites))
+
+	for i, s := range sites {
+		cell := open(clip)
+		for j, o := range sites {
+			if j == i || o == s || len(cell) == 0 {
+				continue
+			}
+
+			// Keep the side of the bisector closer to s.
+			nx, ny := o[0]-s[0], o[1]-s[1]
+			c := (nx*(o[0]+s[0]) + ny*(o[1]+s[1])) / 2
+			cell = clipHalfPlane(cell, nx, ny, c)
+		}
+
+		if len(cell) >= 3 {
+			cells[i] = append(cell, cell[0])
+		}
+	}
+
+	return cells
+}
+
+// ClipConvex clips a ring to a convex counterclockwise polygon and returns it
+// closed, or nil when nothing but a point or a segment of it is left. The
+// ring must be convex as well: a concave one split in several parts would
+// come back as one ring joining them along the edges of the polygon.
+func ClipConvex(ring, convex [][2]float64) [][2]float64 {
+	result := open(ring)
+	edges := open(convex)
+
+	for k, a := range edges {
+		if len(result) == 0 {
+			return nil
+		}
+
+		// Keep the left of the edge from a to b.
+		b := edges[(k+1)%len(edges)]
+		nx, ny := b[1]-a[1], a[0]-b[0]
+		result = clipHalfPlane(result, nx, ny, nx*a[0]+ny*a[1])
+	}
+
+	if len(result) < 3 || math.Abs(area(result)) <= 1e-9*math.Abs(area(edges)) {
+		return nil
+	}
+
+	return append(result, result[0])
+}
+
+// IsConvex reports whether a ring, closed or not, is a convex polygon: it
+// turns the same way at every vertex and only once around. Collinear
+// vertices are allowed.
+func IsConvex(ring [][2]float64) bool {
+	ring = open(ring)
+	if len(ring) < 3 {
+		return false
+	}
+
+	sign, turning := 0.0, 0.0
+	for k, b := range ring {
+		a := ring[(k+len(ring)-1)%len(ring)]
+		c := ring[(k+1)%len(ring)]
+
+		turn := cross(a, b, c)
+		if turn == 0 {
+			continue
+		}
+
+		if sign == 0 {
+			sign = turn
+		} else if (turn > 0) != (sign > 0) {
+			return false
+		}
+
+		turning += math.Atan2(turn, (b[0]-a[0])*(c[0]-b[0])+(b[1]-a[1])*(c[1]-b[1]))
+	}
+
+	// A star turns the same way at every vertex, but more than once around.
+	return sign != 0 && math.Abs(math.Abs(turning)-2*math.Pi) < 1e-6
+}
+
+// clipHalfPlane clips an open ring to nx*x + ny*y <= c, Sutherland-Hodgman
+// style.
+func clipHalfPlane(ring [][2]float64, nx, ny, c float64) [][2]float64 {
+	result := make([][2]float64, 0, len(ring)+1)
+
+	for k, cur := range ring {
+		prev := ring[(k+len(ring)-1)%len(ring)]
+		dCur := nx*cur[0] + ny*cur[1] - c
+		dPrev := nx*prev[0] + ny*prev[1] - c
+
+		if (dCur < 0 && dPrev > 0) || (dCur > 0 && dPrev < 0) {
+			t := dPrev / (dPrev - dCur)
+			result = append(result, [2]float64{prev[0] + t*(cur[0]-prev[0]), prev[1] + t*(cur[1]-prev[1])})
+		}
+
+		if dCur <= 0 {
+			result = append(result, cur)
+		}
+	}
+
+	return result
+}
+
+// open returns a copy of a ring without its closing position.
+func open(ring [][2]float64) [][2]float64 {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+
+	result := make([][2]float64, len(ring))
+	copy(result, ring)
+
+	return result
+}
+
+// area returns the signed area of a ring, closed or not, positive when it is
+// counterclockwise.
+func area(ring [][2]float64) float64 {
+	sum := 0.0
+	for k, a := range ring {
+		b := ring[(k+1)%len(ring)]
+		sum += a[0]*b[1] - b[0]*a[1]
+	}
+
+	return sum / 2
+}
+
+func cross(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
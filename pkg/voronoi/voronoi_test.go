@@ -0,0 +1,135 @@
+package voronoi
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriangulate(t *testing.T) {
+	t.Run("Square", func(t *testing.T) {
+		sites := [][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 1.2}}
+		triangles := Triangulate(sites)
+
+		assert.Len(t, triangles, 4)
+		for _, tri := range triangles {
+			assert.Contains(t, tri, 4)
+			assert.Greater(t, cross(sites[tri[0]], sites[tri[1]], sites[tri[2]]), 0.0)
+		}
+	})
+
+	t.Run("EmptyCircumcircles", func(t *testing.T) {
+		sites := make([][2]float64, 0)
+		for i := 0; i < 40; i++ {
+			sites = append(sites, [2]float64{math.Mod(float64(i)*7.31, 10), math.Mod(float64(i*i)*3.17, 10)})
+		}
+
+		triangles := Triangulate(sites)
+		assert.NotEmpty(t, triangles)
+
+		for _, tri := range triangles {
+			c := newTriangle(sites, tri[0], tri[1], tri[2])
+			for k, s := range sites {
+				if k == tri[0] || k == tri[1] || k == tri[2] {
+					continue
+				}
+
+				dx, dy := s[0]-c.cx, s[1]-c.cy
+				assert.GreaterOrEqual(t, dx*dx+dy*dy, c.r2*(1-1e-9))
+			}
+		}
+	})
+
+	t.Run("Degenerate", func(t *testing.T) {
+		assert.Empty(t, Triangulate([][2]float64{{0, 0}, {1, 1}}))
+		assert.Empty(t, Triangulate([][2]float64{{0, 0}, {1, 1}, {2, 2}, {3, 3}}))
+		assert.Empty(t, Triangulate([][2]float64{{1, 1}, {1, 1}, {1, 1}}))
+	})
+}
+
+func TestCells(t *testing.T) {
+	clip := [][2]float64{{0, 0}, {4, 0}, {4, 2}, {0, 2}, {0, 0}}
+
+	t.Run("SplitsClip", func(t *testing.T) {
+		cells := Cells([][2]float64{{1, 1}, {3, 1}}, clip)
+
+		assert.Len(t, cells, 2)
+		assert.InDelta(t, 4, area(cells[0]), 1e-9)
+		assert.InDelta(t, 4, area(cells[1]), 1e-9)
+
+		xs := make([]float64, 0)
+		for _, p := range cells[0] {
+			xs = append(xs, p[0])
+		}
+
+		sort.Float64s(xs)
+		assert.Equal(t, 2.0, xs[len(xs)-1])
+	})
+
+	t.Run("CoversClip", func(t *testing.T) {
+		sites := [][2]float64{{0.5, 0.5}, {3.5, 0.2}, {2, 1.5}, {1, 1.8}, {3, 1}}
+		var total float64
+		for _, cell := range Cells(sites, clip) {
+			assert.Equal(t, cell[0], cell[len(cell)-1])
+			total += area(cell)
+		}
+
+		assert.InDelta(t, 8, total, 1e-9)
+	})
+
+	t.Run("SingleSite", func(t *testing.T) {
+		assert.Equal(t, [][][2]float64{clip}, Cells([][2]float64{{1, 1}}, clip))
+	})
+
+	t.Run("SiteOutsideClip", func(t *testing.T) {
+		cells := Cells([][2]float64{{1, 1}, {10, 1}}, clip)
+		assert.InDelta(t, 8, area(cells[0]), 1e-9)
+		assert.Nil(t, cells[1])
+	})
+}
+
+func TestClipConvex(t *testing.T) {
+	square := [][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}
+
+	t.Run("Overlap", func(t *testing.T) {
+		ring := ClipConvex([][2]float64{{1, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 1}}, square)
+		assert.Equal(t, ring[0], ring[len(ring)-1])
+		assert.InDelta(t, 1, area(ring), 1e-9)
+	})
+
+	t.Run("Inside", func(t *testing.T) {
+		ring := [][2]float64{{0.5, 0.5}, {1.5, 0.5}, {1, 1.5}, {0.5, 0.5}}
+		assert.Equal(t, ring, ClipConvex(ring, square))
+	})
+
+	t.Run("Concave", func(t *testing.T) {
+		// A U shape whose arms leave the square through its top.
+		u := [][2]float64{{0, 1}, {3, 1}, {3, 4}, {2.5, 4}, {2.5, 1.5}, {0.5, 1.5}, {0.5, 4}, {0, 4}, {0, 1}}
+		ring := ClipConvex(u, square)
+		assert.InDelta(t, 2*1-1.5*0.5, area(ring), 1e-9)
+	})
+
+	t.Run("Outside", func(t *testing.T) {
+		assert.Nil(t, ClipConvex([][2]float64{{3, 3}, {4, 3}, {4, 4}, {3, 3}}, square))
+	})
+
+	t.Run("Touching", func(t *testing.T) {
+		assert.Nil(t, ClipConvex([][2]float64{{2, 0}, {3, 0}, {3, 2}, {2, 2}, {2, 0}}, square))
+	})
+}
+
+func TestIsConvex(t *testing.T) {
+	assert.True(t, IsConvex([][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}))
+	assert.True(t, IsConvex([][2]float64{{0, 0}, {0, 2}, {2, 2}, {2, 0}}), "clockwise and open")
+	assert.True(t, IsConvex([][2]float64{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}), "collinear vertex")
+
+	u := [][2]float64{{0, 1}, {3, 1}, {3, 4}, {2.5, 4}, {2.5, 1.5}, {0.5, 1.5}, {0.5, 4}, {0, 4}, {0, 1}}
+	assert.False(t, IsConvex(u))
+
+	star := [][2]float64{{0, 3}, {2, -3}, {-3, 1}, {3, 1}, {-2, -3}, {0, 3}}
+	assert.False(t, IsConvex(star))
+
+	assert.False(t, IsConvex([][2]float64{{0, 0}, {1, 1}, {2, 2}, {0, 0}}), "flat")
+}